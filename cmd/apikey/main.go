@@ -0,0 +1,128 @@
+package main
+
+import (
+	"PocGo/internal/auth"
+	config "PocGo/internal/configuration"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/logging"
+	repository "PocGo/internal/repositories"
+	dataBaseConnection "PocGo/pkg/database"
+	"context"
+	"flag"
+	configIO "fmt"
+	"log/slog"
+	setIO "os"
+	"strings"
+	"time"
+)
+
+const usage = `Uso: apikey [-env development] <comando>
+
+Comandos:
+  create <nome> [papéis separados por vírgula] [validade, ex: 720h]
+                 cria uma chave e a exibe uma única vez
+  revoke <id>    revoga a chave informada
+`
+
+func main() {
+	env := flag.String("env", "development", "ambiente usado para carregar o arquivo .env.<env>")
+	flag.Usage = func() {
+		configIO.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 2 {
+		flag.Usage()
+		setIO.Exit(2)
+	}
+
+	configuration := config.LoadConfig(*env)
+
+	logger, err := logging.New(configuration.Log)
+	if err != nil {
+		fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	backend, err := repository.GetBackend(configuration.Database.Driver)
+	if err != nil {
+		fatal(err)
+	}
+
+	if !backend.RequiresConnection {
+		configIO.Printf("O driver %s não persiste as chaves; use sqlserver ou sqlite.\n", backend.Name)
+		setIO.Exit(1)
+	}
+
+	dbInstance, err := dataBaseConnection.NewConnection(configuration)
+	if err != nil {
+		fatal(err)
+	}
+	defer dbInstance.Close()
+
+	repos, err := repository.NewRepositories(configuration.Database.Driver, dbInstance.GetConnection(), configuration.Timeout, logger)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := run(context.Background(), repos.ApiKey, flag.Args()); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	slog.Error("Erro ao gerenciar as chaves de API", logging.KeyError, err)
+	setIO.Exit(1)
+}
+
+func run(ctx context.Context, keys repository.ApiKeyRepository, args []string) error {
+	switch args[0] {
+	case "create":
+		return create(ctx, keys, args[1:])
+	case "revoke":
+		id, err := values.ParseGUID(args[1])
+		if err != nil {
+			return configIO.Errorf("id inválido %q: %w", args[1], err)
+		}
+		if err := keys.Revoke(ctx, id, time.Now()); err != nil {
+			return err
+		}
+		configIO.Printf("Chave %s revogada\n", id)
+		return nil
+	default:
+		flag.Usage()
+		setIO.Exit(2)
+		return nil
+	}
+}
+
+func create(ctx context.Context, keys repository.ApiKeyRepository, args []string) error {
+	var roles []string
+	if len(args) > 1 && args[1] != "" {
+		for _, role := range strings.Split(args[1], ",") {
+			roles = append(roles, strings.TrimSpace(role))
+		}
+	}
+
+	now := time.Now()
+	var expiresAt *time.Time
+	if len(args) > 2 {
+		validity, err := time.ParseDuration(args[2])
+		if err != nil {
+			return configIO.Errorf("validade inválida %q: %w", args[2], err)
+		}
+		expiration := now.Add(validity).UTC()
+		expiresAt = &expiration
+	}
+
+	key, apiKey := auth.NewApiKey(args[0], roles, expiresAt, now)
+	if err := keys.Create(ctx, &apiKey); err != nil {
+		return err
+	}
+
+	configIO.Printf("Chave criada: id %s, prefixo %s\n", apiKey.ID, apiKey.Prefix)
+	configIO.Printf("%s\n", key)
+	configIO.Println("Guarde a chave agora: ela não pode ser exibida novamente.")
+	return nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	config "PocGo/internal/configuration"
+	"PocGo/internal/logging"
+	migration "PocGo/internal/migrations"
+	repository "PocGo/internal/repositories"
+	dataBaseConnection "PocGo/pkg/database"
+	"context"
+	"flag"
+	configIO "fmt"
+	"log/slog"
+	setIO "os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const usage = `Uso: migrate [-env development] <comando>
+
+Comandos:
+  up             aplica todas as migrações pendentes
+  down           reverte a última migração aplicada
+  status         lista as migrações e seu estado
+  goto <versão>  aplica ou reverte até a versão informada (0 reverte tudo)
+`
+
+func main() {
+	env := flag.String("env", "development", "ambiente usado para carregar o arquivo .env.<env>")
+	flag.Usage = func() {
+		configIO.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		setIO.Exit(2)
+	}
+
+	configuration := config.LoadConfig(*env)
+
+	logger, err := logging.New(configuration.Log)
+	if err != nil {
+		fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	backend, err := repository.GetBackend(configuration.Database.Driver)
+	if err != nil {
+		fatal(err)
+	}
+
+	if !backend.RequiresConnection {
+		configIO.Printf("O driver %s não possui esquema; nada a migrar.\n", backend.Name)
+		return
+	}
+
+	dbInstance, err := dataBaseConnection.NewConnection(configuration)
+	if err != nil {
+		fatal(err)
+	}
+	defer dbInstance.Close()
+
+	runner, err := migration.NewRunner(configuration.Database.Driver, dbInstance.GetConnection(), logger)
+	if err != nil {
+		fatal(err)
+	}
+
+	if err := run(context.Background(), runner, flag.Args()); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	slog.Error("Erro ao executar as migrações", logging.KeyError, err)
+	setIO.Exit(1)
+}
+
+func run(ctx context.Context, runner *migration.Runner, args []string) error {
+	switch args[0] {
+	case "up":
+		count, err := runner.Up(ctx)
+		configIO.Printf("%d migração(ões) aplicada(s)\n", count)
+		return err
+	case "down":
+		count, err := runner.Down(ctx)
+		configIO.Printf("%d migração(ões) revertida(s)\n", count)
+		return err
+	case "goto":
+		if len(args) < 2 {
+			return configIO.Errorf("informe a versão de destino")
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return configIO.Errorf("versão inválida %q: %w", args[1], err)
+		}
+		count, err := runner.Goto(ctx, version)
+		configIO.Printf("%d migração(ões) executada(s)\n", count)
+		return err
+	case "status":
+		return printStatus(ctx, runner)
+	default:
+		flag.Usage()
+		setIO.Exit(2)
+		return nil
+	}
+}
+
+func printStatus(ctx context.Context, runner *migration.Runner) error {
+	statuses, err := runner.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(setIO.Stdout, 0, 4, 2, ' ', 0)
+	configIO.Fprintln(writer, "VERSÃO\tNOME\tESTADO\tAPLICADA EM")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		configIO.Fprintf(writer, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+	}
+	return writer.Flush()
+}
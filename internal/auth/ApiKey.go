@@ -0,0 +1,40 @@
+package auth
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// ApiKeyPrefix starts every generated key, so secret scanners can find leaked keys.
+const ApiKeyPrefix = "pocgo_"
+
+// displayedKeyLength is the part of a key kept in ApiKey.Prefix to identify it.
+const displayedKeyLength = len(ApiKeyPrefix) + 8
+
+// NewApiKey generates a random key and returns it with the entity to store, which only holds its hash.
+// The key cannot be recovered afterwards.
+func NewApiKey(name string, roles []string, expiresAt *time.Time, now time.Time) (string, entity.ApiKey) {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	key := ApiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return key, entity.ApiKey{
+		Name:      name,
+		Prefix:    key[:displayedKeyLength],
+		Hash:      HashApiKey(key),
+		Roles:     roles,
+		CreatedAt: now.UTC(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// HashApiKey returns the hexadecimal SHA-256 of key, as stored. Keys are random 256-bit values,
+// so a fast hash is enough and lets the key be found by its hash.
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
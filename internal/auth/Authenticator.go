@@ -0,0 +1,104 @@
+package auth
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	handlerBase "PocGo/internal/handler/base"
+	"PocGo/internal/logging"
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	httpclient "net/http"
+	"strings"
+	"time"
+)
+
+// ApiKeyHeader carries the API key of machine clients.
+const ApiKeyHeader = "X-Api-Key"
+
+// ApiKeyStore finds API keys by hash; repositories.ApiKeyRepository satisfies it.
+type ApiKeyStore interface {
+	FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error)
+}
+
+// Authenticator resolves the principal of a request from a bearer token or an API key.
+type Authenticator struct {
+	tokens *JwtVerifier
+	keys   ApiKeyStore
+	now    func() time.Time
+}
+
+// NewAuthenticator builds an Authenticator; tokens may be nil to reject bearer tokens and keys nil to reject API keys.
+func NewAuthenticator(tokens *JwtVerifier, keys ApiKeyStore) *Authenticator {
+	return &Authenticator{tokens: tokens, keys: keys, now: time.Now}
+}
+
+// Authenticate reads the X-Api-Key header or, when absent, the Authorization bearer token. Missing or invalid
+// credentials are UNAUTHENTICATED; a failure to look the key up is returned as is.
+func (authenticator *Authenticator) Authenticate(request *httpclient.Request) (Principal, error) {
+	if key := request.Header.Get(ApiKeyHeader); key != "" {
+		return authenticator.authenticateKey(request.Context(), key)
+	}
+
+	authorization := request.Header.Get("Authorization")
+	if authorization == "" {
+		return Principal{}, unauthenticated("credenciais ausentes")
+	}
+
+	scheme, token, _ := strings.Cut(authorization, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return Principal{}, unauthenticated("esquema " + scheme + " não suportado")
+	}
+	if authenticator.tokens == nil {
+		return Principal{}, unauthenticated("tokens não são aceitos")
+	}
+
+	principal, err := authenticator.tokens.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return Principal{}, unauthenticated(err)
+	}
+	return principal, nil
+}
+
+func (authenticator *Authenticator) authenticateKey(ctx context.Context, raw string) (Principal, error) {
+	if authenticator.keys == nil {
+		return Principal{}, unauthenticated("chaves de API não são aceitas")
+	}
+
+	key, err := authenticator.keys.FindByHash(ctx, HashApiKey(raw))
+	if notify.HasCode(err, notify.CodeNotFound) {
+		return Principal{}, unauthenticated("chave de API inválida")
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	if !key.IsActive(authenticator.now()) {
+		return Principal{}, unauthenticated("chave de API revogada ou expirada")
+	}
+
+	return Principal{Subject: key.ID.String(), Name: key.Name, Kind: PrincipalApiKey, Roles: key.Roles}, nil
+}
+
+// Require is the middleware of the protected routes. Requests without valid credentials get 401 with a
+// WWW-Authenticate challenge; the others reach next with the principal in the context, the logs and the span.
+func (authenticator *Authenticator) Require(next httpclient.Handler) httpclient.Handler {
+	return httpclient.HandlerFunc(func(w httpclient.ResponseWriter, request *httpclient.Request) {
+		principal, err := authenticator.Authenticate(request)
+		if err != nil {
+			if notify.HasCode(err, notify.CodeUnauthenticated) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="PocGo"`)
+			}
+			handlerBase.SendErrorResponse(w, request, err)
+			return
+		}
+
+		trace.SpanFromContext(request.Context()).SetAttributes(attribute.String("enduser.id", principal.Subject))
+		ctx := WithPrincipal(request.Context(), principal)
+		ctx = logging.With(ctx, logging.KeyPrincipal, principal.Subject)
+		next.ServeHTTP(w, request.WithContext(ctx))
+	})
+}
+
+func unauthenticated(data any) error {
+	return notify.CreateCustomNotification(notify.Unauthenticated, "", data)
+}
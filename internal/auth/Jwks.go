@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	setJson "encoding/json"
+	configIO "fmt"
+	"math/big"
+	setIO "os"
+)
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// LoadJwks reads the RSA signing keys of the JSON Web Key Set at path, by kid. Keys of other types
+// and encryption keys are skipped; a set without any RSA signing key is an error.
+func LoadJwks(path string) (map[string]*rsa.PublicKey, error) {
+	content, err := setIO.ReadFile(path)
+	if err != nil {
+		return nil, configIO.Errorf("auth: erro ao ler o JWKS: %w", err)
+	}
+
+	var set jsonWebKeySet
+	if err := setJson.Unmarshal(content, &set); err != nil {
+		return nil, configIO.Errorf("auth: JWKS inválido: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, key := range set.Keys {
+		if key.Kty != "RSA" || (key.Use != "" && key.Use != "sig") {
+			continue
+		}
+
+		publicKey, err := key.rsaPublicKey()
+		if err != nil {
+			return nil, configIO.Errorf("auth: chave %q do JWKS inválida: %w", key.Kid, err)
+		}
+		keys[key.Kid] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return nil, configIO.Errorf("auth: o JWKS %s não possui chaves RSA de assinatura", path)
+	}
+	return keys, nil
+}
+
+func (key jsonWebKey) rsaPublicKey() (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+
+	e := new(big.Int).SetBytes(exponent)
+	if len(modulus) == 0 || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+		return nil, configIO.Errorf("módulo ou expoente inválido")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(e.Int64())}, nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"crypto/rsa"
+	"errors"
+	configIO "fmt"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minSecretLength is the shortest HS256 secret accepted, the size of the SHA-256 output.
+const minSecretLength = 32
+
+// tokenClaims are the claims read from a bearer token; roles is a custom claim listing the caller roles.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// JwtVerifier checks bearer tokens signed with HS256 or RS256. Only the algorithms with a configured key are
+// accepted, so a token cannot pick another one (such as "none" or HS256 signed with the RSA public key).
+type JwtVerifier struct {
+	secret []byte
+	keys   map[string]*rsa.PublicKey
+	parser *jwt.Parser
+}
+
+// NewJwtVerifier returns nil when configuration has neither a secret nor a JWKS file.
+func NewJwtVerifier(configuration *provider.AuthConfig) (*JwtVerifier, error) {
+	verifier := &JwtVerifier{}
+	var methods []string
+
+	if configuration.JwtSecret != "" {
+		if len(configuration.JwtSecret) < minSecretLength {
+			return nil, configIO.Errorf("auth: o segredo HS256 deve ter ao menos %d bytes", minSecretLength)
+		}
+		verifier.secret = []byte(configuration.JwtSecret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if configuration.JwksFile != "" {
+		keys, err := LoadJwks(configuration.JwksFile)
+		if err != nil {
+			return nil, err
+		}
+		verifier.keys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	if len(methods) == 0 {
+		return nil, nil
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(configuration.Leeway),
+	}
+	if configuration.Issuer != "" {
+		options = append(options, jwt.WithIssuer(configuration.Issuer))
+	}
+	if configuration.Audience != "" {
+		options = append(options, jwt.WithAudience(configuration.Audience))
+	}
+	verifier.parser = jwt.NewParser(options...)
+	return verifier, nil
+}
+
+// Verify checks the signature, expiry, issuer and audience of token and returns its principal.
+func (verifier *JwtVerifier) Verify(token string) (Principal, error) {
+	claims := &tokenClaims{}
+	if _, err := verifier.parser.ParseWithClaims(token, claims, verifier.key); err != nil {
+		return Principal{}, err
+	}
+	if claims.Subject == "" {
+		return Principal{}, errors.New("token sem a claim sub")
+	}
+
+	return Principal{Subject: claims.Subject, Name: claims.Name, Kind: PrincipalUser, Roles: claims.Roles}, nil
+}
+
+// key returns the key verifying token: the secret for HS256, or the JWKS key named by the kid header for RS256.
+// A token without kid is accepted when the set has a single key.
+func (verifier *JwtVerifier) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return verifier.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" && len(verifier.keys) == 1 {
+			for _, key := range verifier.keys {
+				return key, nil
+			}
+		}
+		if key, exists := verifier.keys[kid]; exists {
+			return key, nil
+		}
+		return nil, configIO.Errorf("chave %q desconhecida", kid)
+	default:
+		return nil, configIO.Errorf("algoritmo %q não suportado", token.Method.Alg())
+	}
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Kinds of Principal.
+const (
+	PrincipalUser   = "user"
+	PrincipalApiKey = "api_key"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject is the sub claim of a bearer token or the ID of an API key.
+	Subject string
+	// Name is the name claim of a token or the name of an API key; it may be empty.
+	Name  string
+	Kind  string
+	Roles []string
+}
+
+func (principal Principal) HasRole(role string) bool {
+	return slices.Contains(principal.Roles, role)
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying principal, which PrincipalFrom returns further down the call.
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFrom returns the principal stored by WithPrincipal; false on public routes.
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
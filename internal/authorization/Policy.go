@@ -0,0 +1,134 @@
+package authorization
+
+import (
+	"PocGo/internal/auth"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/logging"
+	"context"
+	setJson "encoding/json"
+	configIO "fmt"
+	setIO "os"
+	"strings"
+)
+
+// Permission is an operation a role may perform.
+type Permission string
+
+const (
+	UsersRead Permission = "users:read"
+	// UsersCreate allows creating users.
+	UsersCreate Permission = "users:create"
+	// UsersUpdate allows changing the name and email of a user.
+	UsersUpdate Permission = "users:update"
+	// UsersUpdateStatus allows changing the status of a user, including reactivation and the inactive-user sweep.
+	UsersUpdateStatus Permission = "users:update_status"
+	UsersDelete       Permission = "users:delete"
+	UsersRestore      Permission = "users:restore"
+	JobsRead          Permission = "jobs:read"
+	JobsRun           Permission = "jobs:run"
+)
+
+var knownPermissions = map[Permission]bool{
+	UsersRead: true, UsersCreate: true, UsersUpdate: true, UsersUpdateStatus: true,
+	UsersDelete: true, UsersRestore: true, JobsRead: true, JobsRun: true,
+}
+
+// RoleSelf is held by every principal, but only over the user whose ID is the principal subject.
+const RoleSelf = "self"
+
+// Policy grants permissions to roles. It is read from a JSON file such as
+//
+//	{"roles": {"admin": ["users:read", "users:update"], "self": ["users:read"]}}
+type Policy struct {
+	roles map[string]map[Permission]bool
+}
+
+type policyFile struct {
+	Roles map[string][]Permission `json:"roles"`
+}
+
+// LoadPolicy reads the policy file at path, rejecting unknown permissions so a typo does not silently deny access.
+func LoadPolicy(path string) (*Policy, error) {
+	content, err := setIO.ReadFile(path)
+	if err != nil {
+		return nil, configIO.Errorf("authorization: erro ao ler a política: %w", err)
+	}
+	return ParsePolicy(content)
+}
+
+// ParsePolicy reads a policy in the format of LoadPolicy.
+func ParsePolicy(content []byte) (*Policy, error) {
+	var file policyFile
+	if err := setJson.Unmarshal(content, &file); err != nil {
+		return nil, configIO.Errorf("authorization: política inválida: %w", err)
+	}
+
+	policy := &Policy{roles: make(map[string]map[Permission]bool, len(file.Roles))}
+	for role, permissions := range file.Roles {
+		granted := make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			if !knownPermissions[permission] {
+				return nil, configIO.Errorf("authorization: permissão desconhecida %q no papel %q", permission, role)
+			}
+			granted[permission] = true
+		}
+		policy.roles[role] = granted
+	}
+	return policy, nil
+}
+
+// Allows reports whether principal holds permission through one of its roles or, when owner is the
+// principal's own user ID, through RoleSelf. owner is empty for operations not aimed at a single user.
+func (policy *Policy) Allows(principal auth.Principal, permission Permission, owner string) bool {
+	for _, role := range principal.Roles {
+		if role != RoleSelf && policy.roles[role][permission] {
+			return true
+		}
+	}
+	return policy.roles[RoleSelf][permission] && isSelf(principal, owner)
+}
+
+// Authorize returns a FORBIDDEN DomainError unless the principal of ctx may perform permission on owner,
+// see Allows. Calls without a principal, made by scheduled jobs or with authentication disabled, and
+// a nil policy are not restricted.
+func (policy *Policy) Authorize(ctx context.Context, permission Permission, owner string) error {
+	return policy.AuthorizeAny(ctx, owner, permission)
+}
+
+// AuthorizeAny is Authorize for operations allowed by any of permissions.
+func (policy *Policy) AuthorizeAny(ctx context.Context, owner string, permissions ...Permission) error {
+	if policy == nil {
+		return nil
+	}
+
+	principal, authenticated := auth.PrincipalFrom(ctx)
+	if !authenticated {
+		return nil
+	}
+	for _, permission := range permissions {
+		if policy.Allows(principal, permission, owner) {
+			return nil
+		}
+	}
+
+	names := make([]string, len(permissions))
+	for i, permission := range permissions {
+		names[i] = string(permission)
+	}
+	logging.FromContext(ctx, nil).Warn("Acesso negado", "permissions", names, "roles", principal.Roles)
+	return notify.CreateCustomNotification(notify.Forbidden, "", "permissão "+strings.Join(names, " ou ")+" necessária")
+}
+
+func isSelf(principal auth.Principal, owner string) bool {
+	if owner == "" || principal.Kind != auth.PrincipalUser {
+		return false
+	}
+
+	subject, err := values.ParseGUID(principal.Subject)
+	if err != nil {
+		return false
+	}
+	target, err := values.ParseGUID(owner)
+	return err == nil && subject == target
+}
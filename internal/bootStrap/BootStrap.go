@@ -1,17 +1,26 @@
 package bootStrap
 
 import (
+	"PocGo/internal/auth"
+	"PocGo/internal/authorization"
 	config "PocGo/internal/configuration"
-	constant "PocGo/internal/domain/constants"
 	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/health"
+	"PocGo/internal/jobs"
+	"PocGo/internal/logging"
+	"PocGo/internal/metrics"
+	migration "PocGo/internal/migrations"
+	"PocGo/internal/ratelimit"
 	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
 	applicationServer "PocGo/internal/server"
 	service "PocGo/internal/services"
+	"PocGo/internal/tracing"
 	dataBaseConnection "PocGo/pkg/database"
 	"context"
 	dbProvider "database/sql"
-	logger "log"
-	"sync"
+	"log/slog"
+	setIO "os"
 	"time"
 )
 
@@ -19,133 +28,256 @@ type Application struct {
 	Server        *applicationServer.ApplicationServer
 	Configuration *config.Config
 	dataBase      *dbProvider.DB
-	schedulerDone chan struct{}
-	schedulerWg   sync.WaitGroup
+	Scheduler     *scheduler.Scheduler
+	Logger        *slog.Logger
+	Metrics       *metrics.Metrics
+	Health        *health.Health
+	services      *service.Services
+	// stopTracing flushes the spans still buffered by the exporter.
+	stopTracing func(context.Context) error
 }
 
+const (
+	schedulerStopTimeout = 10 * time.Second
+	tracingStopTimeout   = 5 * time.Second
+)
+
 func NewApplication() *Application {
 	configuration := config.LoadConfig("development")
-	dbInstance, err := dataBaseConnection.NewConnection(configuration)
 
+	logger, err := logging.New(configuration.Log)
 	if err != nil {
-		logger.Fatalf(notify.ErrorDbFatal, err)
+		slog.Error("Erro ao configurar o log", logging.KeyError, err)
+		setIO.Exit(1)
 	}
+	slog.SetDefault(logger)
 
-	defer func(dbInstance *dataBaseConnection.Database) {
-		err := dbInstance.Close()
-		if err != nil {
+	application := &Application{
+		Configuration: configuration,
+		Logger:        logger,
+		Metrics:       metrics.New(),
+	}
+
+	application.stopTracing, err = tracing.Setup(context.Background(), configuration.Tracing)
+	if err != nil {
+		application.fatal(notify.ErrorTracingFatal, err)
+	}
 
+	dataBase, err := application.setupDatabase()
+	if err != nil {
+		application.fatal(notify.ErrorDbFatal, err)
+	}
+	application.dataBase = dataBase
+
+	if configuration.Database.AutoMigrate {
+		if err := application.runMigrations(dataBase); err != nil {
+			application.fatal(notify.ErrorMigrationFatal, err)
 		}
-	}(dbInstance)
+	}
 
-	application := &Application{
-		Configuration: configuration,
-		dataBase:      dbInstance.GetConnection(),
-		schedulerDone: make(chan struct{}),
+	repositories, err := application.setupRepositories(dataBase)
+	if err != nil {
+		application.fatal(notify.ErrorRepositoryFatal, err)
+	}
+
+	jobScheduler, err := application.setupScheduler(repositories)
+	if err != nil {
+		application.fatal(notify.ErrorSchedulerFatal, err)
+	}
+	application.Scheduler = jobScheduler
+
+	application.Health, err = application.setupHealth(dataBase)
+	if err != nil {
+		application.fatal(notify.ErrorHealthFatal, err)
+	}
+
+	policy, err := application.setupPolicy()
+	if err != nil {
+		application.fatal(notify.ErrorPolicyFatal, err)
+	}
+
+	application.services = application.setupServices(repositories, policy)
+
+	if err := application.registerJobs(); err != nil {
+		application.fatal(notify.ErrorSchedulerFatal, err)
 	}
 
-	dataBase, _ := application.setupDatabase()
-	repositories, _ := application.setupRepositories(dataBase)
-	services := application.setupServices(repositories)
-	server := application.setupServer(services)
+	authenticator, err := application.setupAuthenticator(repositories)
+	if err != nil {
+		application.fatal(notify.ErrorAuthFatal, err)
+	}
 
-	application.Server = server
+	limiter, err := application.setupLimiter()
+	if err != nil {
+		application.fatal(notify.ErrorRateLimitFatal, err)
+	}
+
+	application.Server = application.setupServer(application.services, authenticator, limiter)
 	return application
 }
 
+// fatal logs a startup failure and exits, as the application cannot run without the failed dependency.
+func (app *Application) fatal(message string, err error) {
+	app.Logger.Error(message, logging.KeyError, err)
+	setIO.Exit(1)
+}
+
+// setupDatabase opens the connection required by the configured backend.
+// Backends that keep their data in memory do not get a connection.
 func (app *Application) setupDatabase() (*dbProvider.DB, error) {
+	backend, err := repository.GetBackend(app.Configuration.Database.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	if !backend.RequiresConnection {
+		return nil, nil
+	}
+
 	dataBase, err := dataBaseConnection.NewConnection(app.Configuration)
 	if err != nil {
-		logger.Fatalf(notify.ErrorDbFatal, err)
+		return nil, err
+	}
+	if err := app.Metrics.RegisterDatabase(backend.Name, dataBase); err != nil {
+		return nil, err
+	}
+	return dataBase.GetConnection(), nil
+}
+
+// runMigrations brings the schema up to date before the repositories start using it.
+// In-memory backends have no schema, so there is nothing to run.
+func (app *Application) runMigrations(db *dbProvider.DB) error {
+	if db == nil {
+		return nil
+	}
+
+	runner, err := migration.NewRunner(app.Configuration.Database.Driver, db, app.Logger)
+	if err != nil {
+		return err
 	}
-	return dataBase.GetConnection(), err
+
+	_, err = runner.Up(context.Background())
+	return err
 }
 
 func (app *Application) setupRepositories(db *dbProvider.DB) (*repository.Repositories, error) {
-	return repository.NewRepositories(db)
+	return repository.NewRepositories(app.Configuration.Database.Driver, db, app.Configuration.Timeout, app.Logger)
 }
 
-func (app *Application) setupServices(repos *repository.Repositories) *service.Services {
-	return service.NewServices(repos)
+func (app *Application) setupServices(repos *repository.Repositories, policy *authorization.Policy) *service.Services {
+	return service.NewServices(repos, app.Scheduler, app.Logger, policy)
 }
 
-func (app *Application) setupServer(services *service.Services) *applicationServer.ApplicationServer {
-	return applicationServer.NewServer(services)
+// setupPolicy loads the role permissions from AUTH_POLICY_FILE. Without authentication there is no
+// principal to check, so no policy is loaded.
+func (app *Application) setupPolicy() (*authorization.Policy, error) {
+	if !app.Configuration.Auth.Enabled {
+		return nil, nil
+	}
+	return authorization.LoadPolicy(app.Configuration.Auth.PolicyFile)
 }
 
-func (app *Application) runUpdateOldUsersStatus() {
-	repos, err := app.setupRepositories(app.dataBase)
-	if err != nil {
-		logger.Printf(notify.ErrorRepositoryFatal, err)
-		return
+func (app *Application) setupServer(services *service.Services, authenticator *auth.Authenticator, limiter *ratelimit.Limiter) *applicationServer.ApplicationServer {
+	return applicationServer.NewServer(services, app.Configuration, app.Logger, app.Metrics, app.Health, authenticator, limiter)
+}
+
+// setupLimiter keeps the rate limit buckets in memory, so each replica limits the clients on its own.
+func (app *Application) setupLimiter() (*ratelimit.Limiter, error) {
+	return ratelimit.NewLimiter(ratelimit.NewMemoryStore(nil), app.Configuration.RateLimit, app.Configuration.App.TrustedProxies)
+}
+
+// setupAuthenticator accepts API keys from the repository and, when a secret or JWKS file is configured,
+// bearer tokens. With AUTH_ENABLED=false it returns nil and every route is public.
+func (app *Application) setupAuthenticator(repos *repository.Repositories) (*auth.Authenticator, error) {
+	if !app.Configuration.Auth.Enabled {
+		app.Logger.Warn("Autenticação desabilitada: todas as rotas estão públicas")
+		return nil, nil
 	}
 
-	services := app.setupServices(repos)
-	count, err := services.User.UpdateOldUsersStatus()
+	tokens, err := auth.NewJwtVerifier(app.Configuration.Auth)
 	if err != nil {
-		logger.Printf(notify.LogForErrorUpdateUsers, err)
-	} else {
-		logger.Printf(notify.LogForPartialUpdateUsers, count)
+		return nil, err
 	}
+	if tokens == nil {
+		app.Logger.Info("Nenhuma chave JWT configurada: apenas chaves de API são aceitas")
+	}
+	return auth.NewAuthenticator(tokens, repos.ApiKey), nil
 }
 
-func (app *Application) startDailyScheduler(cfg config.Config) {
-	app.schedulerWg.Add(1)
+// setupHealth registers the readiness checks: the scheduler heartbeat and, for backends with a
+// connection, the database ping and the schema version.
+func (app *Application) setupHealth(db *dbProvider.DB) (*health.Health, error) {
+	settings := app.Configuration.Health
+	checks := health.New(health.Options{Timeout: settings.Timeout, CacheTTL: settings.CacheTTL})
+	checks.Register(health.SchedulerChecker(app.Scheduler, settings.SchedulerMaxAge))
 
-	go func() {
-		defer app.schedulerWg.Done()
+	if db == nil {
+		return checks, nil
+	}
 
-		app.runUpdateOldUsersStatus()
+	runner, err := migration.NewRunner(app.Configuration.Database.Driver, db, app.Logger)
+	if err != nil {
+		return nil, err
+	}
+	checks.Register(health.DatabaseChecker(db))
+	checks.Register(health.MigrationChecker(runner))
+	return checks, nil
+}
 
-		now := time.Now()
-		nextMidnight := time.Date(
-			now.Year(),
-			now.Month(),
-			now.Day()+cfg.Routine.IncrementDay,
-			cfg.Routine.Hour,
-			cfg.Routine.Minute,
-			cfg.Routine.Second,
-			cfg.Routine.Millisecond,
-			now.Location())
+// setupScheduler creates the scheduler, recording the runs in the job run repository and
+// taking the backend lock before each run, so replicas sharing the database do not repeat jobs.
+func (app *Application) setupScheduler(repos *repository.Repositories) (*scheduler.Scheduler, error) {
+	location := time.Local
+	if app.Configuration.Routine.TimeZone != "" {
+		loaded, err := time.LoadLocation(app.Configuration.Routine.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		location = loaded
+	}
 
-		initialDelay := nextMidnight.Sub(now)
+	return scheduler.New(scheduler.Options{
+		Location: location,
+		Recorder: repos.JobRun,
+		Locker:   repos.Locker,
+		LockTTL:  app.Configuration.Routine.LockTTL,
+		Logger:   app.Logger,
+		Observer: app.Metrics,
+	}), nil
+}
 
-		logger.Printf(notify.LogStartRotineAction,
-			nextMidnight.Format(constant.FormatDate),
-			initialDelay)
+// registerJobs registers every job of the jobs package; adding a job does not touch this file.
+func (app *Application) registerJobs() error {
+	return jobs.RegisterAll(app.Scheduler, jobs.Dependencies{
+		Configuration: app.Configuration,
+		Services:      app.services,
+	})
+}
 
-		select {
-		case <-time.After(initialDelay):
-		case <-app.schedulerDone:
-			logger.Println(notify.LogRotineNoStarted)
-			return
-		}
+// StopScheduler stops scheduling new runs and waits for the runs in progress,
+// cancelling them after schedulerStopTimeout.
+func (app *Application) StopScheduler() {
+	ctx, cancel := context.WithTimeout(context.Background(), schedulerStopTimeout)
+	defer cancel()
 
-		ticker := time.NewTicker(24 * time.Hour)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				app.runUpdateOldUsersStatus()
-				nextRun := time.Now().Add(24 * time.Hour)
-				logger.Printf(notify.LogNextRotine, nextRun.Format(constant.FormatDate))
-			case <-app.schedulerDone:
-				logger.Println(notify.LogRotineOff)
-				return
-			}
-		}
-	}()
+	if err := app.Scheduler.Stop(ctx); err != nil {
+		app.Logger.Warn("Agendador: Tempo de parada esgotado, jobs em execução cancelados", logging.KeyError, err)
+	}
+	app.Logger.Info("Agendador: Parado")
 }
 
-func (app *Application) StopScheduler() {
-	close(app.schedulerDone)
-	app.schedulerWg.Wait()
-	logger.Println(notify.LogRotineStoped)
+// StopTracing exports the spans still buffered, giving up after tracingStopTimeout.
+func (app *Application) StopTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingStopTimeout)
+	defer cancel()
+
+	if err := app.stopTracing(ctx); err != nil {
+		app.Logger.Warn("Erro ao exportar os spans pendentes", logging.KeyError, err)
+	}
 }
 
 func (app *Application) Run(ctx context.Context) error {
-	app.startDailyScheduler(*app.Configuration)
+	app.Scheduler.Start(context.Background())
 
 	return app.Server.Start(ctx)
 }
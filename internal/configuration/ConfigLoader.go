@@ -2,38 +2,117 @@ package config
 
 import (
 	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/logging"
 	envConfig "github.com/joho/godotenv"
-	"log"
+	"log/slog"
+	"net/netip"
 	setter "os"
 	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReadTimeout  = 15 * time.Second
+	defaultWriteTimeout = 30 * time.Second
+	defaultJobTimeout   = 30 * time.Minute
+	defaultLockTTL      = time.Minute
+	defaultServiceName  = "PocGo"
+
+	defaultHealthTimeout   = 2 * time.Second
+	defaultHealthCacheTTL  = 5 * time.Second
+	defaultSchedulerMaxAge = 30 * time.Second
+	defaultShutdownDelay   = 5 * time.Second
+
+	defaultJwtLeeway  = 30 * time.Second
+	defaultPolicyFile = "authorization.json"
+
+	defaultRateLimitRequests        = 300
+	defaultRateLimitPeriod          = time.Minute
+	defaultRateLimitAddressRequests = 600
 )
 
 type Config struct {
-	Database *provider.DatabaseConfig
-	Routine  *provider.RoutineConfig
+	App       *provider.AppConfig
+	Database  *provider.DatabaseConfig
+	Routine   *provider.RoutineConfig
+	Timeout   *provider.TimeoutConfig
+	Log       *provider.LogConfig
+	Tracing   *provider.TracingConfig
+	Health    *provider.HealthConfig
+	Auth      *provider.AuthConfig
+	RateLimit *provider.RateLimitConfig
 }
 
 func LoadConfig(env string) *Config {
 	err := envConfig.Load(".env." + env)
 	if err != nil {
-		log.Println("Erro ao carregar arquivo : ", err)
+		slog.Warn("Erro ao carregar arquivo", "file", ".env."+env, logging.KeyError, err)
+	}
+
+	environment := setter.Getenv("APP_ENV")
+	if environment == "" {
+		environment = env
+	}
+
+	driver := setter.Getenv("DB_DRIVER")
+	if driver == "" {
+		driver = provider.DriverSqlServer
 	}
 
 	connStr := setter.Getenv("DB_CONNECTION_STRING")
 	maxOpers, _ := strconv.Atoi(setter.Getenv("DB_MAX_OPEN_CONNECTIONS"))
 	maxIdle, _ := strconv.Atoi(setter.Getenv("DB_MAX_IDLE_CONNECTIONS"))
+	autoMigrate, _ := strconv.ParseBool(setter.Getenv("DB_AUTO_MIGRATE"))
 
 	day, _ := strconv.Atoi(setter.Getenv("RT_INCREMENT_DAY"))
 	hour, _ := strconv.Atoi(setter.Getenv("RT_HOUR"))
 	minute, _ := strconv.Atoi(setter.Getenv("RT_MINUTE"))
 	second, _ := strconv.Atoi(setter.Getenv("RT_SECOND"))
 	millisecond, _ := strconv.Atoi(setter.Getenv("RT_MILLISECOND"))
+	inactiveMonths, _ := strconv.Atoi(setter.Getenv("RT_INACTIVE_MONTHS"))
+	batchSize, _ := strconv.Atoi(setter.Getenv("RT_BATCH_SIZE"))
+	runOnStart, err := strconv.ParseBool(setter.Getenv("RT_RUN_ON_START"))
+	if err != nil {
+		runOnStart = true
+	}
+
+	traceInsecure, _ := strconv.ParseBool(setter.Getenv("TRACE_INSECURE"))
+
+	authEnabled, err := strconv.ParseBool(setter.Getenv("AUTH_ENABLED"))
+	if err != nil {
+		authEnabled = true
+	}
+
+	rateLimitEnabled, err := strconv.ParseBool(setter.Getenv("RATE_LIMIT_ENABLED"))
+	if err != nil {
+		rateLimitEnabled = true
+	}
+	rateLimitRequests, err := strconv.Atoi(setter.Getenv("RATE_LIMIT_REQUESTS"))
+	if err != nil {
+		rateLimitRequests = defaultRateLimitRequests
+	}
+	rateLimitBurst, err := strconv.Atoi(setter.Getenv("RATE_LIMIT_BURST"))
+	if err != nil {
+		rateLimitBurst = rateLimitRequests
+	}
+	rateLimitAddressRequests, err := strconv.Atoi(setter.Getenv("RATE_LIMIT_ADDRESS_REQUESTS"))
+	if err != nil {
+		rateLimitAddressRequests = defaultRateLimitAddressRequests
+	}
 
 	return &Config{
+		App: &provider.AppConfig{
+			Environment:    environment,
+			LegacySunset:   getDate("APP_LEGACY_SUNSET"),
+			TrustedProxies: getPrefixes("APP_TRUSTED_PROXIES"),
+		},
 		Database: &provider.DatabaseConfig{
+			Driver:           driver,
 			ConnectionString: connStr,
 			MaxOpenConns:     maxOpers,
 			MaxIdleConns:     maxIdle,
+			AutoMigrate:      autoMigrate,
 		},
 		Routine: &provider.RoutineConfig{
 			IncrementDay: day,
@@ -41,6 +120,170 @@ func LoadConfig(env string) *Config {
 			Minute:       minute,
 			Second:       second,
 			Millisecond:  millisecond,
+			Schedule:     setter.Getenv("RT_SCHEDULE"),
+			TimeZone:     setter.Getenv("RT_TIMEZONE"),
+			Jitter:       getDuration("RT_JITTER", 0),
+			RunOnStart:   runOnStart,
+			LockTTL:      getDuration("RT_LOCK_TTL", defaultLockTTL),
+
+			InactiveAfterMonths: inactiveMonths,
+			BatchSize:           batchSize,
+		},
+		Timeout: &provider.TimeoutConfig{
+			Read:  getDuration("DB_READ_TIMEOUT", defaultReadTimeout),
+			Write: getDuration("DB_WRITE_TIMEOUT", defaultWriteTimeout),
+			Job:   getDuration("RT_JOB_TIMEOUT", defaultJobTimeout),
+		},
+		Log: &provider.LogConfig{
+			Format: getString("LOG_FORMAT", provider.LogFormatText),
+			Level:  getString("LOG_LEVEL", "info"),
+			Output: getString("LOG_OUTPUT", provider.LogOutputStdout),
+
+			AccessFormat: getString("LOG_ACCESS_FORMAT", provider.AccessLogSlog),
+			AccessOutput: getString("LOG_ACCESS_OUTPUT", provider.LogOutputStdout),
+		},
+		Tracing: &provider.TracingConfig{
+			Exporter:    getString("TRACE_EXPORTER", provider.TraceExporterNone),
+			Endpoint:    setter.Getenv("TRACE_ENDPOINT"),
+			Insecure:    traceInsecure,
+			SampleRatio: getFloat("TRACE_SAMPLE_RATIO", 1),
+			ServiceName: getString("TRACE_SERVICE_NAME", defaultServiceName),
+		},
+		Health: &provider.HealthConfig{
+			Timeout:         getDuration("HEALTH_TIMEOUT", defaultHealthTimeout),
+			CacheTTL:        getDuration("HEALTH_CACHE_TTL", defaultHealthCacheTTL),
+			SchedulerMaxAge: getDuration("HEALTH_SCHEDULER_MAX_AGE", defaultSchedulerMaxAge),
+			ShutdownDelay:   getDuration("HEALTH_SHUTDOWN_DELAY", defaultShutdownDelay),
+		},
+		Auth: &provider.AuthConfig{
+			Enabled:   authEnabled,
+			JwtSecret: setter.Getenv("AUTH_JWT_SECRET"),
+			JwksFile:  setter.Getenv("AUTH_JWKS_FILE"),
+			Issuer:    setter.Getenv("AUTH_JWT_ISSUER"),
+			Audience:  setter.Getenv("AUTH_JWT_AUDIENCE"),
+			Leeway:    getDuration("AUTH_JWT_LEEWAY", defaultJwtLeeway),
+
+			PolicyFile: getString("AUTH_POLICY_FILE", defaultPolicyFile),
 		},
+		RateLimit: &provider.RateLimitConfig{
+			Enabled: rateLimitEnabled,
+			Default: provider.RateLimit{
+				Requests: rateLimitRequests,
+				Period:   getDuration("RATE_LIMIT_PERIOD", defaultRateLimitPeriod),
+				Burst:    rateLimitBurst,
+			},
+			Routes: getRateLimits("RATE_LIMIT_ROUTES"),
+			Address: provider.RateLimit{
+				Requests: rateLimitAddressRequests,
+				Period:   getDuration("RATE_LIMIT_ADDRESS_PERIOD", defaultRateLimitPeriod),
+			},
+		},
+	}
+}
+
+// getString reads a value from the environment, falling back when unset.
+func getString(key string, fallback string) string {
+	if value := setter.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getFloat reads a number from the environment, falling back when unset or invalid.
+func getFloat(key string, fallback float64) float64 {
+	value := setter.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		slog.Warn("Valor inválido", "key", key, logging.KeyError, err)
+		return fallback
+	}
+	return number
+}
+
+// getDuration reads a Go duration (ex: "15s", "2m") from the environment, falling back when unset or invalid.
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := setter.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		slog.Warn("Valor inválido", "key", key, logging.KeyError, err)
+		return fallback
+	}
+
+	return duration
+}
+
+// getDate reads a YYYY-MM-DD date (midnight UTC) from the environment, returning the zero time when unset or invalid.
+func getDate(key string) time.Time {
+	value := setter.Getenv(key)
+	if value == "" {
+		return time.Time{}
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		slog.Warn("Valor inválido", "key", key, logging.KeyError, err)
+		return time.Time{}
+	}
+	return date
+}
+
+// getRateLimits reads a comma-separated list of route limits written as "<método> <rota>=<requisições>/<período>"
+// (ex: "GET /user/get_all_users=10/1m"), skipping the invalid entries. The burst of a route is its request count.
+func getRateLimits(key string) map[string]provider.RateLimit {
+	limits := map[string]provider.RateLimit{}
+	for _, entry := range strings.Split(setter.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, limit, _ := strings.Cut(entry, "=")
+		count, period, _ := strings.Cut(limit, "/")
+		requests, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || requests <= 0 {
+			slog.Warn("Valor inválido", "key", key, "entry", entry)
+			continue
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(period))
+		if err != nil || duration <= 0 {
+			slog.Warn("Valor inválido", "key", key, "entry", entry)
+			continue
+		}
+
+		limits[strings.Join(strings.Fields(route), " ")] = provider.RateLimit{Requests: requests, Period: duration, Burst: requests}
+	}
+	return limits
+}
+
+// getPrefixes reads a comma-separated list of CIDRs or single addresses (ex: "10.0.0.0/8, 127.0.0.1"),
+// skipping the invalid entries.
+func getPrefixes(key string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, entry := range strings.Split(setter.Getenv(key), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if address, err := netip.ParseAddr(entry); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(address, address.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			slog.Warn("Valor inválido", "key", key, logging.KeyError, err)
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
 	}
+	return prefixes
 }
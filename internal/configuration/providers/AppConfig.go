@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"net/netip"
+	"time"
+)
+
+const (
+	EnvironmentProduction = "production"
+)
+
+type AppConfig struct {
+	Environment string
+	// LegacySunset is announced in the Sunset header of the unversioned routes; zero omits the header.
+	LegacySunset time.Time
+	// TrustedProxies are the proxies whose X-Forwarded-For header gives the client address.
+	TrustedProxies []netip.Prefix
+}
+
+// IsProduction reports whether internal error details must be hidden from API clients.
+func (app *AppConfig) IsProduction() bool {
+	return app != nil && app.Environment == EnvironmentProduction
+}
@@ -0,0 +1,21 @@
+package providers
+
+import "time"
+
+// AuthConfig configures the authentication of the protected routes. Bearer tokens are accepted when
+// JwtSecret (HS256) or JwksFile (RS256) is set; API keys are always accepted.
+type AuthConfig struct {
+	// Enabled protects the routes; when false every route is public, which is meant for local development only.
+	Enabled bool
+	// JwtSecret is the HS256 shared secret, at least 32 bytes.
+	JwtSecret string
+	// JwksFile is a local JSON Web Key Set with the RS256 public keys, selected by the token kid.
+	JwksFile string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// Leeway is the clock skew tolerated on exp, nbf and iat.
+	Leeway time.Duration
+	// PolicyFile is the JSON file granting permissions to roles, see authorization.LoadPolicy.
+	PolicyFile string
+}
@@ -1,7 +1,15 @@
 package providers
 
+const (
+	DriverSqlServer = "sqlserver"
+	DriverSqlite    = "sqlite"
+	DriverMemory    = "memory"
+)
+
 type DatabaseConfig struct {
+	Driver           string
 	ConnectionString string
 	MaxOpenConns     int
 	MaxIdleConns     int
+	AutoMigrate      bool
 }
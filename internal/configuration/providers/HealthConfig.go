@@ -0,0 +1,16 @@
+package providers
+
+import "time"
+
+// HealthConfig configures the readiness checks of /health/ready.
+type HealthConfig struct {
+	// Timeout bounds each dependency check.
+	Timeout time.Duration
+	// CacheTTL is how long a readiness report is reused; a negative value checks on every probe.
+	CacheTTL time.Duration
+	// SchedulerMaxAge is the oldest scheduler heartbeat still considered alive.
+	SchedulerMaxAge time.Duration
+	// ShutdownDelay is how long the server keeps serving after readiness turns 503 on shutdown, so the load
+	// balancer stops routing to the instance before it closes its listener.
+	ShutdownDelay time.Duration
+}
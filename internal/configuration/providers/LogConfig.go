@@ -0,0 +1,34 @@
+package providers
+
+const (
+	LogFormatJson = "json"
+	LogFormatText = "text"
+
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+)
+
+// Access log formats, see middleware.AccessLog.
+const (
+	// AccessLogSlog logs each request through the application logger, so LOG_FORMAT applies.
+	AccessLogSlog = "slog"
+	// AccessLogCommon and AccessLogCombined are the Apache/NGINX formats read by most log tools.
+	AccessLogCommon   = "common"
+	AccessLogCombined = "combined"
+	// AccessLogJson writes one JSON object per request.
+	AccessLogJson = "json"
+)
+
+// LogConfig configures the application logger.
+type LogConfig struct {
+	// Format is LogFormatJson or LogFormatText.
+	Format string
+	// Level is debug, info, warn or error.
+	Level string
+	// Output is LogOutputStdout, LogOutputStderr or the path of a file, opened for appending.
+	Output string
+	// AccessFormat is one of the AccessLog* formats.
+	AccessFormat string
+	// AccessOutput is where the common, combined and json access logs go, with the same values as Output.
+	AccessOutput string
+}
@@ -0,0 +1,22 @@
+package providers
+
+import "time"
+
+// RateLimit allows Requests per Period to each client, in bursts of up to Burst requests.
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// RateLimitConfig configures the per-client and per-address limits of the protected routes.
+type RateLimitConfig struct {
+	Enabled bool
+	Default RateLimit
+	// Routes overrides Default for the routes keyed by method and template, ex: "GET /user/get_all_users".
+	// Each route then has a bucket of its own, apart from the one shared by the other routes.
+	Routes map[string]RateLimit
+	// Address limits every client address before authentication, so failed credentials are limited too.
+	// Zero Requests leaves it off.
+	Address RateLimit
+}
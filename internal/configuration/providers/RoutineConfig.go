@@ -1,9 +1,36 @@
 package providers
 
+import (
+	configIO "fmt"
+	"time"
+)
+
 type RoutineConfig struct {
 	IncrementDay int
 	Hour         int
 	Minute       int
 	Second       int
 	Millisecond  int
+
+	// Schedule is the cron expression of the inactive-user sweep; when empty it is derived from Hour, Minute and Second.
+	Schedule   string
+	TimeZone   string
+	Jitter     time.Duration
+	RunOnStart bool
+	// LockTTL is the lease taken before each run so only one replica runs the job.
+	LockTTL time.Duration
+
+	// InactiveAfterMonths is the account age after which the sweep marks users as inactive; 0 means the service default.
+	InactiveAfterMonths int
+	// BatchSize is the number of users updated per transaction by the sweep; 0 means the repository default.
+	BatchSize int
+}
+
+// CronSchedule returns Schedule or, for configurations that predate it, a daily expression
+// at the configured time. IncrementDay and Millisecond have no cron equivalent and are ignored.
+func (c *RoutineConfig) CronSchedule() string {
+	if c.Schedule != "" {
+		return c.Schedule
+	}
+	return configIO.Sprintf("%d %d %d * * *", c.Second, c.Minute, c.Hour)
 }
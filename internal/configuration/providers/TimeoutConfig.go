@@ -0,0 +1,11 @@
+package providers
+
+import "time"
+
+// TimeoutConfig holds the deadline applied to each kind of operation.
+// A zero value disables the deadline for that operation.
+type TimeoutConfig struct {
+	Read  time.Duration
+	Write time.Duration
+	Job   time.Duration
+}
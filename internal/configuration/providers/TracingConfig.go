@@ -0,0 +1,20 @@
+package providers
+
+const (
+	TraceExporterNone   = "none"
+	TraceExporterStdout = "stdout"
+	TraceExporterOtlp   = "otlp"
+)
+
+// TracingConfig configures the OpenTelemetry spans.
+type TracingConfig struct {
+	// Exporter is TraceExporterNone, TraceExporterStdout or TraceExporterOtlp (OTLP over HTTP).
+	Exporter string
+	// Endpoint is the OTLP collector, e.g. "localhost:4318"; empty uses OTEL_EXPORTER_OTLP_ENDPOINT or its default.
+	Endpoint string
+	// Insecure sends OTLP over plain HTTP.
+	Insecure bool
+	// SampleRatio is the fraction of new traces recorded, in [0, 1]; incoming sampled traces are always kept.
+	SampleRatio float64
+	ServiceName string
+}
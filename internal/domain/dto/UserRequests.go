@@ -0,0 +1,76 @@
+package dto
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
+	setJson "encoding/json"
+	"strings"
+)
+
+// CreateUserRequest is the body of POST /users. A missing status creates an active user.
+// The 256-character limits of the requests match the name and email columns.
+type CreateUserRequest struct {
+	Name   string             `json:"name" validate:"required,max=256"`
+	Email  string             `json:"email" validate:"required,max=256,email"`
+	Status setJson.RawMessage `json:"status,omitempty" validate:"omitempty,status"`
+}
+
+func (request CreateUserRequest) ToUser() entity.User {
+	return entity.User{
+		Name:   strings.TrimSpace(request.Name),
+		Email:  strings.TrimSpace(request.Email),
+		Status: statusOf(request.Status),
+	}
+}
+
+// UpdateUserRequest is the body of PUT /users/{id}. Empty fields keep the stored values.
+type UpdateUserRequest struct {
+	Name   string             `json:"name,omitempty" validate:"omitempty,max=256"`
+	Email  string             `json:"email,omitempty" validate:"omitempty,max=256,email"`
+	Status setJson.RawMessage `json:"status,omitempty" validate:"omitempty,status"`
+}
+
+// ToUser builds the changes for the user with id; an invalid id becomes the zero GUID, which matches no user.
+func (request UpdateUserRequest) ToUser(id string) entity.User {
+	guid, _ := values.ParseGUID(id)
+	return entity.User{
+		ID:     guid,
+		Name:   strings.TrimSpace(request.Name),
+		Email:  strings.TrimSpace(request.Email),
+		Status: statusOf(request.Status),
+	}
+}
+
+// LegacyUpdateUserRequest is the body of PUT /user/update_user, which carries the ID instead of the path.
+type LegacyUpdateUserRequest struct {
+	ID string `json:"id" validate:"required,guid"`
+	UpdateUserRequest
+}
+
+// UserDocument is the JSON representation of a user after a PATCH is applied; unlike an update,
+// every field must be present.
+type UserDocument struct {
+	ID     string             `json:"id" validate:"required,guid"`
+	Name   string             `json:"name" validate:"max=256"`
+	Email  string             `json:"email" validate:"required,max=256,email"`
+	Status setJson.RawMessage `json:"status" validate:"required,status"`
+}
+
+func (document UserDocument) ToUser() entity.User {
+	id, _ := values.ParseGUID(document.ID)
+	return entity.User{
+		ID:     id,
+		Name:   strings.TrimSpace(document.Name),
+		Email:  strings.TrimSpace(document.Email),
+		Status: statusOf(document.Status),
+	}
+}
+
+// statusOf decodes a status already accepted by the status rule; an absent one is StatusUndefined.
+func statusOf(raw setJson.RawMessage) entity.UserStatus {
+	var status entity.UserStatus
+	if len(raw) > 0 {
+		_ = status.UnmarshalJSON(raw)
+	}
+	return status
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"PocGo/internal/domain/values"
+	"time"
+)
+
+// ApiKey is the credential of a machine client. Only the SHA-256 hash of the key is stored: the key itself
+// is shown once, when created, and Prefix identifies it afterwards in listings and logs.
+type ApiKey struct {
+	ID        values.GUID `json:"id"`
+	Name      string      `json:"name"`
+	Prefix    string      `json:"prefix"`
+	Hash      string      `json:"-"`
+	Roles     []string    `json:"roles"`
+	CreatedAt time.Time   `json:"createdAt"`
+	ExpiresAt *time.Time  `json:"expiresAt,omitempty"`
+	RevokedAt *time.Time  `json:"revokedAt,omitempty"`
+}
+
+// IsActive reports whether the key is neither revoked nor expired at now.
+func (key ApiKey) IsActive(now time.Time) bool {
+	if key.RevokedAt != nil && !key.RevokedAt.After(now) {
+		return false
+	}
+	return key.ExpiresAt == nil || key.ExpiresAt.After(now)
+}
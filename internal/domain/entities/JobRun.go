@@ -0,0 +1,69 @@
+package entities
+
+import "time"
+
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	// JobRunPartial means the job finished but some items failed.
+	JobRunPartial JobRunStatus = "partial"
+	JobRunFailed  JobRunStatus = "failed"
+)
+
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"
+)
+
+// MaxJobRunErrors caps the item errors kept per run; Failed still counts every failure.
+const MaxJobRunErrors = 100
+
+// ItemError is the failure of one item processed by a job, such as a user the sweep could not update.
+type ItemError struct {
+	ItemID  string `json:"itemId"`
+	Message string `json:"message"`
+}
+
+// JobRun is one execution of a scheduled job.
+type JobRun struct {
+	ID          string       `json:"id"`
+	JobName     string       `json:"jobName"`
+	TriggeredBy string       `json:"triggeredBy"`
+	DryRun      bool         `json:"dryRun"`
+	Status      JobRunStatus `json:"status"`
+	StartedAt   time.Time    `json:"startedAt"`
+	// ScheduledFor is the schedule slot a scheduled run was fired for; every replica fires the same slots,
+	// and only the first to record one runs it.
+	ScheduledFor *time.Time  `json:"scheduledFor,omitempty"`
+	FinishedAt   *time.Time  `json:"finishedAt,omitempty"`
+	Processed    int         `json:"processed"`
+	Updated      int         `json:"updated"`
+	Failed       int         `json:"failed"`
+	Error        string      `json:"error,omitempty"`
+	Errors       []ItemError `json:"errors,omitempty"`
+}
+
+// AddError counts a failed item, keeping its message while under MaxJobRunErrors.
+func (run *JobRun) AddError(itemID string, message string) {
+	run.Failed++
+	if len(run.Errors) < MaxJobRunErrors {
+		run.Errors = append(run.Errors, ItemError{ItemID: itemID, Message: message})
+	}
+}
+
+// Finish records the end of the run and derives its final status from err and the failed count.
+func (run *JobRun) Finish(finishedAt time.Time, err error) {
+	run.FinishedAt = &finishedAt
+
+	switch {
+	case err != nil:
+		run.Status = JobRunFailed
+		run.Error = err.Error()
+	case run.Failed > 0:
+		run.Status = JobRunPartial
+	default:
+		run.Status = JobRunSucceeded
+	}
+}
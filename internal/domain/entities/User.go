@@ -1,8 +1,12 @@
 package entities
 
+import "PocGo/internal/domain/values"
+
 type User struct {
-	ID     string `json:"id"`
-	Name   string `json:"name"`
-	Email  string `json:"email"`
-	Status int    `json:"status"`
+	ID     values.GUID `json:"id"`
+	Name   string      `json:"name"`
+	Email  string      `json:"email"`
+	Status UserStatus  `json:"status,omitempty"`
+	// Version is incremented on every write and exposed as the ETag, not in the body.
+	Version int64 `json:"-"`
 }
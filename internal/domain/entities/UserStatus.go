@@ -0,0 +1,116 @@
+package entities
+
+import (
+	setJson "encoding/json"
+	configIO "fmt"
+	"strings"
+)
+
+// UserStatus is the lifecycle state of a user. The numeric values are the ones stored
+// in the status column; 1 and 2 predate this type and must not change.
+type UserStatus int
+
+const (
+	// StatusUndefined is the zero value and means "not informed", e.g. a PUT without status.
+	StatusUndefined UserStatus = 0
+	StatusActive    UserStatus = 1
+	StatusInactive  UserStatus = 2
+	StatusPending   UserStatus = 3
+	StatusBlocked   UserStatus = 4
+	StatusDeleted   UserStatus = 5
+)
+
+var userStatusNames = map[UserStatus]string{
+	StatusActive:   "active",
+	StatusInactive: "inactive",
+	StatusPending:  "pending",
+	StatusBlocked:  "blocked",
+	StatusDeleted:  "deleted",
+}
+
+// userStatusTransitions lists, for each state, the states it may move to.
+// StatusUndefined holds the states a user may be created with.
+var userStatusTransitions = map[UserStatus][]UserStatus{
+	StatusUndefined: {StatusPending, StatusActive},
+	StatusPending:   {StatusActive, StatusBlocked, StatusDeleted},
+	StatusActive:    {StatusInactive, StatusBlocked, StatusDeleted},
+	StatusInactive:  {StatusActive, StatusBlocked, StatusDeleted},
+	StatusBlocked:   {StatusActive, StatusDeleted},
+	StatusDeleted:   {StatusInactive},
+}
+
+// ParseUserStatus accepts the status name, case-insensitively.
+func ParseUserStatus(value string) (UserStatus, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	for status, name := range userStatusNames {
+		if name == normalized {
+			return status, nil
+		}
+	}
+	return StatusUndefined, configIO.Errorf("status de usuário desconhecido: %q", value)
+}
+
+func (status UserStatus) String() string {
+	if name, exists := userStatusNames[status]; exists {
+		return name
+	}
+	return configIO.Sprintf("UserStatus(%d)", int(status))
+}
+
+// IsValid reports whether status is one of the named states.
+func (status UserStatus) IsValid() bool {
+	_, exists := userStatusNames[status]
+	return exists
+}
+
+// CanTransitionTo reports whether the transition table allows moving from status to target.
+// Staying in the same state is always allowed.
+func (status UserStatus) CanTransitionTo(target UserStatus) bool {
+	if status == target && status != StatusUndefined {
+		return true
+	}
+
+	for _, allowed := range userStatusTransitions[status] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownStatusName is written for values outside the enum, such as a row fixed by hand, so a single bad
+// row does not fail the encoding of a whole response.
+const unknownStatusName = "unknown"
+
+func (status UserStatus) MarshalJSON() ([]byte, error) {
+	if !status.IsValid() {
+		return setJson.Marshal(unknownStatusName)
+	}
+	return setJson.Marshal(status.String())
+}
+
+// UnmarshalJSON accepts the status name or, for older clients, its numeric value.
+func (status *UserStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := setJson.Unmarshal(data, &name); err == nil {
+		parsed, err := ParseUserStatus(name)
+		if err != nil {
+			return err
+		}
+		*status = parsed
+		return nil
+	}
+
+	var number int
+	if err := setJson.Unmarshal(data, &number); err != nil {
+		return configIO.Errorf("status de usuário deve ser texto ou número: %s", string(data))
+	}
+
+	parsed := UserStatus(number)
+	if parsed != StatusUndefined && !parsed.IsValid() {
+		return configIO.Errorf("status de usuário desconhecido: %d", number)
+	}
+
+	*status = parsed
+	return nil
+}
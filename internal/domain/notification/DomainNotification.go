@@ -1,9 +1,19 @@
 package notification
 
 const (
-	NotFound      = "Notific : {{.Entity}} não encontrado"
-	InvalidData   = "Notific : Dados do {{.Entity}} inválidos: {{if .Data}}{{.Data}}{{end}}"
-	InvalidMethod = "Notific : Método não permitido"
+	NotFound                = "Notific : {{.Entity}} não encontrado"
+	InvalidData             = "Notific : Dados do {{.Entity}} inválidos: {{if .Data}}{{.Data}}{{end}}"
+	InvalidMethod           = "Notific : Método não permitido"
+	InvalidState            = "Notific : {{.Entity}} em estado inválido para a operação: {{if .Data}}{{.Data}}{{end}}"
+	InvalidStatusTransition = "Notific : Transição de status do {{.Entity}} não permitida: {{if .Data}}{{.Data}}{{end}}"
+	Conflict                = "Notific : Conflito com outro {{.Entity}}: {{if .Data}}{{.Data}}{{end}}"
+	UnsupportedMediaType    = "Notific : Tipo de conteúdo não suportado: {{if .Data}}{{.Data}}{{end}}"
+	PatchTestFailed         = "Notific : Condição do patch do {{.Entity}} não atendida: {{if .Data}}{{.Data}}{{end}}"
+	PreconditionRequired    = "Notific : A alteração do {{.Entity}} exige o header If-Match: {{if .Data}}{{.Data}}{{end}}"
+	PreconditionFailed      = "Notific : O {{.Entity}} foi alterado por outra requisição: {{if .Data}}{{.Data}}{{end}}"
+	Unauthenticated         = "Notific : Autenticação necessária: {{if .Data}}{{.Data}}{{end}}"
+	Forbidden               = "Notific : Acesso negado: {{if .Data}}{{.Data}}{{end}}"
+	TooManyRequests         = "Notific : Limite de requisições excedido: {{if .Data}}{{.Data}}{{end}}"
 )
 
 const (
@@ -13,23 +23,42 @@ const (
 )
 
 const (
-	ErrorDbFatal         = "Erro ao conectar ao banco de dados: %v"
-	ErrorRepositoryFatal = "Erro ao iniciar os repositorios: %v"
+	ErrorDbFatal         = "Erro ao conectar ao banco de dados"
+	ErrorRepositoryFatal = "Erro ao iniciar os repositorios"
+	ErrorTracingFatal    = "Erro ao configurar o tracing"
+	ErrorHealthFatal     = "Erro ao configurar os health checks"
+	ErrorAuthFatal       = "Erro ao configurar a autenticação"
+	ErrorPolicyFatal     = "Erro ao carregar a política de autorização"
+	ErrorRateLimitFatal  = "Erro ao configurar o limite de requisições"
 )
 
 const (
 	ErrorConfigDb       = "Notific : Configuração do banco de dados não está definida"
 	ErrorOpenConnection = "Notific : Erro ao abrir conexão:{{if .Data}}{{.Data}}{{end}}"
 	ErrorTestConnection = "Notific : Erro ao testar conexão: {{if .Data}}{{.Data}}{{end}}"
+	ErrorUnknownDriver  = "Notific : Driver de banco de dados não suportado: {{if .Data}}{{.Data}}{{end}}"
+	ErrorNoConnection   = "Notific : O driver {{if .Data}}{{.Data}}{{end}} exige uma conexão com o banco de dados"
 )
 
 const (
-	LogForErrorUpdateUsers   = "Erro ao tentar atualizar os usuários: %v"
-	LogForPartialUpdateUsers = "Status de %d usuários antigos atualizado para 2"
-	LogStartRotineAction     = "Agendador: Próxima atualização agendada para %v (em %v)"
-	LogRotineNoStarted       = "Agendador: Desligando antes da primeira execução programada"
-	LogNextRotine            = "Agendador: Próxima atualização agendada para %v"
-	LogRotineOff             = "Agendador: Desligando"
-	LogRotineStoped          = "Agendador: Parado"
-	LogMiddleware            = "[%s] %s %s - Status: %d - Duration: %s"
+	ErrorMigrationFailed      = "Notific : Erro ao executar a migração: {{if .Data}}{{.Data}}{{end}}"
+	ErrorMigrationChecksum    = "Notific : Checksum divergente na migração já aplicada: {{if .Data}}{{.Data}}{{end}}"
+	ErrorMigrationNotFound    = "Notific : Migração não encontrada: {{if .Data}}{{.Data}}{{end}}"
+	ErrorMigrationUnsupported = "Notific : Driver sem suporte a migrações: {{if .Data}}{{.Data}}{{end}}"
+	ErrorMigrationFatal       = "Erro ao executar as migrações"
+)
+
+const (
+	ErrorJobInvalid       = "Notific : Job inválido: {{if .Data}}{{.Data}}{{end}}"
+	ErrorJobDuplicate     = "Notific : Job já registrado: {{if .Data}}{{.Data}}{{end}}"
+	ErrorJobRunning       = "Notific : Job já está em execução: {{if .Data}}{{.Data}}{{end}}"
+	ErrorSchedulerStopped = "Notific : Agendador parado, job não executado: {{if .Data}}{{.Data}}{{end}}"
+	ErrorJobLocked        = "Notific : Job em execução em outra instância: {{if .Data}}{{.Data}}{{end}}"
+	ErrorJobSlotDone      = "Notific : Horário do job já executado por outra instância: {{if .Data}}{{.Data}}{{end}}"
+	ErrorSchedulerFatal   = "Erro ao registrar os jobs"
+)
+
+const (
+	ErrorLockFailed = "Notific : Erro ao obter o lock: {{if .Data}}{{.Data}}{{end}}"
+	ErrorLockLost   = "Notific : Lock perdido: {{if .Data}}{{.Data}}{{end}}"
 )
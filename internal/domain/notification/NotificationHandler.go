@@ -2,20 +2,67 @@ package notification
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	templateTx "text/template"
 )
 
+const (
+	CodeNotFound             = "NOT_FOUND"
+	CodeInvalidData          = "INVALID_DATA"
+	CodeInvalidMethod        = "INVALID_METHOD"
+	CodeInvalidState         = "INVALID_STATE"
+	CodeInvalidTransition    = "INVALID_STATUS_TRANSITION"
+	CodeConflict             = "CONFLICT"
+	CodeUnsupportedMedia     = "UNSUPPORTED_MEDIA_TYPE"
+	CodePatchTestFailed      = "PATCH_TEST_FAILED"
+	CodePreconditionRequired = "PRECONDITION_REQUIRED"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodeUnauthenticated      = "UNAUTHENTICATED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeScanError            = "SCAN_ERROR"
+	CodeFindError            = "FIND_ERROR"
+	CodeFindAllError         = "FIND_ALL_ERROR"
+	CodeUnknownDriver        = "UNKNOWN_DRIVER"
+	CodeNoConnection         = "NO_CONNECTION"
+	CodeMigrationError       = "MIGRATION_ERROR"
+	CodeMigrationChecksum    = "MIGRATION_CHECKSUM"
+	CodeMigrationNotFound    = "MIGRATION_NOT_FOUND"
+	CodeMigrationUnsupported = "MIGRATION_UNSUPPORTED"
+	CodeJobInvalid           = "JOB_INVALID"
+	CodeJobDuplicate         = "JOB_DUPLICATE"
+	CodeJobRunning           = "JOB_RUNNING"
+	CodeSchedulerStopped     = "SCHEDULER_STOPPED"
+	CodeJobLocked            = "JOB_LOCKED"
+	CodeJobSlotDone          = "JOB_SLOT_DONE"
+	CodeLockError            = "LOCK_ERROR"
+	CodeLockLost             = "LOCK_LOST"
+	CodeTemplateError        = "TEMPLATE_ERROR"
+	CodeUnknownError         = "UNKNOWN_ERROR"
+)
+
 type NotificationData struct {
 	Entity string
 	Data   string
 }
 
+// FieldError is the failure of one field of a request payload, named as in the payload.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
 type DomainError struct {
 	Message string
-	Code    string
-	Cause   error
+	// PublicMessage is Message rendered without the underlying error data, safe to show to API clients.
+	PublicMessage string
+	Code          string
+	Cause         error
+	// Fields lists every invalid field of the payload, for validation errors.
+	Fields []FieldError
 }
 
 func (e *DomainError) Error() string {
@@ -26,6 +73,20 @@ func (e *DomainError) Unwrap() error {
 	return e.Cause
 }
 
+// CodeOf returns the code of the outermost DomainError in err's chain, or an empty string.
+func CodeOf(err error) string {
+	var domainError *DomainError
+	if errors.As(err, &domainError) {
+		return domainError.Code
+	}
+	return ""
+}
+
+// HasCode reports whether the outermost DomainError in err's chain has the given code.
+func HasCode(err error, code string) bool {
+	return CodeOf(err) == code
+}
+
 var templateCache = struct {
 	sync.RWMutex
 	templates map[string]*templateTx.Template
@@ -76,11 +137,7 @@ func CreateCustomNotification(template string, entity string, data interface{})
 
 	tmpl := getOrCreateTemplate(template)
 	if err := tmpl.Execute(&buffer, notificationData); err != nil {
-		return &DomainError{
-			Message: "Erro ao processar template de notificação",
-			Code:    "TEMPLATE_ERROR",
-			Cause:   err,
-		}
+		return templateError(err)
 	}
 
 	var cause error
@@ -89,35 +146,55 @@ func CreateCustomNotification(template string, entity string, data interface{})
 	}
 
 	return &DomainError{
-		Message: buffer.String(),
-		Code:    getErrorCode(template),
-		Cause:   cause,
+		Message:       buffer.String(),
+		PublicMessage: renderPublicMessage(tmpl, entity),
+		Code:          getErrorCode(template),
+		Cause:         cause,
 	}
 }
 
 func CreateSimpleNotification(template string, data error) error {
 	var buffer bytes.Buffer
+	var errorMessage string
+
+	if data != nil {
+		errorMessage = data.Error()
+	}
 
 	notificationData := NotificationData{
 		Entity: "",
-		Data:   data.Error(),
+		Data:   errorMessage,
 	}
 
-	if err := getOrCreateTemplate(template).Execute(&buffer, notificationData); err != nil {
-		return &DomainError{
-			Message: "Erro ao processar template de notificação",
-			Code:    "TEMPLATE_ERROR",
-			Cause:   err,
-		}
+	tmpl := getOrCreateTemplate(template)
+	if err := tmpl.Execute(&buffer, notificationData); err != nil {
+		return templateError(err)
 	}
 
 	return &DomainError{
-		Message: buffer.String(),
-		Code:    getErrorCode(template),
-		Cause:   data,
+		Message:       buffer.String(),
+		PublicMessage: renderPublicMessage(tmpl, ""),
+		Code:          getErrorCode(template),
+		Cause:         data,
 	}
 }
 
+// CreateValidationNotification builds an INVALID_DATA error for entity carrying every field error.
+func CreateValidationNotification(entity string, fields []FieldError) error {
+	messages := make([]string, len(fields))
+	for index, field := range fields {
+		messages[index] = field.Field + ": " + field.Message
+	}
+
+	err := CreateCustomNotification(InvalidData, entity, strings.Join(messages, "; "))
+
+	var domainError *DomainError
+	if errors.As(err, &domainError) {
+		domainError.Fields = fields
+	}
+	return err
+}
+
 func CreateNotification(template string) error {
 	var buffer bytes.Buffer
 
@@ -126,26 +203,93 @@ func CreateNotification(template string) error {
 	}
 
 	return &DomainError{
-		Message: buffer.String(),
-		Code:    getErrorCode(template),
+		Message:       buffer.String(),
+		PublicMessage: buffer.String(),
+		Code:          getErrorCode(template),
+	}
+}
+
+func templateError(err error) error {
+	return &DomainError{
+		Message:       "Erro ao processar template de notificação",
+		PublicMessage: "Erro ao processar template de notificação",
+		Code:          CodeTemplateError,
+		Cause:         err,
+	}
+}
+
+// renderPublicMessage renders the template without Data, dropping driver and parser messages.
+func renderPublicMessage(tmpl *templateTx.Template, entity string) string {
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, NotificationData{Entity: entity}); err != nil {
+		return ""
 	}
+	return buffer.String()
 }
 
 func getErrorCode(template string) string {
 	switch template {
 	case NotFound:
-		return "NOT_FOUND"
+		return CodeNotFound
 	case InvalidData:
-		return "INVALID_DATA"
+		return CodeInvalidData
 	case InvalidMethod:
-		return "INVALID_METHOD"
+		return CodeInvalidMethod
+	case InvalidState:
+		return CodeInvalidState
+	case InvalidStatusTransition:
+		return CodeInvalidTransition
+	case Conflict:
+		return CodeConflict
+	case UnsupportedMediaType:
+		return CodeUnsupportedMedia
+	case PatchTestFailed:
+		return CodePatchTestFailed
+	case PreconditionRequired:
+		return CodePreconditionRequired
+	case PreconditionFailed:
+		return CodePreconditionFailed
+	case Unauthenticated:
+		return CodeUnauthenticated
+	case Forbidden:
+		return CodeForbidden
+	case TooManyRequests:
+		return CodeRateLimited
 	case ScanErrorRepository:
-		return "SCAN_ERROR"
+		return CodeScanError
 	case FindErrorRepository:
-		return "FIND_ERROR"
+		return CodeFindError
 	case FindAllErrorRepository:
-		return "FIND_ALL_ERROR"
+		return CodeFindAllError
+	case ErrorUnknownDriver:
+		return CodeUnknownDriver
+	case ErrorNoConnection:
+		return CodeNoConnection
+	case ErrorMigrationFailed:
+		return CodeMigrationError
+	case ErrorMigrationChecksum:
+		return CodeMigrationChecksum
+	case ErrorMigrationNotFound:
+		return CodeMigrationNotFound
+	case ErrorMigrationUnsupported:
+		return CodeMigrationUnsupported
+	case ErrorJobInvalid:
+		return CodeJobInvalid
+	case ErrorJobDuplicate:
+		return CodeJobDuplicate
+	case ErrorJobRunning:
+		return CodeJobRunning
+	case ErrorSchedulerStopped:
+		return CodeSchedulerStopped
+	case ErrorJobLocked:
+		return CodeJobLocked
+	case ErrorJobSlotDone:
+		return CodeJobSlotDone
+	case ErrorLockFailed:
+		return CodeLockError
+	case ErrorLockLost:
+		return CodeLockLost
 	default:
-		return "UNKNOWN_ERROR"
+		return CodeUnknownError
 	}
 }
@@ -0,0 +1,46 @@
+package values
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var ErrInvalidEmail = errors.New("email inválido")
+
+// Email is an address in canonical form: trimmed, with the domain lower-cased and converted to ASCII (IDNA),
+// so "Ana@Exämple.COM" becomes "Ana@xn--exmple-cua.com". The local part keeps its case, since mail servers
+// may tell it apart; Normalized folds it for comparisons.
+type Email string
+
+// ParseEmail accepts a bare address such as "user@example.com", without a display name.
+func ParseEmail(raw string) (Email, error) {
+	value := strings.TrimSpace(raw)
+
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Name != "" || address.Address != value {
+		return "", ErrInvalidEmail
+	}
+
+	at := strings.LastIndex(value, "@")
+	local, domain := value[:at], value[at+1:]
+
+	asciiDomain, err := idna.Lookup.ToASCII(domain)
+	if err != nil || !strings.Contains(asciiDomain, ".") {
+		return "", ErrInvalidEmail
+	}
+
+	return Email(local + "@" + asciiDomain), nil
+}
+
+func (email Email) String() string {
+	return string(email)
+}
+
+// Normalized returns the upper-cased address, equal for addresses that only differ in case. It folds case
+// like Login.Normalized, so an email and the login made from it normalize to the same value.
+func (email Email) Normalized() string {
+	return strings.ToUpper(strings.TrimSpace(string(email)))
+}
@@ -0,0 +1,162 @@
+package values
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	configIO "fmt"
+	"strings"
+)
+
+var ErrInvalidGUID = errors.New("GUID inválido")
+
+// GUID is a 128-bit identifier held in the byte order of its text form, so "00112233-4455-6677-8899-AABBCCDDEEFF"
+// is 0x00, 0x11, ... 0xFF. SQL Server's uniqueidentifier stores the first three groups little-endian instead;
+// Scan converts that order and Value sends the text form, which SQL Server converts back itself.
+type GUID [16]byte
+
+// NewGUID returns a random (version 4) GUID.
+func NewGUID() GUID {
+	var guid GUID
+	_, _ = rand.Read(guid[:])
+	guid[6] = (guid[6] & 0x0f) | 0x40
+	guid[8] = (guid[8] & 0x3f) | 0x80
+	return guid
+}
+
+// ParseGUID accepts, in any case, the canonical forms "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", the same wrapped in
+// braces or parentheses, the 32 digits without hyphens and "urn:uuid:" followed by the hyphenated form.
+func ParseGUID(raw string) (GUID, error) {
+	value := strings.TrimSpace(raw)
+
+	switch {
+	case len(value) == 45 && strings.EqualFold(value[:9], "urn:uuid:"):
+		value = value[9:]
+	case len(value) == 38 && (value[0] == '{' && value[37] == '}' || value[0] == '(' && value[37] == ')'):
+		value = value[1:37]
+	}
+
+	switch len(value) {
+	case 36:
+		if value[8] != '-' || value[13] != '-' || value[18] != '-' || value[23] != '-' {
+			return GUID{}, ErrInvalidGUID
+		}
+		value = value[:8] + value[9:13] + value[14:18] + value[19:23] + value[24:]
+	case 32:
+	default:
+		return GUID{}, ErrInvalidGUID
+	}
+
+	var guid GUID
+	if _, err := hex.Decode(guid[:], []byte(value)); err != nil {
+		return GUID{}, ErrInvalidGUID
+	}
+	return guid, nil
+}
+
+// MustParseGUID is ParseGUID for constants, panicking on an invalid value.
+func MustParseGUID(raw string) GUID {
+	guid, err := ParseGUID(raw)
+	if err != nil {
+		panic(configIO.Sprintf("values: GUID inválido %q", raw))
+	}
+	return guid
+}
+
+// GUIDFromSqlServer converts the 16 bytes of a uniqueidentifier as SQL Server stores and returns them.
+func GUIDFromSqlServer(raw []byte) (GUID, error) {
+	if len(raw) != len(GUID{}) {
+		return GUID{}, ErrInvalidGUID
+	}
+
+	var guid GUID
+	copy(guid[:], raw)
+	reverse(guid[0:4])
+	reverse(guid[4:6])
+	reverse(guid[6:8])
+	return guid, nil
+}
+
+// SqlServerBytes returns guid in the byte order of a SQL Server uniqueidentifier.
+func (guid GUID) SqlServerBytes() []byte {
+	raw := guid
+	reverse(raw[0:4])
+	reverse(raw[4:6])
+	reverse(raw[6:8])
+	return raw[:]
+}
+
+func reverse(group []byte) {
+	for left, right := 0, len(group)-1; left < right; left, right = left+1, right-1 {
+		group[left], group[right] = group[right], group[left]
+	}
+}
+
+// String returns the hyphenated form in upper case, as SQL Server displays it.
+func (guid GUID) String() string {
+	return strings.ToUpper(configIO.Sprintf("%x-%x-%x-%x-%x", guid[0:4], guid[4:6], guid[6:8], guid[8:10], guid[10:16]))
+}
+
+func (guid GUID) IsZero() bool {
+	return guid == GUID{}
+}
+
+// Compare orders GUIDs as their text forms.
+func (guid GUID) Compare(other GUID) int {
+	return bytes.Compare(guid[:], other[:])
+}
+
+// Scan reads a SQL Server uniqueidentifier (16 bytes) or a GUID stored as text, as in SQLite. NULL is the zero GUID.
+func (guid *GUID) Scan(src any) error {
+	var err error
+	switch value := src.(type) {
+	case nil:
+		*guid = GUID{}
+	case []byte:
+		if len(value) == len(GUID{}) {
+			*guid, err = GUIDFromSqlServer(value)
+		} else {
+			*guid, err = ParseGUID(string(value))
+		}
+	case string:
+		*guid, err = ParseGUID(value)
+	default:
+		err = configIO.Errorf("values: não é possível ler um GUID de %T", src)
+	}
+	return err
+}
+
+// Value sends the text form, accepted both by SQLite text columns and by SQL Server uniqueidentifier ones.
+func (guid GUID) Value() (driver.Value, error) {
+	return guid.String(), nil
+}
+
+func (guid GUID) MarshalText() ([]byte, error) {
+	return []byte(guid.String()), nil
+}
+
+func (guid *GUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseGUID(string(text))
+	if err != nil {
+		return err
+	}
+	*guid = parsed
+	return nil
+}
+
+func (guid GUID) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + guid.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a string in any of the ParseGUID forms; null leaves guid unchanged.
+func (guid *GUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return ErrInvalidGUID
+	}
+	return guid.UnmarshalText(data[1 : len(data)-1])
+}
@@ -0,0 +1,20 @@
+package values
+
+import "strings"
+
+// Login is the name a user signs in with. Users sign in with their email, as in ASP.NET Identity.
+type Login string
+
+func LoginFromEmail(email Email) Login {
+	return Login(email)
+}
+
+func (login Login) String() string {
+	return string(login)
+}
+
+// Normalized returns the value stored in normalized_login and used to keep logins unique, following
+// ASP.NET Identity's upper-case convention.
+func (login Login) Normalized() string {
+	return strings.ToUpper(strings.TrimSpace(string(login)))
+}
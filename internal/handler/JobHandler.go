@@ -0,0 +1,96 @@
+package handler
+
+import (
+	notify "PocGo/internal/domain/notification"
+	handlerBase "PocGo/internal/handler/base"
+	applicationService "PocGo/internal/services"
+	httpclient "net/http"
+	"strconv"
+)
+
+type JobHandler interface {
+	GetAll(writer httpclient.ResponseWriter, request *httpclient.Request)
+	GetRuns(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Trigger(writer httpclient.ResponseWriter, request *httpclient.Request)
+}
+
+type jobHandler struct {
+	service applicationService.JobService
+}
+
+func NewJobHandler(service applicationService.JobService) JobHandler {
+	return &jobHandler{service: service}
+}
+
+func (handler *jobHandler) GetAll(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidationGetMethod(responseWriter, request); err != nil {
+		return
+	}
+
+	jobs, err := handler.service.GetAll(request.Context())
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	if err := handlerBase.SendJsonResponse(responseWriter, request, jobs); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+func (handler *jobHandler) GetRuns(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidationGetMethod(responseWriter, request); err != nil {
+		return
+	}
+
+	limit := 0
+	if value := handlerBase.GetFromQuery(request, "limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			handlerBase.SendErrorResponse(responseWriter, request,
+				notify.CreateCustomNotification(notify.InvalidData, applicationService.JobEntity, "limit deve ser um inteiro positivo"))
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := handler.service.GetRuns(request.Context(), handlerBase.GetFromPath(request, "name"), limit)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	if err := handlerBase.SendJsonResponse(responseWriter, request, runs); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+// Trigger answers 202 Accepted with the run as started; its outcome is read from the runs endpoint.
+func (handler *jobHandler) Trigger(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodPost); err != nil {
+		return
+	}
+
+	dryRun := false
+	if value := handlerBase.GetFromQuery(request, "dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			handlerBase.SendErrorResponse(responseWriter, request,
+				notify.CreateCustomNotification(notify.InvalidData, applicationService.JobEntity, "dryRun deve ser true ou false"))
+			return
+		}
+		dryRun = parsed
+	}
+
+	name := handlerBase.GetFromPath(request, "name")
+	run, err := handler.service.Trigger(request.Context(), name, dryRun)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	responseWriter.Header().Set("Location", "/admin/jobs/"+name+"/runs")
+	if err := handlerBase.SendJsonResponseWithStatus(responseWriter, request, run, httpclient.StatusAccepted); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
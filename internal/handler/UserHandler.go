@@ -1,17 +1,32 @@
 package handler
 
 import (
+	"PocGo/internal/domain/dto"
 	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
 	handlerBase "PocGo/internal/handler/base"
+	"PocGo/internal/patch"
+	repository "PocGo/internal/repositories"
 	applicationService "PocGo/internal/services"
-	setJson "encoding/json"
+	"errors"
+	configIO "fmt"
+	"io"
 	httpclient "net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type UserHandler interface {
 	Update(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Patch(writer httpclient.ResponseWriter, request *httpclient.Request)
 	GetById(writer httpclient.ResponseWriter, request *httpclient.Request)
 	GetAll(writer httpclient.ResponseWriter, request *httpclient.Request)
+	List(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Create(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Delete(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Restore(writer httpclient.ResponseWriter, request *httpclient.Request)
+	Reactivate(writer httpclient.ResponseWriter, request *httpclient.Request)
 }
 
 type userHandler struct {
@@ -27,19 +42,83 @@ func (handler *userHandler) Update(responseWriter httpclient.ResponseWriter, req
 		return
 	}
 
-	var user entity.User
-	if err := setJson.NewDecoder(request.Body).Decode(&user); err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusBadRequest)
+	// The versioned route takes the ID from the path; the legacy one only has the body ID.
+	var changes dto.UpdateUserRequest
+	id := handlerBase.GetFromPath(request, "id")
+	if id != "" {
+		if err := handlerBase.DecodeRequest(responseWriter, request, &changes, applicationService.Entity); err != nil {
+			handlerBase.SendErrorResponse(responseWriter, request, err)
+			return
+		}
+	} else {
+		var legacy dto.LegacyUpdateUserRequest
+		if err := handlerBase.DecodeRequest(responseWriter, request, &legacy, applicationService.Entity); err != nil {
+			handlerBase.SendErrorResponse(responseWriter, request, err)
+			return
+		}
+		id, changes = legacy.ID, legacy.UpdateUserRequest
+	}
+
+	user := changes.ToUser(id)
+	version, ok := handler.matchedVersion(responseWriter, request, user.ID.String())
+	if !ok {
+		return
+	}
+	user.Version = version
+
+	if err := handler.service.Update(request.Context(), &user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	handlerBase.SetETag(responseWriter, user.Version)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+// Patch applies a JSON Merge Patch (application/merge-patch+json) or a JSON Patch (application/json-patch+json)
+// to the user and answers the updated resource. Other content types get 415 with the accepted ones in Accept-Patch.
+func (handler *userHandler) Patch(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodPatch); err != nil {
+		return
+	}
+
+	body, err := io.ReadAll(httpclient.MaxBytesReader(responseWriter, request.Body, handlerBase.MaxRequestBodySize))
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.InvalidData, applicationService.Entity, err))
+		return
+	}
+
+	changes, err := patch.ForContentType(request.Header.Get(handlerBase.ContentTypeKey), body)
+	if err != nil {
+		if errors.Is(err, patch.ErrUnsupportedMediaType) {
+			responseWriter.Header().Set("Accept-Patch", patch.AcceptedMediaTypes)
+			handlerBase.SendErrorResponse(responseWriter, request,
+				notify.CreateCustomNotification(notify.UnsupportedMediaType, applicationService.Entity, request.Header.Get(handlerBase.ContentTypeKey)))
+			return
+		}
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.InvalidData, applicationService.Entity, err))
+		return
+	}
+
+	id := handlerBase.GetFromPath(request, "id")
+	version, ok := handler.matchedVersion(responseWriter, request, id)
+	if !ok {
 		return
 	}
 
-	if err := handler.service.Update(&user); err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+	user, err := handler.service.Patch(request.Context(), id, version, changes)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
 		return
 	}
 
-	if err := handlerBase.SendJsonResponse(responseWriter, user); err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+	handlerBase.SetETag(responseWriter, user.Version)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
 	}
 }
 
@@ -48,24 +127,31 @@ func (handler *userHandler) GetById(responseWriter httpclient.ResponseWriter, re
 		return
 	}
 
-	id := handlerBase.GetFromQuery(request, "id")
+	id := handlerBase.GetFromPath(request, "id")
+	if id == "" {
+		id = handlerBase.GetFromQuery(request, "id")
+	}
 	if id == "" {
-		handlerBase.SendErrorResponse(
-			responseWriter,
-			setJson.NewDecoder(nil).Decode(nil),
-			httpclient.StatusBadRequest,
-		)
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.InvalidData, applicationService.Entity, "id é obrigatório"))
 		return
 	}
 
-	user, err := handler.service.GetById(id)
+	user, err := handler.service.GetById(request.Context(), id)
 	if err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	etag := handlerBase.ETag(user.Version)
+	responseWriter.Header().Set(handlerBase.ETagKey, etag)
+	if handlerBase.MatchesIfNoneMatch(request.Header.Get(handlerBase.IfNoneMatchKey), etag) {
+		responseWriter.WriteHeader(httpclient.StatusNotModified)
 		return
 	}
 
-	if err := handlerBase.SendJsonResponse(responseWriter, user); err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
 	}
 }
 
@@ -74,15 +160,202 @@ func (handler *userHandler) GetAll(responseWriter httpclient.ResponseWriter, req
 		return
 	}
 
+	// The legacy route has always been called with "data"; "date" is the documented name.
 	date := handlerBase.GetFromQuery(request, "date")
+	if date == "" {
+		date = handlerBase.GetFromQuery(request, "data")
+	}
+
+	users, err := handler.service.GetAll(request.Context(), date)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	if err := handlerBase.SendJsonResponse(responseWriter, request, users); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+// List answers a page of users with its items, nextCursor and hasMore, plus Link headers to the first and next pages.
+// Query: limit, cursor, sort (creationDate, email or login; "-" prefix for descending), status (comma-separated names),
+// email and login (prefixes), createdFrom (inclusive) and createdTo (exclusive) as RFC 3339 or YYYY-MM-DD.
+func (handler *userHandler) List(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidationGetMethod(responseWriter, request); err != nil {
+		return
+	}
+
+	query, err := parseUserListQuery(request)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.InvalidData, applicationService.Entity, err))
+		return
+	}
+
+	page, err := handler.service.List(request.Context(), query)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	handlerBase.SetPaginationLinks(responseWriter, request, "cursor", page.NextCursor)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, page); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+func (handler *userHandler) Create(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodPost); err != nil {
+		return
+	}
+
+	var payload dto.CreateUserRequest
+	if err := handlerBase.DecodeRequest(responseWriter, request, &payload, applicationService.Entity); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	user := payload.ToUser()
+
+	if err := handler.service.Create(request.Context(), &user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	responseWriter.Header().Set("Location", strings.TrimSuffix(request.URL.Path, "/")+"/"+user.ID.String())
+	handlerBase.SetETag(responseWriter, user.Version)
+	if err := handlerBase.SendJsonResponseWithStatus(responseWriter, request, user, httpclient.StatusCreated); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+func (handler *userHandler) Delete(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodDelete); err != nil {
+		return
+	}
+
+	if err := handler.service.Delete(request.Context(), handlerBase.GetFromPath(request, "id")); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	handlerBase.SendNoContent(responseWriter)
+}
+
+func (handler *userHandler) Restore(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodPost); err != nil {
+		return
+	}
+
+	user, err := handler.service.Restore(request.Context(), handlerBase.GetFromPath(request, "id"))
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return
+	}
+
+	handlerBase.SetETag(responseWriter, user.Version)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+func (handler *userHandler) Reactivate(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+	if err := handlerBase.ValidateHTTPMethod(responseWriter, request, httpclient.MethodPost); err != nil {
+		return
+	}
 
-	users, err := handler.service.GetAll(date)
+	user, err := handler.service.Reactivate(request.Context(), handlerBase.GetFromPath(request, "id"))
 	if err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+		handlerBase.SendErrorResponse(responseWriter, request, err)
 		return
 	}
 
-	if err := handlerBase.SendJsonResponse(responseWriter, users); err != nil {
-		handlerBase.SendErrorResponse(responseWriter, err, httpclient.StatusInternalServerError)
+	handlerBase.SetETag(responseWriter, user.Version)
+	if err := handlerBase.SendJsonResponse(responseWriter, request, user); err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+	}
+}
+
+// matchedVersion checks the If-Match header of a write against the stored user, answering 428 when it is missing
+// and 412 when it does not match. It returns the version the write must be based on.
+func (handler *userHandler) matchedVersion(responseWriter httpclient.ResponseWriter, request *httpclient.Request, id string) (int64, bool) {
+	ifMatch := request.Header.Get(handlerBase.IfMatchKey)
+	if ifMatch == "" {
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.PreconditionRequired, applicationService.Entity, "envie o ETag obtido na consulta"))
+		return 0, false
+	}
+
+	current, err := handler.service.GetById(request.Context(), id)
+	if err != nil {
+		handlerBase.SendErrorResponse(responseWriter, request, err)
+		return 0, false
+	}
+
+	etag := handlerBase.ETag(current.Version)
+	if !handlerBase.MatchesIfMatch(ifMatch, etag) {
+		responseWriter.Header().Set(handlerBase.ETagKey, etag)
+		handlerBase.SendErrorResponse(responseWriter, request,
+			notify.CreateCustomNotification(notify.PreconditionFailed, applicationService.Entity, ifMatch))
+		return 0, false
+	}
+
+	return current.Version, true
+}
+
+func parseUserListQuery(request *httpclient.Request) (repository.UserListQuery, error) {
+	query := repository.UserListQuery{
+		Cursor: handlerBase.GetFromQuery(request, "cursor"),
+		Filter: repository.UserFilter{
+			EmailPrefix: handlerBase.GetFromQuery(request, "email"),
+			LoginPrefix: handlerBase.GetFromQuery(request, "login"),
+		},
+	}
+
+	if value := handlerBase.GetFromQuery(request, "limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			return query, errors.New("limit deve ser um inteiro positivo")
+		}
+		query.Limit = limit
+	}
+
+	sort := handlerBase.GetFromQuery(request, "sort")
+	query.Sort, query.Descending = strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+
+	if value := handlerBase.GetFromQuery(request, "status"); value != "" {
+		for _, name := range strings.Split(value, ",") {
+			status, err := entity.ParseUserStatus(name)
+			if err != nil {
+				return query, err
+			}
+			query.Filter.Statuses = append(query.Filter.Statuses, status)
+		}
+	}
+
+	var err error
+	if query.Filter.CreatedFrom, err = parseQueryDate(request, "createdFrom"); err != nil {
+		return query, err
+	}
+	if query.Filter.CreatedTo, err = parseQueryDate(request, "createdTo"); err != nil {
+		return query, err
+	}
+
+	return query, nil
+}
+
+// parseQueryDate reads an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC); a missing key is the zero time.
+func parseQueryDate(request *httpclient.Request, key string) (time.Time, error) {
+	value := handlerBase.GetFromQuery(request, key)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed, nil
 	}
+	return time.Time{}, configIO.Errorf("%s deve estar no formato RFC 3339 ou AAAA-MM-DD", key)
 }
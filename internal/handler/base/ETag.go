@@ -0,0 +1,47 @@
+package base
+
+import (
+	httpclient "net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	ETagKey        = "ETag"
+	IfMatchKey     = "If-Match"
+	IfNoneMatchKey = "If-None-Match"
+)
+
+// ETag returns the strong entity tag of a resource version.
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// SetETag writes the ETag header for version.
+func SetETag(responseWriter httpclient.ResponseWriter, version int64) {
+	responseWriter.Header().Set(ETagKey, ETag(version))
+}
+
+// MatchesIfMatch evaluates an If-Match header against etag with the strong comparison of RFC 9110:
+// "*" matches any current representation and weak tags never match.
+func MatchesIfMatch(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesIfNoneMatch reports whether an If-None-Match header matches etag, using the weak comparison
+// of RFC 9110, so a GET can be answered with 304 Not Modified.
+func MatchesIfNoneMatch(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
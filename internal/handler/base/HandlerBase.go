@@ -2,8 +2,12 @@ package base
 
 import (
 	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/tracing"
+	"PocGo/internal/validation"
 	setJson "encoding/json"
+	muxRouter "github.com/gorilla/mux"
 	httpclient "net/http"
+	"net/url"
 )
 
 const (
@@ -12,12 +16,17 @@ const (
 	AcceptKey        = "Accept"
 )
 
+// MaxRequestBodySize bounds the body of write requests.
+const MaxRequestBodySize = 1 << 20
+
 // ValidateHTTPMethod validates if the request method matches the expected method
 // Returns an error if the method is not allowed
 func ValidateHTTPMethod(responseWriter httpclient.ResponseWriter, request *httpclient.Request, method string) error {
 	if request.Method != method {
-		httpclient.Error(responseWriter, notify.InvalidMethod, httpclient.StatusMethodNotAllowed)
-		return notify.CreateNotification(notify.InvalidMethod)
+		err := notify.CreateNotification(notify.InvalidMethod)
+		responseWriter.Header().Set("Allow", method)
+		SendErrorResponse(responseWriter, request, err)
+		return err
 	}
 
 	return nil
@@ -29,14 +38,47 @@ func ValidationGetMethod(responseWriter httpclient.ResponseWriter, request *http
 	return ValidateHTTPMethod(responseWriter, request, httpclient.MethodGet)
 }
 
+// DecodeRequest decodes and validates the JSON body of request into target, a request DTO.
+// Failures are INVALID_DATA errors of entity listing the invalid fields.
+func DecodeRequest(responseWriter httpclient.ResponseWriter, request *httpclient.Request, target any, entity string) error {
+	body := httpclient.MaxBytesReader(responseWriter, request.Body, MaxRequestBodySize)
+	return validation.DecodeJSON(body, target, entity)
+}
+
 // GetFromQuery extracts a query parameter from the request URL
 func GetFromQuery(request *httpclient.Request, key string) string {
 	return request.URL.Query().Get(key)
 }
 
-// SendErrorResponse sends an error response with the appropriate status code
-func SendErrorResponse(responseWriter httpclient.ResponseWriter, err error, statusCode int) {
-	httpclient.Error(responseWriter, err.Error(), statusCode)
+// GetFromPath extracts a path variable declared in the route template, e.g. {id}
+func GetFromPath(request *httpclient.Request, key string) string {
+	return muxRouter.Vars(request)[key]
+}
+
+// SetPaginationLinks writes an RFC 8288 Link header with the first page and, when nextCursor is set,
+// the next one. Both keep the request query, replacing only the cursor parameter.
+func SetPaginationLinks(responseWriter httpclient.ResponseWriter, request *httpclient.Request, cursorKey string, nextCursor string) {
+	pageLink := func(cursor string, rel string) string {
+		query := request.URL.Query()
+		query.Del(cursorKey)
+		if cursor != "" {
+			query.Set(cursorKey, cursor)
+		}
+
+		target := url.URL{Path: request.URL.Path, RawQuery: query.Encode()}
+		return "<" + target.String() + ">; rel=\"" + rel + "\""
+	}
+
+	// Added rather than set, so links from middlewares (e.g. successor-version) are kept.
+	responseWriter.Header().Add("Link", pageLink("", "first"))
+	if nextCursor != "" {
+		responseWriter.Header().Add("Link", pageLink(nextCursor, "next"))
+	}
+}
+
+// SendNoContent writes a 204 No Content response
+func SendNoContent(responseWriter httpclient.ResponseWriter) {
+	responseWriter.WriteHeader(httpclient.StatusNoContent)
 }
 
 // SetResponseHeaders sets common headers for HTTP responses
@@ -46,17 +88,20 @@ func SetResponseHeaders(responseWriter httpclient.ResponseWriter) {
 }
 
 // SendJsonResponse sets headers and sends a JSON response with status 200 OK
-func SendJsonResponse[T any](responseWriter httpclient.ResponseWriter, data T) error {
-	SetResponseHeaders(responseWriter)
-	responseWriter.WriteHeader(httpclient.StatusOK)
-	return setJson.NewEncoder(responseWriter).Encode(data)
+func SendJsonResponse[T any](responseWriter httpclient.ResponseWriter, request *httpclient.Request, data T) error {
+	return SendJsonResponseWithStatus(responseWriter, request, data, httpclient.StatusOK)
 }
 
-// SendJsonResponseWithStatus sets headers and sends a JSON response with the specified status code
-func SendJsonResponseWithStatus[T any](responseWriter httpclient.ResponseWriter, data T, statusCode int) error {
+// SendJsonResponseWithStatus sets headers and sends a JSON response with the specified status code.
+// The encoding runs in a span of its own, so slow serialization shows apart from the service calls.
+func SendJsonResponseWithStatus[T any](responseWriter httpclient.ResponseWriter, request *httpclient.Request, data T, statusCode int) error {
+	_, span := tracing.Start(request.Context(), "json.Encode")
+
 	SetResponseHeaders(responseWriter)
 	responseWriter.WriteHeader(statusCode)
-	return setJson.NewEncoder(responseWriter).Encode(data)
+	err := setJson.NewEncoder(responseWriter).Encode(data)
+	tracing.End(span, err)
+	return err
 }
 
 // SetHeaders is kept for backward compatibility
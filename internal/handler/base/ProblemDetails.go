@@ -0,0 +1,153 @@
+package base
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/logging"
+	"PocGo/internal/middleware"
+	"context"
+	setJson "encoding/json"
+	"errors"
+	httpclient "net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	ContentTypeProblem = "application/problem+json"
+	problemTypePrefix  = "urn:pocgo:problem:"
+)
+
+// ProblemDetails is the RFC 7807 body sent for every error response.
+type ProblemDetails struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      string `json:"code"`
+	RequestId string `json:"requestId,omitempty"`
+	Cause     string `json:"cause,omitempty"`
+	// Errors lists the invalid fields of the request payload.
+	Errors []notify.FieldError `json:"errors,omitempty"`
+}
+
+// statusByCode maps DomainError codes to HTTP status codes. Unlisted codes are internal errors.
+var statusByCode = map[string]int{
+	notify.CodeNotFound:             httpclient.StatusNotFound,
+	notify.CodeInvalidData:          httpclient.StatusBadRequest,
+	notify.CodeInvalidMethod:        httpclient.StatusMethodNotAllowed,
+	notify.CodeInvalidState:         httpclient.StatusConflict,
+	notify.CodeInvalidTransition:    httpclient.StatusConflict,
+	notify.CodeConflict:             httpclient.StatusConflict,
+	notify.CodeUnsupportedMedia:     httpclient.StatusUnsupportedMediaType,
+	notify.CodePatchTestFailed:      httpclient.StatusConflict,
+	notify.CodePreconditionRequired: httpclient.StatusPreconditionRequired,
+	notify.CodePreconditionFailed:   httpclient.StatusPreconditionFailed,
+	notify.CodeUnauthenticated:      httpclient.StatusUnauthorized,
+	notify.CodeForbidden:            httpclient.StatusForbidden,
+	notify.CodeRateLimited:          httpclient.StatusTooManyRequests,
+	notify.CodeJobRunning:           httpclient.StatusConflict,
+	notify.CodeJobLocked:            httpclient.StatusConflict,
+	notify.CodeJobSlotDone:          httpclient.StatusConflict,
+	notify.CodeSchedulerStopped:     httpclient.StatusServiceUnavailable,
+	notify.CodeScanError:            httpclient.StatusInternalServerError,
+	notify.CodeFindError:            httpclient.StatusInternalServerError,
+	notify.CodeFindAllError:         httpclient.StatusInternalServerError,
+}
+
+var problemOptions = struct {
+	sync.RWMutex
+	hideCauses bool
+}{}
+
+// ConfigureProblemResponses controls whether error details coming from drivers and parsers reach the client.
+// Production deployments should hide them.
+func ConfigureProblemResponses(hideCauses bool) {
+	problemOptions.Lock()
+	defer problemOptions.Unlock()
+	problemOptions.hideCauses = hideCauses
+}
+
+func causesHidden() bool {
+	problemOptions.RLock()
+	defer problemOptions.RUnlock()
+	return problemOptions.hideCauses
+}
+
+// StatusForError returns the HTTP status matching the outermost DomainError code of err.
+func StatusForError(err error) int {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return httpclient.StatusGatewayTimeout
+	}
+
+	if status, exists := statusByCode[notify.CodeOf(err)]; exists {
+		return status
+	}
+
+	return httpclient.StatusInternalServerError
+}
+
+// NewProblemDetails builds the problem body for err as seen by request.
+func NewProblemDetails(request *httpclient.Request, err error) ProblemDetails {
+	status := StatusForError(err)
+	code := notify.CodeOf(err)
+	if code == "" {
+		code = notify.CodeUnknownError
+	}
+
+	problem := ProblemDetails{
+		Type:      problemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-"),
+		Title:     httpclient.StatusText(status),
+		Status:    status,
+		Instance:  request.URL.Path,
+		Code:      code,
+		RequestId: middleware.GetRequestId(request.Context()),
+	}
+
+	var domainError *notify.DomainError
+	isDomainError := errors.As(err, &domainError)
+
+	// Field errors describe the client's own payload, so they are sent even when causes are hidden.
+	if isDomainError {
+		problem.Errors = domainError.Fields
+	}
+
+	if causesHidden() {
+		if isDomainError {
+			problem.Detail = domainError.PublicMessage
+		}
+		return problem
+	}
+
+	problem.Detail = err.Error()
+	if isDomainError && domainError.Cause != nil {
+		problem.Cause = rootCause(domainError.Cause).Error()
+	}
+
+	return problem
+}
+
+// SendErrorResponse writes err as application/problem+json with the status mapped from its DomainError code.
+// Server errors are also logged with their full cause, which production responses leave out.
+func SendErrorResponse(responseWriter httpclient.ResponseWriter, request *httpclient.Request, err error) {
+	problem := NewProblemDetails(request, err)
+	if problem.Status >= httpclient.StatusInternalServerError {
+		logging.FromContext(request.Context(), nil).Error("Erro ao processar requisição",
+			"status", problem.Status, logging.KeyError, err)
+	}
+
+	responseWriter.Header().Set(ContentTypeKey, ContentTypeProblem)
+	responseWriter.Header().Set("X-Content-Type-Options", "nosniff")
+	responseWriter.WriteHeader(problem.Status)
+	_ = setJson.NewEncoder(responseWriter).Encode(problem)
+}
+
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -0,0 +1,83 @@
+package health
+
+import (
+	"context"
+	"errors"
+	configIO "fmt"
+	"time"
+)
+
+// Pinger is a database connection; *sql.DB satisfies it.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// HeartbeatSource reports when a background worker last proved alive; scheduler.Scheduler satisfies it.
+type HeartbeatSource interface {
+	// Heartbeat returns the zero time when the worker is not running.
+	Heartbeat() time.Time
+}
+
+// SchemaSource reports the applied and the expected schema versions; migrations.Runner satisfies it.
+type SchemaSource interface {
+	Version(ctx context.Context) (int64, error)
+	Latest() int64
+}
+
+type checkerFunc struct {
+	name  string
+	check func(ctx context.Context) (string, error)
+}
+
+func (checker checkerFunc) Name() string {
+	return checker.name
+}
+
+func (checker checkerFunc) Check(ctx context.Context) (string, error) {
+	return checker.check(ctx)
+}
+
+// NewChecker adapts check to a Checker named name.
+func NewChecker(name string, check func(ctx context.Context) (string, error)) Checker {
+	return checkerFunc{name: name, check: check}
+}
+
+// DatabaseChecker pings the connection pool.
+func DatabaseChecker(db Pinger) Checker {
+	return NewChecker("database", func(ctx context.Context) (string, error) {
+		return "", db.PingContext(ctx)
+	})
+}
+
+// SchedulerChecker fails when the scheduler is stopped or its last heartbeat is older than maxAge.
+func SchedulerChecker(source HeartbeatSource, maxAge time.Duration) Checker {
+	return NewChecker("scheduler", func(context.Context) (string, error) {
+		last := source.Heartbeat()
+		if last.IsZero() {
+			return "", errors.New("agendador parado")
+		}
+
+		detail := "último heartbeat em " + last.UTC().Format(time.RFC3339)
+		if age := time.Since(last); age > maxAge {
+			return detail, configIO.Errorf("sem heartbeat há %s", age.Round(time.Second))
+		}
+		return detail, nil
+	})
+}
+
+// MigrationChecker fails when the database schema is behind the migrations embedded in the binary.
+// A newer schema, left by a more recent release, is accepted.
+func MigrationChecker(source SchemaSource) Checker {
+	return NewChecker("migrations", func(ctx context.Context) (string, error) {
+		version, err := source.Version(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		detail := configIO.Sprintf("versão %d", version)
+		if latest := source.Latest(); version < latest {
+			return detail, configIO.Errorf("migrações pendentes: versão %d, esperada %d", version, latest)
+		}
+		return detail, nil
+	})
+}
@@ -0,0 +1,168 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+	// StatusShuttingDown is reported by readiness once shutdown begins, without running the checks.
+	StatusShuttingDown = "shutting_down"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+// Checker is one dependency the application needs to serve requests.
+type Checker interface {
+	Name() string
+	// Check returns an optional detail for the report, such as the schema version; an error marks the component down.
+	Check(ctx context.Context) (string, error)
+}
+
+// Component is the result of one Checker.
+type Component struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Detail    string  `json:"detail,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the body of the health endpoints. Components are listed in registration order.
+type Report struct {
+	Status     string      `json:"status"`
+	CheckedAt  time.Time   `json:"checked_at"`
+	Components []Component `json:"components,omitempty"`
+}
+
+func (report Report) IsUp() bool {
+	return report.Status == StatusUp
+}
+
+// Options configures a Health. Every field is optional.
+type Options struct {
+	// Timeout bounds each check; defaultTimeout when zero.
+	Timeout time.Duration
+	// CacheTTL is how long a readiness report is reused, so frequent probes do not reach the dependencies;
+	// defaultCacheTTL when zero, and a negative value checks on every call.
+	CacheTTL time.Duration
+}
+
+// Health runs the registered checkers for readiness. Liveness never runs them: a dependency being down
+// should take the instance out of the load balancer, not get the process restarted.
+type Health struct {
+	timeout      time.Duration
+	cacheTTL     time.Duration
+	shuttingDown atomic.Bool
+
+	mu          sync.Mutex
+	checkers    []Checker
+	cached      Report
+	cachedUntil time.Time
+}
+
+// New creates a Health without checkers; see Options for the defaults.
+func New(options Options) *Health {
+	health := &Health{timeout: options.Timeout, cacheTTL: options.CacheTTL}
+	if health.timeout <= 0 {
+		health.timeout = defaultTimeout
+	}
+	if health.cacheTTL == 0 {
+		health.cacheTTL = defaultCacheTTL
+	}
+	return health
+}
+
+// Register adds checker to readiness, discarding the cached report.
+func (health *Health) Register(checker Checker) {
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	health.checkers = append(health.checkers, checker)
+	health.cachedUntil = time.Time{}
+}
+
+// BeginShutdown makes readiness report StatusShuttingDown from now on, so the load balancer stops
+// sending requests while the in-flight ones finish.
+func (health *Health) BeginShutdown() {
+	health.shuttingDown.Store(true)
+}
+
+// Live reports that the process is running and able to answer.
+func (health *Health) Live() Report {
+	return Report{Status: StatusUp, CheckedAt: time.Now().UTC()}
+}
+
+// Ready runs every checker concurrently, or returns the report cached less than CacheTTL ago.
+// Concurrent calls wait for the same run. The checks outlive a cancelled ctx, as their result is shared.
+func (health *Health) Ready(ctx context.Context) Report {
+	if health.shuttingDown.Load() {
+		return Report{Status: StatusShuttingDown, CheckedAt: time.Now().UTC()}
+	}
+
+	health.mu.Lock()
+	defer health.mu.Unlock()
+
+	if time.Now().Before(health.cachedUntil) {
+		return health.cached
+	}
+
+	report := health.run(context.WithoutCancel(ctx))
+	if health.cacheTTL > 0 {
+		health.cached = report
+		health.cachedUntil = time.Now().Add(health.cacheTTL)
+	}
+	return report
+}
+
+// run must be called with health.mu held.
+func (health *Health) run(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusUp,
+		CheckedAt:  time.Now().UTC(),
+		Components: make([]Component, len(health.checkers)),
+	}
+
+	var checks sync.WaitGroup
+	for index, checker := range health.checkers {
+		checks.Add(1)
+		go func() {
+			defer checks.Done()
+			report.Components[index] = health.check(ctx, checker)
+		}()
+	}
+	checks.Wait()
+
+	for _, component := range report.Components {
+		if component.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (health *Health) check(ctx context.Context, checker Checker) Component {
+	ctx, cancel := context.WithTimeout(ctx, health.timeout)
+	defer cancel()
+
+	started := time.Now()
+	detail, err := checker.Check(ctx)
+	component := Component{
+		Name:      checker.Name(),
+		Status:    StatusUp,
+		LatencyMs: float64(time.Since(started).Microseconds()) / 1000,
+		Detail:    detail,
+	}
+	if err != nil {
+		component.Status = StatusDown
+		component.Error = err.Error()
+	}
+	return component
+}
@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/logging"
+	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
+	service "PocGo/internal/services"
+	"context"
+)
+
+const InactiveUserSweep = "inactive-user-sweep"
+
+func init() {
+	Register(InactiveUserSweep, newInactiveUserSweep)
+}
+
+// newInactiveUserSweep marks users older than RT_INACTIVE_MONTHS as inactive, on the RT_* schedule.
+func newInactiveUserSweep(deps Dependencies) scheduler.Job {
+	routine := deps.Configuration.Routine
+
+	return scheduler.Job{
+		Schedule:   routine.CronSchedule(),
+		TimeZone:   routine.TimeZone,
+		Jitter:     routine.Jitter,
+		Timeout:    deps.Configuration.Timeout.Job,
+		RunOnStart: routine.RunOnStart,
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			runLogger := logging.FromContext(ctx, nil)
+			result, err := deps.Services.User.UpdateOldUsersStatus(ctx, service.SweepOptions{
+				InactiveAfterMonths: routine.InactiveAfterMonths,
+				BatchSize:           routine.BatchSize,
+				DryRun:              run.DryRun,
+				Progress: func(progress repository.BatchResult) {
+					runLogger.Info("Agendador: Job em andamento", "processed", progress.Processed,
+						"updated", len(progress.Updated), "skipped", len(progress.Skipped), "failed", len(progress.Failed))
+				},
+			})
+
+			run.Processed = result.Processed
+			run.Updated = len(result.Updated)
+			for _, itemError := range result.Failed {
+				run.AddError(itemError.ItemID, itemError.Message)
+			}
+
+			return err
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package jobs
+
+import (
+	config "PocGo/internal/configuration"
+	"PocGo/internal/scheduler"
+	service "PocGo/internal/services"
+	"sort"
+	"sync"
+)
+
+// Dependencies is what job builders receive from the application.
+type Dependencies struct {
+	Configuration *config.Config
+	Services      *service.Services
+}
+
+// Builder creates a job from the application dependencies.
+type Builder func(deps Dependencies) scheduler.Job
+
+var registry = struct {
+	sync.Mutex
+	builders map[string]Builder
+}{
+	builders: make(map[string]Builder),
+}
+
+// Register makes a job available to RegisterAll. Jobs call it from init, so adding a job
+// only takes a new file in this package.
+func Register(name string, builder Builder) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.builders[name] = builder
+}
+
+// RegisterAll builds every registered job and adds it to jobScheduler, in name order.
+func RegisterAll(jobScheduler *scheduler.Scheduler, deps Dependencies) error {
+	registry.Lock()
+	names := make([]string, 0, len(registry.builders))
+	for name := range registry.builders {
+		names = append(names, name)
+	}
+	builders := registry.builders
+	registry.Unlock()
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		job := builders[name](deps)
+		job.Name = name
+		if err := jobScheduler.Register(job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	configIO "fmt"
+	setIO "os"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is used when a Locker is asked for a lease without a positive TTL.
+const DefaultTTL = time.Minute
+
+// Locker hands out named, exclusive leases shared by every replica using the same backend.
+type Locker interface {
+	// TryAcquire takes the lease on name without waiting. It returns acquired=false, and no error,
+	// when another holder has it. The lease is renewed in the background until released.
+	TryAcquire(ctx context.Context, name string, ttl time.Duration) (lease Lease, acquired bool, err error)
+}
+
+// Lease is a held lock.
+type Lease interface {
+	// Lost is closed when the lease could not be renewed; the holder must stop its work.
+	Lost() <-chan struct{}
+	// Release stops the renewal and frees the lock. Calling it more than once is harmless.
+	Release(ctx context.Context) error
+}
+
+// newOwnerId identifies one lease across replicas: host, process and a random suffix.
+func newOwnerId() string {
+	host, _ := setIO.Hostname()
+	suffix := make([]byte, 6)
+	_, _ = rand.Read(suffix)
+	return configIO.Sprintf("%s:%d:%x", host, setIO.Getpid(), suffix)
+}
+
+func normalizeTTL(ttl time.Duration) time.Duration {
+	if ttl <= 0 {
+		return DefaultTTL
+	}
+	return ttl
+}
+
+// renewingLease renews a lease every third of its TTL, so one failed renewal still leaves
+// time for the next before the lease expires.
+type renewingLease struct {
+	lost    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	release func(ctx context.Context) error
+	once    sync.Once
+	err     error
+}
+
+func startRenewal(ttl time.Duration, renew func(ctx context.Context) error, release func(ctx context.Context) error) *renewingLease {
+	lease := &renewingLease{
+		lost:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		release: release,
+	}
+
+	go lease.renewLoop(ttl/3, renew)
+	return lease
+}
+
+func (lease *renewingLease) renewLoop(interval time.Duration, renew func(ctx context.Context) error) {
+	defer close(lease.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			err := renew(ctx)
+			cancel()
+			if err != nil {
+				close(lease.lost)
+				return
+			}
+		case <-lease.stop:
+			return
+		}
+	}
+}
+
+func (lease *renewingLease) Lost() <-chan struct{} {
+	return lease.lost
+}
+
+func (lease *renewingLease) Release(ctx context.Context) error {
+	lease.once.Do(func() {
+		close(lease.stop)
+		<-lease.done
+		lease.err = lease.release(ctx)
+	})
+	return lease.err
+}
@@ -0,0 +1,76 @@
+package lock
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryHolder struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// MemoryLocker keeps the leases in process memory. It only coordinates the schedulers
+// sharing the instance, which is enough for a single replica and for tests.
+type MemoryLocker struct {
+	mu      sync.Mutex
+	holders map[string]memoryHolder
+}
+
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		holders: make(map[string]memoryHolder),
+	}
+}
+
+func (locker *MemoryLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (Lease, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	ttl = normalizeTTL(ttl)
+	owner := newOwnerId()
+
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+
+	if holder, held := locker.holders[name]; held && time.Now().Before(holder.expiresAt) {
+		return nil, false, nil
+	}
+	locker.holders[name] = memoryHolder{owner: owner, expiresAt: time.Now().Add(ttl)}
+
+	renew := func(context.Context) error {
+		return locker.extend(name, owner, ttl)
+	}
+	release := func(context.Context) error {
+		locker.release(name, owner)
+		return nil
+	}
+
+	return startRenewal(ttl, renew, release), true, nil
+}
+
+func (locker *MemoryLocker) extend(name string, owner string, ttl time.Duration) error {
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+
+	holder, held := locker.holders[name]
+	if !held || holder.owner != owner {
+		return notify.CreateCustomNotification(notify.ErrorLockLost, "", name)
+	}
+
+	holder.expiresAt = time.Now().Add(ttl)
+	locker.holders[name] = holder
+	return nil
+}
+
+func (locker *MemoryLocker) release(name string, owner string) {
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+
+	if holder, held := locker.holders[name]; held && holder.owner == owner {
+		delete(locker.holders, name)
+	}
+}
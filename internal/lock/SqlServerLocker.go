@@ -0,0 +1,76 @@
+package lock
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"context"
+	dbProvider "database/sql"
+	"database/sql/driver"
+	configIO "fmt"
+	"time"
+)
+
+const (
+	// LockTimeout = 0 makes sp_getapplock return -1 at once when the lock is taken.
+	getAppLockQuery = `DECLARE @result INT;
+EXEC @result = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = 0;
+SELECT @result;`
+	releaseAppLockQuery = `EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'`
+	appLockModeQuery    = `SELECT APPLOCK_MODE('public', @p1, 'Session')`
+)
+
+// SqlServerLocker uses sp_getapplock with a session owner. Each lease pins a dedicated
+// connection: SQL Server frees the lock when that session ends, so a crashed replica
+// never keeps it. Renewal checks that the session still holds the lock.
+type SqlServerLocker struct {
+	dataBase *dbProvider.DB
+}
+
+func NewSqlServerLocker(dataBase *dbProvider.DB) *SqlServerLocker {
+	return &SqlServerLocker{dataBase: dataBase}
+}
+
+func (locker *SqlServerLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (Lease, bool, error) {
+	conn, err := locker.dataBase.Conn(ctx)
+	if err != nil {
+		return nil, false, notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+	}
+
+	var result int
+	if err := conn.QueryRowContext(ctx, getAppLockQuery, name).Scan(&result); err != nil {
+		_ = conn.Close()
+		return nil, false, notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+	}
+
+	switch {
+	case result >= 0:
+	case result == -1:
+		_ = conn.Close()
+		return nil, false, nil
+	default:
+		_ = conn.Close()
+		return nil, false, notify.CreateCustomNotification(notify.ErrorLockFailed, "", configIO.Sprintf("sp_getapplock retornou %d", result))
+	}
+
+	renew := func(ctx context.Context) error {
+		var mode string
+		if err := conn.QueryRowContext(ctx, appLockModeQuery, name).Scan(&mode); err != nil {
+			return err
+		}
+		if mode != "Exclusive" {
+			return notify.CreateCustomNotification(notify.ErrorLockLost, "", name)
+		}
+		return nil
+	}
+	release := func(ctx context.Context) error {
+		if _, err := conn.ExecContext(ctx, releaseAppLockQuery, name); err != nil {
+			// The session may still hold the lock, so it is ended instead of going back to the pool.
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			_ = conn.Close()
+			return notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+		}
+		_ = conn.Close()
+		return nil
+	}
+
+	return startRenewal(normalizeTTL(ttl), renew, release), true, nil
+}
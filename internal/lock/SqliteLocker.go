@@ -0,0 +1,66 @@
+package lock
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"context"
+	dbProvider "database/sql"
+	"time"
+)
+
+// Times are stored as Unix milliseconds so comparisons do not depend on text formats.
+const (
+	sqliteAcquireQuery = `INSERT INTO scheduler_lock (name, owner, expires_at) VALUES (?, ?, ?)
+ON CONFLICT (name) DO UPDATE SET owner = excluded.owner, expires_at = excluded.expires_at
+WHERE scheduler_lock.expires_at < ?`
+	sqliteRenewQuery   = `UPDATE scheduler_lock SET expires_at = ? WHERE name = ? AND owner = ?`
+	sqliteReleaseQuery = `DELETE FROM scheduler_lock WHERE name = ? AND owner = ?`
+)
+
+// SqliteLocker keeps leases in the scheduler_lock table. A lease whose holder stopped
+// renewing it can be taken over once expires_at has passed.
+type SqliteLocker struct {
+	dataBase *dbProvider.DB
+}
+
+func NewSqliteLocker(dataBase *dbProvider.DB) *SqliteLocker {
+	return &SqliteLocker{dataBase: dataBase}
+}
+
+func (locker *SqliteLocker) TryAcquire(ctx context.Context, name string, ttl time.Duration) (Lease, bool, error) {
+	ttl = normalizeTTL(ttl)
+	owner := newOwnerId()
+	now := time.Now()
+
+	result, err := locker.dataBase.ExecContext(ctx, sqliteAcquireQuery,
+		name, owner, now.Add(ttl).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return nil, false, notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, false, notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+	}
+	if rowsAffected == 0 {
+		return nil, false, nil
+	}
+
+	renew := func(ctx context.Context) error {
+		result, err := locker.dataBase.ExecContext(ctx, sqliteRenewQuery, time.Now().Add(ttl).UnixMilli(), name, owner)
+		if err != nil {
+			return err
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil || rowsAffected == 0 {
+			return notify.CreateCustomNotification(notify.ErrorLockLost, "", name)
+		}
+		return nil
+	}
+	release := func(ctx context.Context) error {
+		if _, err := locker.dataBase.ExecContext(ctx, sqliteReleaseQuery, name, owner); err != nil {
+			return notify.CreateCustomNotification(notify.ErrorLockFailed, "", err)
+		}
+		return nil
+	}
+
+	return startRenewal(ttl, renew, release), true, nil
+}
@@ -0,0 +1,37 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of ctx carrying logger, which FromContext returns further down the call.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored by WithLogger, falling back to fallback and then to slog.Default.
+// Components keep their injected logger as fallback, so calls outside a request or job still log.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return OrDefault(fallback)
+}
+
+// With stores in ctx the context logger extended with args, e.g. With(ctx, KeyUserId, id).
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx, nil).With(args...))
+}
+
+// OrDefault returns logger, or slog.Default when it is nil.
+func OrDefault(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
@@ -0,0 +1,95 @@
+package logging
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	configIO "fmt"
+	"io"
+	"log/slog"
+	setIO "os"
+	"strings"
+)
+
+// Attribute keys shared by every log line, so request, user and job logs can be correlated.
+const (
+	KeyRequestId = "request_id"
+	KeyMethod    = "method"
+	KeyPath      = "path"
+	KeyRoute     = "route"
+	KeyUserId    = "user_id"
+	KeyPrincipal = "principal"
+	KeyJob       = "job"
+	KeyRunId     = "run_id"
+	KeyTraceId   = "trace_id"
+	KeySpanId    = "span_id"
+	KeyError     = "error"
+)
+
+// New builds the logger described by configuration; a nil configuration logs text at info level to stdout.
+func New(configuration *provider.LogConfig) (*slog.Logger, error) {
+	if configuration == nil {
+		configuration = &provider.LogConfig{}
+	}
+
+	level, err := ParseLevel(configuration.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, err := OpenOutput(configuration.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	handler, err := NewHandler(configuration.Format, level, writer)
+	if err != nil {
+		return nil, err
+	}
+	return slog.New(handler), nil
+}
+
+// NewHandler returns the JSON or text handler writing records of level and above to writer.
+func NewHandler(format string, level slog.Leveler, writer io.Writer) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+
+	switch strings.ToLower(format) {
+	case provider.LogFormatJson:
+		return slog.NewJSONHandler(writer, options), nil
+	case provider.LogFormatText, "":
+		return slog.NewTextHandler(writer, options), nil
+	default:
+		return nil, configIO.Errorf("logging: formato de log desconhecido %q", format)
+	}
+}
+
+// ParseLevel accepts debug, info, warn and error in any case; empty is info.
+func ParseLevel(raw string) (slog.Level, error) {
+	var level slog.Level
+	if raw == "" {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return slog.LevelInfo, configIO.Errorf("logging: nível de log desconhecido %q", raw)
+	}
+	return level, nil
+}
+
+// OpenOutput returns stdout, stderr or the file at output. The file stays open for the life of the process.
+func OpenOutput(output string) (io.Writer, error) {
+	switch strings.ToLower(output) {
+	case provider.LogOutputStdout, "":
+		return setIO.Stdout, nil
+	case provider.LogOutputStderr:
+		return setIO.Stderr, nil
+	}
+
+	file, err := setIO.OpenFile(output, setIO.O_CREATE|setIO.O_WRONLY|setIO.O_APPEND, 0o644)
+	if err != nil {
+		return nil, configIO.Errorf("logging: não foi possível abrir %s: %w", output, err)
+	}
+	return file, nil
+}
+
+// Discard returns a logger that drops every record, for tests and optional dependencies.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
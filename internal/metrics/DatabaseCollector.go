@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	dbProvider "database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// StatsSource is a connection pool; *sql.DB and database.Database satisfy it.
+type StatsSource interface {
+	Stats() dbProvider.DBStats
+}
+
+// databaseCollector reads the pool statistics on each scrape.
+type databaseCollector struct {
+	source StatsSource
+
+	maxOpen      *prometheus.Desc
+	open         *prometheus.Desc
+	inUse        *prometheus.Desc
+	idle         *prometheus.Desc
+	waitCount    *prometheus.Desc
+	waitDuration *prometheus.Desc
+}
+
+// RegisterDatabase exposes the pool statistics of source, labelled with driver.
+func (metrics *Metrics) RegisterDatabase(driver string, source StatsSource) error {
+	labels := prometheus.Labels{"driver": driver}
+	desc := func(name string, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db", name), help, nil, labels)
+	}
+
+	return metrics.registry.Register(&databaseCollector{
+		source:       source,
+		maxOpen:      desc("max_open_connections", "Limite de conexões abertas (0 = sem limite)."),
+		open:         desc("open_connections", "Conexões abertas, em uso ou ociosas."),
+		inUse:        desc("in_use_connections", "Conexões em uso."),
+		idle:         desc("idle_connections", "Conexões ociosas."),
+		waitCount:    desc("wait_count_total", "Total de esperas por uma conexão livre."),
+		waitDuration: desc("wait_duration_seconds_total", "Tempo total de espera por uma conexão livre."),
+	})
+}
+
+func (collector *databaseCollector) Describe(descriptions chan<- *prometheus.Desc) {
+	descriptions <- collector.maxOpen
+	descriptions <- collector.open
+	descriptions <- collector.inUse
+	descriptions <- collector.idle
+	descriptions <- collector.waitCount
+	descriptions <- collector.waitDuration
+}
+
+func (collector *databaseCollector) Collect(metrics chan<- prometheus.Metric) {
+	stats := collector.source.Stats()
+
+	metrics <- prometheus.MustNewConstMetric(collector.maxOpen, prometheus.GaugeValue, float64(stats.MaxOpenConnections))
+	metrics <- prometheus.MustNewConstMetric(collector.open, prometheus.GaugeValue, float64(stats.OpenConnections))
+	metrics <- prometheus.MustNewConstMetric(collector.inUse, prometheus.GaugeValue, float64(stats.InUse))
+	metrics <- prometheus.MustNewConstMetric(collector.idle, prometheus.GaugeValue, float64(stats.Idle))
+	metrics <- prometheus.MustNewConstMetric(collector.waitCount, prometheus.CounterValue, float64(stats.WaitCount))
+	metrics <- prometheus.MustNewConstMetric(collector.waitDuration, prometheus.CounterValue, stats.WaitDuration.Seconds())
+}
@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	httpclient "net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "pocgo"
+
+// unmatchedRoute labels the requests no route matched, so unknown paths cannot grow the label set.
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the application collectors and the registry served on /metrics. It observes the access
+// log (ObserveRequest) and the scheduler (scheduler.RunObserver), and reads the pool of RegisterDatabase.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+
+	jobRuns        *prometheus.CounterVec
+	jobDuration    *prometheus.HistogramVec
+	jobLastSuccess *prometheus.GaugeVec
+	jobUpdated     *prometheus.CounterVec
+	jobLockSkips   *prometheus.CounterVec
+}
+
+// New creates the collectors, registered with the Go runtime and process ones in a registry of their own.
+func New() *Metrics {
+	metrics := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Requisições HTTP atendidas, por método, template da rota e status.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latência das requisições HTTP, por método, template da rota e status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "Execuções de jobs concluídas, por job e status (succeeded, partial, failed).",
+		}, []string{"job", "status"}),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_run_duration_seconds",
+			Help:      "Duração das execuções de jobs.",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 300, 900, 1800},
+		}, []string{"job"}),
+		jobLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "job_last_success_timestamp_seconds",
+			Help:      "Horário Unix do fim da última execução sem falhas de cada job.",
+		}, []string{"job"}),
+		jobUpdated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_items_updated_total",
+			Help:      "Itens atualizados pelos jobs; no inactive-user-sweep, usuários atualizados por UpdateOldUsersStatus.",
+		}, []string{"job"}),
+		jobLockSkips: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_lock_skips_total",
+			Help:      "Execuções ignoradas porque outra instância mantinha o lock do job.",
+		}, []string{"job"}),
+	}
+
+	metrics.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.httpRequests,
+		metrics.httpDuration,
+		metrics.jobRuns,
+		metrics.jobDuration,
+		metrics.jobLastSuccess,
+		metrics.jobUpdated,
+		metrics.jobLockSkips,
+	)
+	return metrics
+}
+
+// Handler serves the registry in the Prometheus text exposition format.
+func (metrics *Metrics) Handler() httpclient.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{Registry: metrics.registry})
+}
+
+// ObserveRequest counts a served request; it is meant for middleware.AccessLogOptions.Observe.
+func (metrics *Metrics) ObserveRequest(entry middleware.AccessEntry) {
+	route := entry.Route
+	if route == "" {
+		route = unmatchedRoute
+	}
+
+	status := strconv.Itoa(entry.Status)
+	metrics.httpRequests.WithLabelValues(entry.Method, route, status).Inc()
+	metrics.httpDuration.WithLabelValues(entry.Method, route, status).Observe(entry.Duration.Seconds())
+}
+
+// RunFinished records a finished run. Only fully successful runs move the last success, as partial runs
+// left items behind, and dry runs do not count their items as updated.
+func (metrics *Metrics) RunFinished(run entity.JobRun) {
+	finishedAt := time.Now()
+	if run.FinishedAt != nil {
+		finishedAt = *run.FinishedAt
+	}
+
+	metrics.jobRuns.WithLabelValues(run.JobName, string(run.Status)).Inc()
+	metrics.jobDuration.WithLabelValues(run.JobName).Observe(finishedAt.Sub(run.StartedAt).Seconds())
+	if !run.DryRun {
+		metrics.jobUpdated.WithLabelValues(run.JobName).Add(float64(run.Updated))
+	}
+
+	if run.Status == entity.JobRunSucceeded {
+		metrics.jobLastSuccess.WithLabelValues(run.JobName).Set(float64(finishedAt.Unix()))
+	}
+}
+
+func (metrics *Metrics) LockSkipped(job string) {
+	metrics.jobLockSkips.WithLabelValues(job).Inc()
+}
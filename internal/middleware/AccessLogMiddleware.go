@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/logging"
+	"context"
+	setJson "encoding/json"
+	configIO "fmt"
+	"io"
+	"log/slog"
+	httpclient "net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	clock "time"
+)
+
+// clfTimeLayout is the timestamp of the Common Log Format, e.g. 10/Oct/2000:13:55:36 -0700.
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// AccessLogOptions configures AccessLog.
+type AccessLogOptions struct {
+	// Format is one of the provider.AccessLog* formats; empty is provider.AccessLogSlog.
+	Format string
+	// Output receives the common, combined and json lines; the slog format uses the request logger instead.
+	Output io.Writer
+	// TrustedProxies are the proxies whose X-Forwarded-For is honoured, see ClientIP.
+	TrustedProxies []netip.Prefix
+	// Observe, when set, also receives each entry, whatever the format; metrics.Metrics.ObserveRequest fits.
+	Observe func(entry AccessEntry)
+}
+
+// AccessEntry is one served request, as written to the access log.
+type AccessEntry struct {
+	Time      clock.Time     `json:"time"`
+	RequestId string         `json:"request_id,omitempty"`
+	ClientIP  string         `json:"client_ip"`
+	Method    string         `json:"method"`
+	URI       string         `json:"uri"`
+	Route     string         `json:"route,omitempty"`
+	Proto     string         `json:"proto"`
+	Status    int            `json:"status"`
+	Bytes     int64          `json:"bytes"`
+	Duration  clock.Duration `json:"duration_ns"`
+	Referer   string         `json:"referer,omitempty"`
+	UserAgent string         `json:"user_agent,omitempty"`
+}
+
+// AccessLog logs every request once, after the response: status, bytes written, route template, client IP,
+// user agent and latency. It goes around the router, inside RequestId and RequestLogger.
+func AccessLog(options AccessLogOptions) func(httpclient.Handler) httpclient.Handler {
+	var outputMutex sync.Mutex
+	write := func(line []byte) {
+		outputMutex.Lock()
+		defer outputMutex.Unlock()
+		_, _ = options.Output.Write(line)
+	}
+
+	return func(next httpclient.Handler) httpclient.Handler {
+		return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+			start := clock.Now()
+			wrapped := NewDtoResponse(responseWriter)
+			request, route := withMatchedRoute(request)
+
+			next.ServeHTTP(wrapped, request)
+
+			entry := AccessEntry{
+				Time:      start,
+				RequestId: GetRequestId(request.Context()),
+				ClientIP:  ClientIP(request, options.TrustedProxies),
+				Method:    request.Method,
+				URI:       request.RequestURI,
+				Route:     route.template,
+				Proto:     request.Proto,
+				Status:    wrapped.Status,
+				Bytes:     wrapped.Bytes,
+				Duration:  clock.Since(start),
+				Referer:   request.Referer(),
+				UserAgent: request.UserAgent(),
+			}
+
+			if options.Observe != nil {
+				options.Observe(entry)
+			}
+
+			switch options.Format {
+			case provider.AccessLogCommon:
+				write([]byte(entry.Common() + "\n"))
+			case provider.AccessLogCombined:
+				write([]byte(entry.Combined() + "\n"))
+			case provider.AccessLogJson:
+				line, _ := setJson.Marshal(entry)
+				write(append(line, '\n'))
+			default:
+				entry.log(request.Context())
+			}
+		})
+	}
+}
+
+// Common formats entry in the Common Log Format: host ident authuser [time] "request" status bytes.
+func (entry AccessEntry) Common() string {
+	bytes := "-"
+	if entry.Bytes > 0 {
+		bytes = strconv.FormatInt(entry.Bytes, 10)
+	}
+
+	return configIO.Sprintf("%s - - [%s] %s %d %s",
+		entry.ClientIP,
+		entry.Time.Format(clfTimeLayout),
+		strconv.Quote(entry.Method+" "+entry.URI+" "+entry.Proto),
+		entry.Status,
+		bytes,
+	)
+}
+
+// Combined formats entry in the Combined Log Format, the Common one followed by the referer and user agent.
+func (entry AccessEntry) Combined() string {
+	return configIO.Sprintf("%s %s %s", entry.Common(), quoteOrDash(entry.Referer), quoteOrDash(entry.UserAgent))
+}
+
+// log writes entry through the request logger, which already carries the request ID, method and path.
+func (entry AccessEntry) log(ctx context.Context) {
+	attributes := []slog.Attr{
+		slog.Int("status", entry.Status),
+		slog.Int64("bytes", entry.Bytes),
+		slog.Duration("duration", entry.Duration),
+		slog.String("client_ip", entry.ClientIP),
+	}
+	if entry.Route != "" {
+		attributes = append(attributes, slog.String(logging.KeyRoute, entry.Route))
+	}
+	if entry.UserAgent != "" {
+		attributes = append(attributes, slog.String("user_agent", entry.UserAgent))
+	}
+
+	logging.FromContext(ctx, nil).LogAttrs(ctx, slog.LevelInfo, "Requisição concluída", attributes...)
+}
+
+func quoteOrDash(value string) string {
+	if value == "" {
+		return `"-"`
+	}
+	return strconv.Quote(value)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net"
+	httpclient "net/http"
+	"net/netip"
+	"strings"
+)
+
+const ForwardedForHeader = "X-Forwarded-For"
+
+// ClientIP returns the address of the client that sent request. X-Forwarded-For is only honoured when the
+// connection comes from a trusted proxy: its hops are read right to left, skipping trusted proxies, and the
+// first untrusted one is the client. Hops a client wrote itself are thus never taken for its address.
+func ClientIP(request *httpclient.Request, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+
+	client, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+	client = client.Unmap()
+
+	if !isTrustedProxy(client, trusted) {
+		return client.String()
+	}
+
+	hops := strings.Split(strings.Join(request.Header.Values(ForwardedForHeader), ","), ",")
+	for index := len(hops) - 1; index >= 0; index-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[index]))
+		if err != nil {
+			break
+		}
+
+		client = hop.Unmap()
+		if !isTrustedProxy(client, trusted) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func isTrustedProxy(address netip.Addr, trusted []netip.Prefix) bool {
+	for _, prefix := range trusted {
+		if prefix.Contains(address) {
+			return true
+		}
+	}
+	return false
+}
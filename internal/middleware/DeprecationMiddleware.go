@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	configIO "fmt"
+	httpclient "net/http"
+	"time"
+)
+
+// Deprecated marks the responses of a route kept only for compatibility. It sends Deprecation (RFC 9745)
+// with deprecatedAt, Sunset (RFC 8594) when sunset is set, and a successor-version Link to the route that
+// replaces it, as returned by successor for the request.
+func Deprecated(deprecatedAt time.Time, sunset time.Time, successor func(request *httpclient.Request) string) func(httpclient.Handler) httpclient.Handler {
+	return func(next httpclient.Handler) httpclient.Handler {
+		return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+			header := responseWriter.Header()
+			header.Set("Deprecation", configIO.Sprintf("@%d", deprecatedAt.Unix()))
+			if !sunset.IsZero() {
+				header.Set("Sunset", sunset.UTC().Format(httpclient.TimeFormat))
+			}
+			if target := successor(request); target != "" {
+				header.Add("Link", configIO.Sprintf(`<%s>; rel="successor-version"`, target))
+			}
+
+			next.ServeHTTP(responseWriter, request)
+		})
+	}
+}
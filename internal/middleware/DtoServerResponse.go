@@ -1,10 +1,19 @@
 package middleware
 
-import httpclient "net/http"
+import (
+	"bufio"
+	"net"
+	httpclient "net/http"
+)
 
+// DtoResponse records the status and the body size written through it. It keeps http.Flusher and
+// http.Hijacker working, and Unwrap lets http.ResponseController reach the other optional interfaces.
 type DtoResponse struct {
 	httpclient.ResponseWriter
 	Status int
+	Bytes  int64
+
+	wroteHeader bool
 }
 
 func NewDtoResponse(responseWriter httpclient.ResponseWriter) *DtoResponse {
@@ -14,7 +23,37 @@ func NewDtoResponse(responseWriter httpclient.ResponseWriter) *DtoResponse {
 	}
 }
 
+// WriteHeader records the first status sent; later calls are passed on, as net/http only warns about them.
 func (dtoResponse *DtoResponse) WriteHeader(code int) {
-	dtoResponse.Status = code
+	if !dtoResponse.wroteHeader {
+		dtoResponse.Status = code
+		dtoResponse.wroteHeader = code >= httpclient.StatusOK
+	}
 	dtoResponse.ResponseWriter.WriteHeader(code)
 }
+
+func (dtoResponse *DtoResponse) Write(body []byte) (int, error) {
+	dtoResponse.wroteHeader = true
+	written, err := dtoResponse.ResponseWriter.Write(body)
+	dtoResponse.Bytes += int64(written)
+	return written, err
+}
+
+func (dtoResponse *DtoResponse) Flush() {
+	dtoResponse.wroteHeader = true
+	_ = httpclient.NewResponseController(dtoResponse.ResponseWriter).Flush()
+}
+
+// Hijack hands the connection over, e.g. for WebSockets; the response is then logged as 101 Switching Protocols.
+func (dtoResponse *DtoResponse) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	connection, buffer, err := httpclient.NewResponseController(dtoResponse.ResponseWriter).Hijack()
+	if err == nil && !dtoResponse.wroteHeader {
+		dtoResponse.Status = httpclient.StatusSwitchingProtocols
+		dtoResponse.wroteHeader = true
+	}
+	return connection, buffer, err
+}
+
+func (dtoResponse *DtoResponse) Unwrap() httpclient.ResponseWriter {
+	return dtoResponse.ResponseWriter
+}
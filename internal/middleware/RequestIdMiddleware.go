@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	httpclient "net/http"
+)
+
+const RequestIdHeader = "X-Request-ID"
+
+// maxRequestIdLength bounds the client supplied ID so it cannot bloat logs and responses.
+const maxRequestIdLength = 128
+
+type requestIdKey struct{}
+
+// RequestId propagates the caller's X-Request-ID, or generates one, and stores it in the request context.
+func RequestId(next httpclient.Handler) httpclient.Handler {
+	return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+		requestId := request.Header.Get(RequestIdHeader)
+		if requestId == "" || len(requestId) > maxRequestIdLength {
+			requestId = newRequestId()
+		}
+
+		responseWriter.Header().Set(RequestIdHeader, requestId)
+		ctx := context.WithValue(request.Context(), requestIdKey{}, requestId)
+		next.ServeHTTP(responseWriter, request.WithContext(ctx))
+	})
+}
+
+// GetRequestId returns the request ID stored by RequestId, or an empty string.
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
+func newRequestId() string {
+	buffer := make([]byte, 16)
+	_, _ = rand.Read(buffer)
+	return hex.EncodeToString(buffer)
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"PocGo/internal/logging"
+	"context"
+	muxRouter "github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	httpclient "net/http"
+)
+
+// RequestLogger stores in the request context a logger derived from base carrying the request ID, method
+// and path, plus the trace and span IDs inside Tracing; it must run inside RequestId. Handlers and services
+// reach it through logging.FromContext.
+func RequestLogger(base *slog.Logger) func(httpclient.Handler) httpclient.Handler {
+	return func(next httpclient.Handler) httpclient.Handler {
+		return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+			requestLogger := logging.OrDefault(base).With(
+				logging.KeyRequestId, GetRequestId(request.Context()),
+				logging.KeyMethod, request.Method,
+				logging.KeyPath, request.URL.Path,
+			)
+			if spanContext := trace.SpanContextFromContext(request.Context()); spanContext.IsValid() {
+				requestLogger = requestLogger.With(
+					logging.KeyTraceId, spanContext.TraceID().String(),
+					logging.KeySpanId, spanContext.SpanID().String(),
+				)
+			}
+
+			ctx := logging.WithLogger(request.Context(), requestLogger)
+			next.ServeHTTP(responseWriter, request.WithContext(ctx))
+		})
+	}
+}
+
+// RouteLogger adds the matched route template to the request logger, with the user ID of /users/{id}
+// routes and the job name of /admin/jobs/{name} ones, and hands the template to the middlewares around the
+// router (AccessLog, Tracing). It is meant for Router.Use, which runs after matching.
+func RouteLogger(next httpclient.Handler) httpclient.Handler {
+	return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+		var args []any
+		if route := muxRouter.CurrentRoute(request); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				args = append(args, logging.KeyRoute, template)
+				setMatchedRoute(request.Context(), template)
+			}
+		}
+
+		vars := muxRouter.Vars(request)
+		if id, exists := vars["id"]; exists {
+			args = append(args, logging.KeyUserId, id)
+		}
+		if name, exists := vars["name"]; exists {
+			args = append(args, logging.KeyJob, name)
+		}
+
+		if len(args) > 0 {
+			request = request.WithContext(logging.With(request.Context(), args...))
+		}
+		next.ServeHTTP(responseWriter, request)
+	})
+}
+
+// matchedRoute is filled by RouteLogger once the router has matched, as the route is only known inside it.
+type matchedRoute struct {
+	template string
+}
+
+type matchedRouteKey struct{}
+
+// withMatchedRoute returns request with a matchedRoute to be filled by RouteLogger, reusing the one of an
+// outer middleware so every middleware around the router sees the same route.
+func withMatchedRoute(request *httpclient.Request) (*httpclient.Request, *matchedRoute) {
+	if route, ok := request.Context().Value(matchedRouteKey{}).(*matchedRoute); ok {
+		return request, route
+	}
+
+	route := &matchedRoute{}
+	return request.WithContext(context.WithValue(request.Context(), matchedRouteKey{}, route)), route
+}
+
+func setMatchedRoute(ctx context.Context, template string) {
+	if route, ok := ctx.Value(matchedRouteKey{}).(*matchedRoute); ok {
+		route.template = template
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"PocGo/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	httpclient "net/http"
+)
+
+// Tracing starts the server span of each request, continuing the trace of an incoming W3C traceparent.
+// The span is named after the route template once the router has matched, e.g. "PUT /user/update_user".
+func Tracing(next httpclient.Handler) httpclient.Handler {
+	return httpclient.HandlerFunc(func(responseWriter httpclient.ResponseWriter, request *httpclient.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+		ctx, span := tracing.Start(ctx, request.Method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(request.Method),
+			semconv.URLPath(request.URL.Path),
+			semconv.UserAgentOriginal(request.UserAgent()),
+		))
+		defer span.End()
+
+		wrapped := NewDtoResponse(responseWriter)
+		request, route := withMatchedRoute(request.WithContext(ctx))
+		next.ServeHTTP(wrapped, request)
+
+		if route.template != "" {
+			span.SetName(request.Method + " " + route.template)
+			span.SetAttributes(semconv.HTTPRoute(route.template))
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(wrapped.Status))
+		if wrapped.Status >= httpclient.StatusInternalServerError {
+			span.SetStatus(codes.Error, httpclient.StatusText(wrapped.Status))
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import provider "PocGo/internal/configuration/providers"
+
+// dialect holds the statements used to maintain the schema_migrations tracking table.
+type dialect struct {
+	createTable string
+	// tableExists counts the schema_migrations tables, so reads need no DDL rights and never create one.
+	tableExists   string
+	selectVersion string
+	selectApplied string
+	insertApplied string
+	deleteApplied string
+}
+
+var dialects = map[string]dialect{
+	provider.DriverSqlServer: {
+		createTable: `IF OBJECT_ID(N'[dbo].[schema_migrations]', N'U') IS NULL
+    CREATE TABLE [dbo].[schema_migrations] (
+        [version]    BIGINT        NOT NULL PRIMARY KEY,
+        [name]       NVARCHAR(255) NOT NULL,
+        [checksum]   CHAR(64)      NOT NULL,
+        [applied_at] DATETIME2     NOT NULL
+    )`,
+		tableExists:   `SELECT COUNT(*) FROM sys.tables WHERE object_id = OBJECT_ID(N'[dbo].[schema_migrations]')`,
+		selectVersion: `SELECT COALESCE(MAX([version]), 0) FROM [dbo].[schema_migrations]`,
+		selectApplied: `SELECT [version], [name], [checksum], [applied_at] FROM [dbo].[schema_migrations] ORDER BY [version]`,
+		insertApplied: `INSERT INTO [dbo].[schema_migrations] ([version], [name], [checksum], [applied_at]) VALUES (@p1, @p2, @p3, @p4)`,
+		deleteApplied: `DELETE FROM [dbo].[schema_migrations] WHERE [version] = @p1`,
+	},
+	provider.DriverSqlite: {
+		createTable: `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    INTEGER  NOT NULL PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    checksum   TEXT     NOT NULL,
+    applied_at DATETIME NOT NULL
+)`,
+		tableExists:   `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`,
+		selectVersion: `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`,
+		selectApplied: `SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`,
+		insertApplied: `INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+		deleteApplied: `DELETE FROM schema_migrations WHERE version = ?`,
+	},
+}
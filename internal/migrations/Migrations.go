@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	configIO "fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed sql
+var scripts embed.FS
+
+// fileNamePattern matches "0001_create_auth_user.up.sql" style names.
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a versioned pair of up/down scripts for a single driver.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Load reads the embedded migrations of driver, ordered by version.
+func Load(driver string) ([]Migration, error) {
+	return loadFrom(scripts, path.Join("sql", driver))
+}
+
+func loadFrom(source fs.FS, directory string) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, directory)
+	if err != nil {
+		return nil, notify.CreateCustomNotification(notify.ErrorMigrationUnsupported, "", directory)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		parts := fileNamePattern.FindStringSubmatch(entry.Name())
+		if parts == nil {
+			return nil, notify.CreateCustomNotification(notify.ErrorMigrationFailed, "",
+				configIO.Sprintf("nome de arquivo inválido %q", entry.Name()))
+		}
+
+		version, _ := strconv.ParseInt(parts[1], 10, 64)
+		content, err := fs.ReadFile(source, path.Join(directory, entry.Name()))
+		if err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: parts[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != parts[2] {
+			return nil, notify.CreateCustomNotification(notify.ErrorMigrationFailed, "",
+				configIO.Sprintf("versão %d usada por %q e %q", version, migration.Name, parts[2]))
+		}
+
+		if parts[3] == "up" {
+			migration.Up = string(content)
+			migration.Checksum = checksum(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, notify.CreateCustomNotification(notify.ErrorMigrationFailed, "",
+				configIO.Sprintf("migração %04d_%s precisa dos scripts up e down", migration.Version, migration.Name))
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,304 @@
+package migrations
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/logging"
+	"context"
+	dbProvider "database/sql"
+	configIO "fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+const (
+	StateApplied  = "applied"
+	StatePending  = "pending"
+	StateModified = "modified"
+	StateMissing  = "missing"
+)
+
+// MigrationStatus describes a migration known to the binary, the database, or both.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	version   int64
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Runner applies the embedded migrations of one driver and records them in schema_migrations.
+type Runner struct {
+	dataBase   *dbProvider.DB
+	dialect    dialect
+	migrations []Migration
+	logger     *slog.Logger
+}
+
+// NewRunner builds the runner of driver over db; logger may be nil to use slog.Default.
+func NewRunner(driver string, db *dbProvider.DB, logger *slog.Logger) (*Runner, error) {
+	selected, exists := dialects[driver]
+	if !exists {
+		return nil, notify.CreateCustomNotification(notify.ErrorMigrationUnsupported, "", driver)
+	}
+
+	migrations, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		dataBase:   db,
+		dialect:    selected,
+		migrations: migrations,
+		logger:     logging.OrDefault(logger),
+	}, nil
+}
+
+// Up applies every pending migration and returns how many were applied.
+func (runner *Runner) Up(ctx context.Context) (int, error) {
+	if len(runner.migrations) == 0 {
+		return 0, nil
+	}
+	return runner.Goto(ctx, runner.Latest())
+}
+
+// Down reverts the most recently applied migration.
+func (runner *Runner) Down(ctx context.Context) (int, error) {
+	current, err := runner.Version(ctx)
+	if err != nil || current == 0 {
+		return 0, err
+	}
+
+	target := int64(0)
+	for _, migration := range runner.migrations {
+		if migration.Version < current {
+			target = migration.Version
+		}
+	}
+
+	return runner.Goto(ctx, target)
+}
+
+// Goto applies or reverts migrations until version is the latest applied one.
+// Version 0 reverts everything.
+func (runner *Runner) Goto(ctx context.Context, version int64) (int, error) {
+	if version != 0 && runner.find(version) == nil {
+		return 0, notify.CreateCustomNotification(notify.ErrorMigrationNotFound, "", configIO.Sprintf("%d", version))
+	}
+
+	if _, err := runner.dataBase.ExecContext(ctx, runner.dialect.createTable); err != nil {
+		return 0, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	applied, err := runner.loadApplied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := runner.verify(applied); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range runner.migrations {
+		if _, done := applied[migration.Version]; done || migration.Version > version {
+			continue
+		}
+		if err := runner.apply(ctx, migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	for i := len(runner.migrations) - 1; i >= 0; i-- {
+		migration := runner.migrations[i]
+		if _, done := applied[migration.Version]; !done || migration.Version <= version {
+			continue
+		}
+		if err := runner.revert(ctx, migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// Latest returns the version of the newest embedded migration, or 0 when there is none.
+func (runner *Runner) Latest() int64 {
+	if len(runner.migrations) == 0 {
+		return 0
+	}
+	return runner.migrations[len(runner.migrations)-1].Version
+}
+
+// Version returns the latest applied migration, or 0 when none was applied. It only reads, so the readiness
+// check can call it without DDL rights.
+func (runner *Runner) Version(ctx context.Context) (int64, error) {
+	exists, err := runner.tableExists(ctx)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var current int64
+	if err := runner.dataBase.QueryRowContext(ctx, runner.dialect.selectVersion).Scan(&current); err != nil {
+		return 0, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+	return current, nil
+}
+
+// Status lists every embedded migration plus any applied migration the binary does not know about.
+func (runner *Runner) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := runner.loadApplied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(runner.migrations))
+	for _, migration := range runner.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: StatePending}
+
+		if record, done := applied[migration.Version]; done {
+			appliedAt := record.appliedAt
+			status.AppliedAt = &appliedAt
+			status.State = StateApplied
+			if record.checksum != migration.Checksum {
+				status.State = StateModified
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for version, record := range applied {
+		if runner.find(version) != nil {
+			continue
+		}
+		appliedAt := record.appliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   version,
+			Name:      record.name,
+			State:     StateMissing,
+			AppliedAt: &appliedAt,
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+
+	return statuses, nil
+}
+
+func (runner *Runner) find(version int64) *Migration {
+	for i := range runner.migrations {
+		if runner.migrations[i].Version == version {
+			return &runner.migrations[i]
+		}
+	}
+	return nil
+}
+
+// verify refuses to move when an applied script was edited after being applied.
+func (runner *Runner) verify(applied map[int64]appliedMigration) error {
+	for _, migration := range runner.migrations {
+		record, done := applied[migration.Version]
+		if done && record.checksum != migration.Checksum {
+			return notify.CreateCustomNotification(notify.ErrorMigrationChecksum, "",
+				configIO.Sprintf("%04d_%s", migration.Version, migration.Name))
+		}
+	}
+	return nil
+}
+
+func (runner *Runner) tableExists(ctx context.Context) (bool, error) {
+	var count int
+	if err := runner.dataBase.QueryRowContext(ctx, runner.dialect.tableExists).Scan(&count); err != nil {
+		return false, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+	return count > 0, nil
+}
+
+// loadApplied reads schema_migrations, which is only created by Goto; a missing table means nothing was applied.
+func (runner *Runner) loadApplied(ctx context.Context) (map[int64]appliedMigration, error) {
+	exists, err := runner.tableExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return map[int64]appliedMigration{}, nil
+	}
+
+	rows, err := runner.dataBase.QueryContext(ctx, runner.dialect.selectApplied)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var record appliedMigration
+		if err := rows.Scan(&record.version, &record.name, &record.checksum, &record.appliedAt); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+		}
+		applied[record.version] = record
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	return applied, nil
+}
+
+func (runner *Runner) apply(ctx context.Context, migration Migration) error {
+	err := runner.inTransaction(ctx, migration.Up, runner.dialect.insertApplied,
+		migration.Version, migration.Name, migration.Checksum, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	runner.logger.Info("Migração aplicada", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+func (runner *Runner) revert(ctx context.Context, migration Migration) error {
+	err := runner.inTransaction(ctx, migration.Down, runner.dialect.deleteApplied, migration.Version)
+	if err != nil {
+		return err
+	}
+
+	runner.logger.Info("Migração revertida", "version", migration.Version, "name", migration.Name)
+	return nil
+}
+
+// inTransaction runs script and the tracking statement atomically.
+func (runner *Runner) inTransaction(ctx context.Context, script string, tracking string, args ...any) error {
+	tx, err := runner.dataBase.BeginTx(ctx, nil)
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		_ = tx.Rollback()
+		return notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, tracking, args...); err != nil {
+		_ = tx.Rollback()
+		return notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return notify.CreateSimpleNotification(notify.ErrorMigrationFailed, err)
+	}
+
+	return nil
+}
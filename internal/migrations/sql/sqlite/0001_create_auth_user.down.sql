@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ix_auth_user_creation_date;
+DROP TABLE IF EXISTS auth_user;
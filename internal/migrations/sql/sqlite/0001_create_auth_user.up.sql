@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS auth_user (
+    id               TEXT     NOT NULL PRIMARY KEY,
+    normalized_login TEXT     NOT NULL,
+    login            TEXT     NOT NULL,
+    name             TEXT     NULL,
+    email            TEXT     NULL,
+    status           INTEGER  NOT NULL DEFAULT 1,
+    creation_date    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS ix_auth_user_creation_date ON auth_user (creation_date);
@@ -0,0 +1 @@
+ALTER TABLE auth_user ADD COLUMN deleted_at DATETIME NULL;
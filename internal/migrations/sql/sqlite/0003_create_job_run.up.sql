@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS job_run (
+    id           TEXT     NOT NULL PRIMARY KEY,
+    job_name     TEXT     NOT NULL,
+    triggered_by TEXT     NOT NULL,
+    dry_run      INTEGER  NOT NULL DEFAULT 0,
+    status       TEXT     NOT NULL,
+    started_at   DATETIME NOT NULL,
+    finished_at  DATETIME NULL,
+    processed    INTEGER  NOT NULL DEFAULT 0,
+    updated      INTEGER  NOT NULL DEFAULT 0,
+    failed       INTEGER  NOT NULL DEFAULT 0,
+    error        TEXT     NULL,
+    item_errors  TEXT     NULL
+);
+
+CREATE INDEX IF NOT EXISTS ix_job_run_job_name_started_at ON job_run (job_name, started_at DESC);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS scheduler_lock (
+    name       TEXT    NOT NULL PRIMARY KEY,
+    owner      TEXT    NOT NULL,
+    expires_at INTEGER NOT NULL
+);
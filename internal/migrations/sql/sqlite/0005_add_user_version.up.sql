@@ -0,0 +1 @@
+ALTER TABLE auth_user ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS ux_auth_user_normalized_login;
@@ -0,0 +1,27 @@
+-- Updates used to change email without login; bring them back in sync before enforcing uniqueness.
+-- Syncing must not make two users share a login, so the migration stops first, naming the clashing logins and
+-- the IDs of their users, to be fixed by hand. SQLite only raises errors from triggers, hence the check table.
+CREATE TEMP TABLE login_collision_check (id INTEGER);
+CREATE TEMP TRIGGER login_collision_check_abort BEFORE INSERT ON login_collision_check
+BEGIN
+    SELECT RAISE(ABORT, 'logins duplicados ao sincronizar com o email, corrija os usuários antes de migrar: ' || clashes)
+    FROM (
+        SELECT group_concat(target || ' (' || ids || ')', '; ') AS clashes
+        FROM (
+            SELECT target, group_concat(id, ', ') AS ids
+            FROM (
+                SELECT id, CASE WHEN email IS NOT NULL AND login <> email THEN UPPER(email) ELSE normalized_login END AS target
+                FROM auth_user
+            )
+            GROUP BY target
+            HAVING COUNT(*) > 1
+        )
+    )
+    WHERE clashes IS NOT NULL;
+END;
+INSERT INTO login_collision_check (id) VALUES (1);
+DROP TABLE login_collision_check;
+
+UPDATE auth_user SET login = email, normalized_login = UPPER(email) WHERE email IS NOT NULL AND login <> email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_auth_user_normalized_login ON auth_user (normalized_login);
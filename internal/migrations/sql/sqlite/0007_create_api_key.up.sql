@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS api_key (
+    id         TEXT     NOT NULL PRIMARY KEY,
+    name       TEXT     NOT NULL,
+    prefix     TEXT     NOT NULL,
+    key_hash   TEXT     NOT NULL,
+    roles      TEXT     NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    expires_at DATETIME NULL,
+    revoked_at DATETIME NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_api_key_key_hash ON api_key (key_hash);
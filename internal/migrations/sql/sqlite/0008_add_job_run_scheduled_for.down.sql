@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS ux_job_run_job_name_scheduled_for;
+
+ALTER TABLE job_run DROP COLUMN scheduled_for;
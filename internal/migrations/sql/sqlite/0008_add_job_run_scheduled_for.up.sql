@@ -0,0 +1,4 @@
+ALTER TABLE job_run ADD COLUMN scheduled_for DATETIME NULL;
+
+-- One run per scheduled slot of a job, whichever replica fires it; manual runs have no slot.
+CREATE UNIQUE INDEX IF NOT EXISTS ux_job_run_job_name_scheduled_for ON job_run (job_name, scheduled_for) WHERE scheduled_for IS NOT NULL;
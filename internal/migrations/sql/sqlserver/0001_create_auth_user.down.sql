@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS [Auth].[User];
+
+IF NOT EXISTS (SELECT 1 FROM sys.objects WHERE schema_id = SCHEMA_ID(N'Auth'))
+    AND EXISTS (SELECT 1 FROM sys.schemas WHERE name = N'Auth')
+    EXEC(N'DROP SCHEMA [Auth]');
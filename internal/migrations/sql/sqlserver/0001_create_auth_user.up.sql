@@ -0,0 +1,17 @@
+IF NOT EXISTS (SELECT 1 FROM sys.schemas WHERE name = N'Auth')
+    EXEC(N'CREATE SCHEMA [Auth]');
+
+IF OBJECT_ID(N'[Auth].[User]', N'U') IS NULL
+    CREATE TABLE [Auth].[User] (
+        [id]               UNIQUEIDENTIFIER NOT NULL CONSTRAINT [PK_Auth_User] PRIMARY KEY
+                                                     CONSTRAINT [DF_Auth_User_id] DEFAULT NEWSEQUENTIALID(),
+        [normalized_login] NVARCHAR(256)    NOT NULL,
+        [login]            NVARCHAR(256)    NOT NULL,
+        [name]             NVARCHAR(256)    NULL,
+        [email]            NVARCHAR(256)    NULL,
+        [status]           INT              NOT NULL CONSTRAINT [DF_Auth_User_status] DEFAULT (1),
+        [creation_date]    DATETIME2        NOT NULL CONSTRAINT [DF_Auth_User_creation_date] DEFAULT SYSUTCDATETIME()
+    );
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'IX_Auth_User_creation_date' AND object_id = OBJECT_ID(N'[Auth].[User]'))
+    CREATE INDEX [IX_Auth_User_creation_date] ON [Auth].[User] ([creation_date]);
@@ -0,0 +1,2 @@
+IF COL_LENGTH(N'[Auth].[User]', N'deleted_at') IS NOT NULL
+    ALTER TABLE [Auth].[User] DROP COLUMN [deleted_at];
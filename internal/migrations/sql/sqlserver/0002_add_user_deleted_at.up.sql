@@ -0,0 +1,2 @@
+IF COL_LENGTH(N'[Auth].[User]', N'deleted_at') IS NULL
+    ALTER TABLE [Auth].[User] ADD [deleted_at] DATETIME2 NULL;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS [Jobs].[JobRun];
+
+IF NOT EXISTS (SELECT 1 FROM sys.objects WHERE schema_id = SCHEMA_ID(N'Jobs'))
+    AND EXISTS (SELECT 1 FROM sys.schemas WHERE name = N'Jobs')
+    EXEC(N'DROP SCHEMA [Jobs]');
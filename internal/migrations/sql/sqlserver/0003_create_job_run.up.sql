@@ -0,0 +1,21 @@
+IF NOT EXISTS (SELECT 1 FROM sys.schemas WHERE name = N'Jobs')
+    EXEC(N'CREATE SCHEMA [Jobs]');
+
+IF OBJECT_ID(N'[Jobs].[JobRun]', N'U') IS NULL
+    CREATE TABLE [Jobs].[JobRun] (
+        [id]           UNIQUEIDENTIFIER NOT NULL CONSTRAINT [PK_Jobs_JobRun] PRIMARY KEY,
+        [job_name]     NVARCHAR(128)    NOT NULL,
+        [triggered_by] NVARCHAR(32)     NOT NULL,
+        [dry_run]      BIT              NOT NULL CONSTRAINT [DF_Jobs_JobRun_dry_run] DEFAULT (0),
+        [status]       NVARCHAR(32)     NOT NULL,
+        [started_at]   DATETIME2        NOT NULL,
+        [finished_at]  DATETIME2        NULL,
+        [processed]    INT              NOT NULL CONSTRAINT [DF_Jobs_JobRun_processed] DEFAULT (0),
+        [updated]      INT              NOT NULL CONSTRAINT [DF_Jobs_JobRun_updated] DEFAULT (0),
+        [failed]       INT              NOT NULL CONSTRAINT [DF_Jobs_JobRun_failed] DEFAULT (0),
+        [error]        NVARCHAR(MAX)    NULL,
+        [item_errors]  NVARCHAR(MAX)    NULL
+    );
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'IX_Jobs_JobRun_job_name_started_at' AND object_id = OBJECT_ID(N'[Jobs].[JobRun]'))
+    CREATE INDEX [IX_Jobs_JobRun_job_name_started_at] ON [Jobs].[JobRun] ([job_name], [started_at] DESC);
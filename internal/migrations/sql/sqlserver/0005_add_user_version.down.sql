@@ -0,0 +1,5 @@
+IF COL_LENGTH(N'[Auth].[User]', N'version') IS NOT NULL
+BEGIN
+    ALTER TABLE [Auth].[User] DROP CONSTRAINT [DF_Auth_User_version];
+    ALTER TABLE [Auth].[User] DROP COLUMN [version];
+END
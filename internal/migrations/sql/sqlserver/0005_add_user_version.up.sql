@@ -0,0 +1,2 @@
+IF COL_LENGTH(N'[Auth].[User]', N'version') IS NULL
+    ALTER TABLE [Auth].[User] ADD [version] BIGINT NOT NULL CONSTRAINT [DF_Auth_User_version] DEFAULT (1);
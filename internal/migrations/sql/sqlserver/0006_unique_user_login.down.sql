@@ -0,0 +1,2 @@
+IF EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'UX_Auth_User_normalized_login' AND object_id = OBJECT_ID(N'[Auth].[User]'))
+    DROP INDEX [UX_Auth_User_normalized_login] ON [Auth].[User];
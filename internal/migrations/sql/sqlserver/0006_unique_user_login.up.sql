@@ -0,0 +1,26 @@
+-- Updates used to change email without login; bring them back in sync before enforcing uniqueness.
+-- Syncing must not make two users share a login, so the migration stops first, naming the clashing logins and
+-- the IDs of their users, to be fixed by hand.
+DECLARE @clashes NVARCHAR(MAX);
+
+SELECT @clashes = STRING_AGG(CONCAT([target], N' (', [ids], N')'), N'; ')
+FROM (
+    SELECT [target], STRING_AGG(CONVERT(NVARCHAR(36), [id]), N', ') AS [ids]
+    FROM (
+        SELECT [id], CASE WHEN [email] IS NOT NULL AND [login] <> [email] THEN UPPER([email]) ELSE [normalized_login] END AS [target]
+        FROM [Auth].[User]
+    ) AS [synced]
+    GROUP BY [target]
+    HAVING COUNT(*) > 1
+) AS [collisions];
+
+IF @clashes IS NOT NULL
+BEGIN
+    DECLARE @message NVARCHAR(2048) = LEFT(CONCAT(N'logins duplicados ao sincronizar com o email, corrija os usuários antes de migrar: ', @clashes), 2048);
+    THROW 50006, @message, 1;
+END;
+
+UPDATE [Auth].[User] SET [login] = [email], [normalized_login] = UPPER([email]) WHERE [email] IS NOT NULL AND [login] <> [email];
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'UX_Auth_User_normalized_login' AND object_id = OBJECT_ID(N'[Auth].[User]'))
+    CREATE UNIQUE INDEX [UX_Auth_User_normalized_login] ON [Auth].[User] ([normalized_login]);
@@ -0,0 +1,17 @@
+IF NOT EXISTS (SELECT 1 FROM sys.schemas WHERE name = N'Auth')
+    EXEC(N'CREATE SCHEMA [Auth]');
+
+IF OBJECT_ID(N'[Auth].[ApiKey]', N'U') IS NULL
+    CREATE TABLE [Auth].[ApiKey] (
+        [id]         UNIQUEIDENTIFIER NOT NULL CONSTRAINT [PK_Auth_ApiKey] PRIMARY KEY,
+        [name]       NVARCHAR(128)    NOT NULL,
+        [prefix]     NVARCHAR(16)     NOT NULL,
+        [key_hash]   CHAR(64)         NOT NULL,
+        [roles]      NVARCHAR(512)    NOT NULL CONSTRAINT [DF_Auth_ApiKey_roles] DEFAULT (N''),
+        [created_at] DATETIME2        NOT NULL,
+        [expires_at] DATETIME2        NULL,
+        [revoked_at] DATETIME2        NULL
+    );
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'UX_Auth_ApiKey_key_hash' AND object_id = OBJECT_ID(N'[Auth].[ApiKey]'))
+    CREATE UNIQUE INDEX [UX_Auth_ApiKey_key_hash] ON [Auth].[ApiKey] ([key_hash]);
@@ -0,0 +1,5 @@
+IF EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'UX_Jobs_JobRun_job_name_scheduled_for' AND object_id = OBJECT_ID(N'[Jobs].[JobRun]'))
+    DROP INDEX [UX_Jobs_JobRun_job_name_scheduled_for] ON [Jobs].[JobRun];
+
+IF COL_LENGTH(N'[Jobs].[JobRun]', N'scheduled_for') IS NOT NULL
+    ALTER TABLE [Jobs].[JobRun] DROP COLUMN [scheduled_for];
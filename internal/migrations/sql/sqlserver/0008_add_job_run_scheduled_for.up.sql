@@ -0,0 +1,7 @@
+IF COL_LENGTH(N'[Jobs].[JobRun]', N'scheduled_for') IS NULL
+    ALTER TABLE [Jobs].[JobRun] ADD [scheduled_for] DATETIME2 NULL;
+
+-- One run per scheduled slot of a job, whichever replica fires it; manual runs have no slot.
+-- EXEC defers the compilation until the column exists.
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'UX_Jobs_JobRun_job_name_scheduled_for' AND object_id = OBJECT_ID(N'[Jobs].[JobRun]'))
+    EXEC(N'CREATE UNIQUE INDEX [UX_Jobs_JobRun_job_name_scheduled_for] ON [Jobs].[JobRun] ([job_name], [scheduled_for]) WHERE [scheduled_for] IS NOT NULL');
@@ -0,0 +1,275 @@
+package patch
+
+import (
+	setJson "encoding/json"
+	configIO "fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type operation struct {
+	Op    string             `json:"op"`
+	Path  string             `json:"path"`
+	From  string             `json:"from"`
+	Value setJson.RawMessage `json:"value"`
+
+	path, from []string
+	value      any
+}
+
+type jsonPatch struct {
+	operations []operation
+}
+
+// ParseJsonPatch parses a JSON Patch (RFC 6902), checking every operation before any is applied.
+func ParseJsonPatch(body []byte) (Patch, error) {
+	var operations []operation
+	if err := setJson.Unmarshal(body, &operations); err != nil {
+		return nil, configIO.Errorf("json patch inválido: %w", err)
+	}
+
+	for index := range operations {
+		if err := operations[index].parse(); err != nil {
+			return nil, configIO.Errorf("operação %d: %w", index, err)
+		}
+	}
+	return jsonPatch{operations: operations}, nil
+}
+
+func (operation *operation) parse() error {
+	var err error
+	if operation.path, err = parsePointer(operation.Path); err != nil {
+		return err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		if operation.Value == nil {
+			return configIO.Errorf("%s exige value", operation.Op)
+		}
+		return setJson.Unmarshal(operation.Value, &operation.value)
+	case "move", "copy":
+		if operation.from, err = parsePointer(operation.From); err != nil {
+			return err
+		}
+		if operation.Op == "move" && isPrefix(operation.from, operation.path) && len(operation.from) < len(operation.path) {
+			return configIO.Errorf("move não pode levar %q para dentro de si mesmo", operation.From)
+		}
+		return nil
+	case "remove":
+		return nil
+	default:
+		return configIO.Errorf("op desconhecida %q", operation.Op)
+	}
+}
+
+func (patch jsonPatch) Apply(document []byte) ([]byte, error) {
+	var root any
+	if err := setJson.Unmarshal(document, &root); err != nil {
+		return nil, configIO.Errorf("documento inválido: %w", err)
+	}
+
+	for index, operation := range patch.operations {
+		var err error
+		if root, err = operation.apply(root); err != nil {
+			return nil, configIO.Errorf("operação %d (%s %s): %w", index, operation.Op, operation.Path, err)
+		}
+	}
+
+	return setJson.Marshal(root)
+}
+
+func (operation operation) apply(root any) (any, error) {
+	// Adding or replacing at the root swaps the whole document (RFC 6902, sections 4.1 and 4.3).
+	if len(operation.path) == 0 && (operation.Op == "add" || operation.Op == "replace") {
+		return operation.value, nil
+	}
+
+	switch operation.Op {
+	case "add":
+		return add(root, operation.path, operation.value)
+	case "remove":
+		root, _, err := remove(root, operation.path)
+		return root, err
+	case "replace":
+		root, _, err := remove(root, operation.path)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, operation.path, operation.value)
+	case "move":
+		root, value, err := remove(root, operation.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, operation.path, value)
+	case "copy":
+		value, err := get(root, operation.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, operation.path, deepCopy(value))
+	default: // test
+		value, err := get(root, operation.path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, operation.value) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped reference tokens; "" is the whole document.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, configIO.Errorf("caminho inválido %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for index, token := range tokens {
+		tokens[index] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for index := range prefix {
+		if prefix[index] != path[index] {
+			return false
+		}
+	}
+	return true
+}
+
+func get(root any, path []string) (any, error) {
+	current := root
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]any:
+			value, exists := container[token]
+			if !exists {
+				return nil, configIO.Errorf("membro %q não existe", token)
+			}
+			current = value
+		case []any:
+			index, err := arrayIndex(token, len(container)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = container[index]
+		default:
+			return nil, configIO.Errorf("%q não é objeto nem array", token)
+		}
+	}
+	return current, nil
+}
+
+// add sets the member or inserts the array element at path, returning the new root.
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		container[last] = value
+		return root, nil
+	case []any:
+		index := len(container)
+		if last != "-" {
+			if index, err = arrayIndex(last, len(container)); err != nil {
+				return nil, err
+			}
+		}
+		updated := append(container[:index:index], append([]any{value}, container[index:]...)...)
+		return replaceContainer(root, path[:len(path)-1], updated)
+	default:
+		return nil, configIO.Errorf("destino de %q não é objeto nem array", last)
+	}
+}
+
+// remove deletes the value at path, returning the new root and the removed value.
+func remove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, configIO.Errorf("não é possível remover o documento inteiro")
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	last := path[len(path)-1]
+	switch container := parent.(type) {
+	case map[string]any:
+		value, exists := container[last]
+		if !exists {
+			return nil, nil, configIO.Errorf("membro %q não existe", last)
+		}
+		delete(container, last)
+		return root, value, nil
+	case []any:
+		index, err := arrayIndex(last, len(container)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := container[index]
+		updated := append(container[:index:index], container[index+1:]...)
+		root, err = replaceContainer(root, path[:len(path)-1], updated)
+		return root, value, err
+	default:
+		return nil, nil, configIO.Errorf("destino de %q não é objeto nem array", last)
+	}
+}
+
+// replaceContainer stores a resized array back at path, since slices cannot grow in place.
+func replaceContainer(root any, path []string, container []any) (any, error) {
+	if len(path) == 0 {
+		return container, nil
+	}
+
+	parent, err := get(root, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	last := path[len(path)-1]
+	switch holder := parent.(type) {
+	case map[string]any:
+		holder[last] = container
+	case []any:
+		index, _ := strconv.Atoi(last)
+		holder[index] = container
+	}
+	return root, nil
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max || (len(token) > 1 && token[0] == '0') {
+		return 0, configIO.Errorf("índice inválido %q", token)
+	}
+	return index, nil
+}
+
+func deepCopy(value any) any {
+	data, _ := setJson.Marshal(value)
+	var copied any
+	_ = setJson.Unmarshal(data, &copied)
+	return copied
+}
@@ -0,0 +1,50 @@
+package patch
+
+import (
+	setJson "encoding/json"
+	configIO "fmt"
+)
+
+type mergePatch struct {
+	value any
+}
+
+// ParseMergePatch parses a JSON Merge Patch (RFC 7396): members set to null are removed, objects are merged
+// recursively and any other value replaces the target.
+func ParseMergePatch(body []byte) (Patch, error) {
+	var value any
+	if err := setJson.Unmarshal(body, &value); err != nil {
+		return nil, configIO.Errorf("merge patch inválido: %w", err)
+	}
+	return mergePatch{value: value}, nil
+}
+
+func (patch mergePatch) Apply(document []byte) ([]byte, error) {
+	var target any
+	if err := setJson.Unmarshal(document, &target); err != nil {
+		return nil, configIO.Errorf("documento inválido: %w", err)
+	}
+
+	return setJson.Marshal(mergeValue(target, patch.value))
+}
+
+func mergeValue(target any, patch any) any {
+	patchObject, isObject := patch.(map[string]any)
+	if !isObject {
+		return patch
+	}
+
+	targetObject, isObject := target.(map[string]any)
+	if !isObject {
+		targetObject = map[string]any{}
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergeValue(targetObject[name], value)
+	}
+	return targetObject
+}
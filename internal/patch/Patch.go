@@ -0,0 +1,43 @@
+package patch
+
+import (
+	"errors"
+	"mime"
+)
+
+const (
+	MediaTypeMergePatch = "application/merge-patch+json"
+	MediaTypeJsonPatch  = "application/json-patch+json"
+)
+
+// AcceptedMediaTypes lists the patch formats understood by ForContentType, as sent in the Accept-Patch header.
+const AcceptedMediaTypes = MediaTypeMergePatch + ", " + MediaTypeJsonPatch
+
+var (
+	// ErrUnsupportedMediaType is returned by ForContentType for any other content type.
+	ErrUnsupportedMediaType = errors.New("tipo de conteúdo de patch não suportado")
+	// ErrTestFailed is returned by Apply when a JSON Patch "test" operation does not match the document.
+	ErrTestFailed = errors.New("operação test não atendida")
+)
+
+// Patch changes a JSON document, returning the patched copy.
+type Patch interface {
+	Apply(document []byte) ([]byte, error)
+}
+
+// ForContentType parses body as a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902), according to contentType.
+func ForContentType(contentType string, body []byte) (Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupportedMediaType
+	}
+
+	switch mediaType {
+	case MediaTypeMergePatch:
+		return ParseMergePatch(body)
+	case MediaTypeJsonPatch:
+		return ParseJsonPatch(body)
+	default:
+		return nil, ErrUnsupportedMediaType
+	}
+}
@@ -0,0 +1,159 @@
+package ratelimit
+
+import (
+	"PocGo/internal/auth"
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	handlerBase "PocGo/internal/handler/base"
+	"PocGo/internal/logging"
+	"PocGo/internal/middleware"
+	configIO "fmt"
+	muxRouter "github.com/gorilla/mux"
+	httpclient "net/http"
+	"net/netip"
+	"strconv"
+	"time"
+)
+
+// Headers of the IETF RateLimit draft, sent on every limited response.
+const (
+	LimitHeader     = "RateLimit-Limit"
+	RemainingHeader = "RateLimit-Remaining"
+	ResetHeader     = "RateLimit-Reset"
+	PolicyHeader    = "RateLimit-Policy"
+)
+
+// Limiter applies a token bucket per client: the API key or the user of the principal or, without one,
+// the client address. Routes with an override have buckets of their own. A bucket per address, taken
+// before authentication, limits the clients that never authenticate.
+type Limiter struct {
+	store          Store
+	limit          Limit
+	routes         map[string]Limit
+	address        Limit
+	trustedProxies []netip.Prefix
+}
+
+// NewLimiter builds a Limiter over store from settings, reading the client address behind trustedProxies.
+// It returns nil, which Middleware accepts, when limiting is disabled.
+func NewLimiter(store Store, settings *provider.RateLimitConfig, trustedProxies []netip.Prefix) (*Limiter, error) {
+	if settings == nil || !settings.Enabled {
+		return nil, nil
+	}
+
+	limiter := &Limiter{
+		store:          store,
+		limit:          Limit(settings.Default).withDefaults(),
+		routes:         make(map[string]Limit, len(settings.Routes)),
+		trustedProxies: trustedProxies,
+	}
+	if !limiter.limit.valid() {
+		return nil, configIO.Errorf("ratelimit: limite padrão inválido: %d/%s", settings.Default.Requests, settings.Default.Period)
+	}
+
+	for route, limit := range settings.Routes {
+		if !Limit(limit).valid() {
+			return nil, configIO.Errorf("ratelimit: limite inválido para %q", route)
+		}
+		limiter.routes[route] = Limit(limit).withDefaults()
+	}
+
+	if settings.Address.Requests != 0 {
+		limiter.address = Limit(settings.Address).withDefaults()
+		if !limiter.address.valid() {
+			return nil, configIO.Errorf("ratelimit: limite por endereço inválido: %d/%s", settings.Address.Requests, settings.Address.Period)
+		}
+	}
+	return limiter, nil
+}
+
+// Middleware rejects with 429 and Retry-After the requests of a client whose bucket is empty. It is meant for
+// the routes, after authentication, so the principal is known. A nil limiter lets every request through, and
+// so does a store failure, logged as a warning, so an unavailable shared store does not take the API down.
+func (limiter *Limiter) Middleware(next httpclient.Handler) httpclient.Handler {
+	if limiter == nil {
+		return next
+	}
+
+	return httpclient.HandlerFunc(func(w httpclient.ResponseWriter, request *httpclient.Request) {
+		key := limiter.clientKey(request)
+		limit := limiter.limit
+		if route := routeKey(request); route != "" {
+			if override, exists := limiter.routes[route]; exists {
+				key, limit = key+"|"+route, override
+			}
+		}
+
+		if limiter.take(w, request, key, limit) {
+			next.ServeHTTP(w, request)
+		}
+	})
+}
+
+// AddressMiddleware limits the requests of each client address with the address bucket. It is meant to run
+// before authentication, so a client guessing credentials, answered with 401 and never reaching Middleware,
+// is limited as well. Without an address limit, or with a nil limiter, it lets every request through.
+func (limiter *Limiter) AddressMiddleware(next httpclient.Handler) httpclient.Handler {
+	if limiter == nil || limiter.address.Requests == 0 {
+		return next
+	}
+
+	return httpclient.HandlerFunc(func(w httpclient.ResponseWriter, request *httpclient.Request) {
+		key := "address:" + middleware.ClientIP(request, limiter.trustedProxies)
+		if limiter.take(w, request, key, limiter.address) {
+			next.ServeHTTP(w, request)
+		}
+	})
+}
+
+// take takes a token from the bucket of key and sets the headers, answering 429 and returning false when the
+// bucket is empty.
+func (limiter *Limiter) take(w httpclient.ResponseWriter, request *httpclient.Request, key string, limit Limit) bool {
+	decision, err := limiter.store.Take(request.Context(), key, limit)
+	if err != nil {
+		logging.FromContext(request.Context(), nil).Warn("Erro ao consultar o limite de requisições", logging.KeyError, err)
+		return true
+	}
+
+	setHeaders(w.Header(), limit, decision)
+	if !decision.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(seconds(decision.RetryAfter)))
+		handlerBase.SendErrorResponse(w, request, notify.CreateCustomNotification(notify.TooManyRequests, "",
+			configIO.Sprintf("tente novamente em %d s", seconds(decision.RetryAfter))))
+		return false
+	}
+	return true
+}
+
+// clientKey identifies the client of request, as "api_key:<id>", "user:<sub>" or "ip:<endereço>".
+func (limiter *Limiter) clientKey(request *httpclient.Request) string {
+	if principal, authenticated := auth.PrincipalFrom(request.Context()); authenticated {
+		return principal.Kind + ":" + principal.Subject
+	}
+	return "ip:" + middleware.ClientIP(request, limiter.trustedProxies)
+}
+
+// routeKey returns the method and template of the matched route, ex: "GET /api/v1/users/{id}".
+func routeKey(request *httpclient.Request) string {
+	route := muxRouter.CurrentRoute(request)
+	if route == nil {
+		return ""
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return request.Method + " " + template
+}
+
+func setHeaders(header httpclient.Header, limit Limit, decision Decision) {
+	header.Set(LimitHeader, strconv.Itoa(limit.Burst))
+	header.Set(RemainingHeader, strconv.Itoa(decision.Remaining))
+	header.Set(ResetHeader, strconv.Itoa(seconds(decision.Reset)))
+	header.Set(PolicyHeader, configIO.Sprintf("%d;w=%d;burst=%d", limit.Requests, seconds(limit.Period), limit.Burst))
+}
+
+// seconds rounds duration up to whole seconds, as the headers take.
+func seconds(duration time.Duration) int {
+	return int((duration + time.Second - 1) / time.Second)
+}
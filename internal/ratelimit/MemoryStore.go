@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often MemoryStore drops the buckets that refilled, which behave as new ones.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket refills completely and may be dropped.
+	full time.Time
+}
+
+// MemoryStore keeps the buckets of one process, so each replica enforces the limits on its own.
+type MemoryStore struct {
+	mutex     sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryStore builds an empty store; now may be nil to use time.Now.
+func NewMemoryStore(now func() time.Time) *MemoryStore {
+	if now == nil {
+		now = time.Now
+	}
+	return &MemoryStore{buckets: map[string]*bucket{}, now: now}
+}
+
+func (store *MemoryStore) Take(_ context.Context, key string, limit Limit) (Decision, error) {
+	limit = limit.withDefaults()
+	interval := limit.interval()
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	store.sweep(now)
+
+	current, exists := store.buckets[key]
+	if !exists {
+		current = &bucket{tokens: float64(limit.Burst), updated: now}
+		store.buckets[key] = current
+	}
+
+	elapsed := now.Sub(current.updated)
+	if elapsed > 0 {
+		current.tokens = min(float64(limit.Burst), current.tokens+float64(elapsed)/float64(interval))
+		current.updated = now
+	}
+
+	decision := Decision{Allowed: current.tokens >= 1}
+	if decision.Allowed {
+		current.tokens--
+	} else {
+		decision.RetryAfter = time.Duration((1 - current.tokens) * float64(interval))
+	}
+
+	decision.Remaining = int(current.tokens)
+	decision.Reset = time.Duration((float64(limit.Burst) - current.tokens) * float64(interval))
+	current.full = now.Add(decision.Reset)
+	return decision, nil
+}
+
+// Len returns the number of buckets kept.
+func (store *MemoryStore) Len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return len(store.buckets)
+}
+
+func (store *MemoryStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < sweepInterval {
+		return
+	}
+	store.lastSweep = now
+
+	for key, current := range store.buckets {
+		if !current.full.After(now) {
+			delete(store.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit is a token bucket holding up to Burst tokens, refilled at Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// valid reports whether the limit can refill; a Burst of zero or less falls back to Requests.
+func (limit Limit) valid() bool {
+	return limit.Requests > 0 && limit.Period > 0
+}
+
+func (limit Limit) withDefaults() Limit {
+	if limit.Burst <= 0 {
+		limit.Burst = limit.Requests
+	}
+	return limit
+}
+
+// interval is the time to refill one token.
+func (limit Limit) interval() time.Duration {
+	return limit.Period / time.Duration(limit.Requests)
+}
+
+// Decision is the outcome of taking a token.
+type Decision struct {
+	Allowed bool
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, when the request was not allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets. MemoryStore keeps them in the process; a store shared by the replicas, such as
+// one backed by Redis, only has to implement Take atomically for the limits to hold across the replicas.
+type Store interface {
+	// Take removes one token from the bucket of key, created full on first use, if one is available.
+	Take(ctx context.Context, key string, limit Limit) (Decision, error)
+}
@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	dbProvider "database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	createApiKeyQuery     = `INSERT INTO [Auth].[ApiKey] ([id], [name], [prefix], [key_hash], [roles], [created_at], [expires_at]) VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)`
+	findApiKeyByHashQuery = `SELECT [id], [name], [prefix], [key_hash], [roles], [created_at], [expires_at], [revoked_at] FROM [Auth].[ApiKey] WHERE [key_hash] = @p1`
+	revokeApiKeyQuery     = `UPDATE [Auth].[ApiKey] SET [revoked_at] = @p1 WHERE [id] = @p2 AND [revoked_at] IS NULL`
+)
+
+// ApiKeyRepository stores the API keys by the hash of the key; the key itself is never stored.
+type ApiKeyRepository interface {
+	Create(ctx context.Context, key *entity.ApiKey) error
+	// FindByHash returns a NOT_FOUND DomainError when no key has hash, revoked and expired keys included.
+	FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error)
+	// Revoke marks the key as revoked at revokedAt; revoking an unknown or already revoked key is NOT_FOUND.
+	Revoke(ctx context.Context, id values.GUID, revokedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	dataBase *tracedDB
+	timeouts operationTimeouts
+}
+
+func NewApiKeyRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig) ApiKeyRepository {
+	return &apiKeyRepository{
+		dataBase: newTracedDB(dbProvider, dbSystemSqlServer),
+		timeouts: newOperationTimeouts(timeouts),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.ApiKey) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	return createApiKey(ctx, r.dataBase, createApiKeyQuery, key)
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	var id values.GUID
+	var row apiKeyRow
+	err := r.dataBase.QueryRowContext(ctx, findApiKeyByHashQuery, hash).Scan(append([]any{&id}, row.targets()...)...)
+	return row.toEntity(id, err)
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id values.GUID, revokedAt time.Time) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, revokeApiKeyQuery, revokedAt.UTC(), id)
+	return singleRowResult(result, err)
+}
+
+// createApiKey runs the insert shared by the SQL repositories, whose arguments are
+// id, name, prefix, key_hash, roles, created_at and expires_at.
+func createApiKey(ctx context.Context, db *tracedDB, query string, key *entity.ApiKey) error {
+	if key.ID.IsZero() {
+		key.ID = values.NewGUID()
+	}
+
+	var expiresAt dbProvider.NullTime
+	if key.ExpiresAt != nil {
+		expiresAt = dbProvider.NullTime{Time: key.ExpiresAt.UTC(), Valid: true}
+	}
+
+	_, err := db.ExecContext(ctx, query,
+		key.ID, key.Name, key.Prefix, key.Hash, strings.Join(key.Roles, ","), key.CreatedAt.UTC(), expiresAt)
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+	return nil
+}
+
+// apiKeyRow holds the columns shared by the SQL API key repositories, except the id.
+type apiKeyRow struct {
+	name      string
+	prefix    string
+	hash      string
+	roles     string
+	createdAt time.Time
+	expiresAt dbProvider.NullTime
+	revokedAt dbProvider.NullTime
+}
+
+func (row *apiKeyRow) targets() []any {
+	return []any{&row.name, &row.prefix, &row.hash, &row.roles, &row.createdAt, &row.expiresAt, &row.revokedAt}
+}
+
+// toEntity converts the row read with scanErr into an ApiKey, mapping a missing row to NOT_FOUND.
+func (row *apiKeyRow) toEntity(id values.GUID, scanErr error) (*entity.ApiKey, error) {
+	if errors.Is(scanErr, dbProvider.ErrNoRows) {
+		return nil, notify.CreateCustomNotification(notify.NotFound, "Chave de API", nil)
+	}
+	if scanErr != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, scanErr)
+	}
+
+	key := &entity.ApiKey{
+		ID:        id,
+		Name:      row.name,
+		Prefix:    row.prefix,
+		Hash:      row.hash,
+		Roles:     splitRoles(row.roles),
+		CreatedAt: row.createdAt,
+	}
+	if row.expiresAt.Valid {
+		expiresAt := row.expiresAt.Time
+		key.ExpiresAt = &expiresAt
+	}
+	if row.revokedAt.Valid {
+		revokedAt := row.revokedAt.Time
+		key.RevokedAt = &revokedAt
+	}
+	return key, nil
+}
+
+func splitRoles(roles string) []string {
+	if roles == "" {
+		return []string{}
+	}
+	return strings.Split(roles, ",")
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/lock"
+	dbProvider "database/sql"
+	"log/slog"
+	"sync"
+)
+
+// Backend describes how the repositories are built for a given DB_DRIVER.
+type Backend struct {
+	Name                string
+	RequiresConnection  bool
+	NewUserRepository   func(db *dbProvider.DB, timeouts *provider.TimeoutConfig, logger *slog.Logger) UserRepository
+	NewJobRunRepository func(db *dbProvider.DB, timeouts *provider.TimeoutConfig) JobRunRepository
+	NewApiKeyRepository func(db *dbProvider.DB, timeouts *provider.TimeoutConfig) ApiKeyRepository
+	// NewLocker builds the lock shared by the replicas using this backend.
+	NewLocker func(db *dbProvider.DB) lock.Locker
+}
+
+var backendRegistry = struct {
+	sync.RWMutex
+	backends map[string]Backend
+}{
+	backends: make(map[string]Backend),
+}
+
+func init() {
+	RegisterBackend(Backend{
+		Name:                provider.DriverSqlServer,
+		RequiresConnection:  true,
+		NewUserRepository:   NewUserRepository,
+		NewJobRunRepository: NewJobRunRepository,
+		NewApiKeyRepository: NewApiKeyRepository,
+		NewLocker: func(db *dbProvider.DB) lock.Locker {
+			return lock.NewSqlServerLocker(db)
+		},
+	})
+
+	RegisterBackend(Backend{
+		Name:                provider.DriverSqlite,
+		RequiresConnection:  true,
+		NewUserRepository:   NewSqliteUserRepository,
+		NewJobRunRepository: NewSqliteJobRunRepository,
+		NewApiKeyRepository: NewSqliteApiKeyRepository,
+		NewLocker: func(db *dbProvider.DB) lock.Locker {
+			return lock.NewSqliteLocker(db)
+		},
+	})
+
+	RegisterBackend(Backend{
+		Name:               provider.DriverMemory,
+		RequiresConnection: false,
+		NewUserRepository: func(_ *dbProvider.DB, _ *provider.TimeoutConfig, _ *slog.Logger) UserRepository {
+			return NewMemoryUserRepository()
+		},
+		NewJobRunRepository: func(_ *dbProvider.DB, _ *provider.TimeoutConfig) JobRunRepository {
+			return NewMemoryJobRunRepository()
+		},
+		NewApiKeyRepository: func(_ *dbProvider.DB, _ *provider.TimeoutConfig) ApiKeyRepository {
+			return NewMemoryApiKeyRepository()
+		},
+		NewLocker: func(_ *dbProvider.DB) lock.Locker {
+			return lock.NewMemoryLocker()
+		},
+	})
+}
+
+// RegisterBackend adds or replaces the backend registered under backend.Name.
+func RegisterBackend(backend Backend) {
+	backendRegistry.Lock()
+	defer backendRegistry.Unlock()
+	backendRegistry.backends[backend.Name] = backend
+}
+
+// GetBackend returns the backend registered for the given driver.
+func GetBackend(driver string) (Backend, error) {
+	backendRegistry.RLock()
+	defer backendRegistry.RUnlock()
+
+	backend, exists := backendRegistry.backends[driver]
+	if !exists {
+		return Backend{}, notify.CreateCustomNotification(notify.ErrorUnknownDriver, "", driver)
+	}
+
+	return backend, nil
+}
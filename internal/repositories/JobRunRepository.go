@@ -0,0 +1,236 @@
+package repositories
+
+import (
+	converter "PocGo/internal/configuration/converters"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	dbProvider "database/sql"
+	setJson "encoding/json"
+	"time"
+)
+
+const (
+	createJobRunQuery = `INSERT INTO [Jobs].[JobRun] ([id], [job_name], [triggered_by], [dry_run], [status], [started_at], [scheduled_for]) VALUES (@p1, @p2, @p3, @p4, @p5, @p6, @p7)`
+	updateJobRunQuery = `UPDATE [Jobs].[JobRun] SET [status] = @p1, [finished_at] = @p2, [processed] = @p3, [updated] = @p4, [failed] = @p5, [error] = @p6, [item_errors] = @p7 WHERE [id] = @p8`
+	findJobRunsQuery  = `SELECT TOP (@p2) [id], [job_name], [triggered_by], [dry_run], [status], [started_at], [finished_at], [processed], [updated], [failed], [error], [item_errors], [scheduled_for] FROM [Jobs].[JobRun] WHERE [job_name] = @p1 ORDER BY [started_at] DESC`
+	slotRunQuery      = `SELECT COUNT(1) FROM [Jobs].[JobRun] WHERE [job_name] = @p1 AND [scheduled_for] = @p2`
+)
+
+// JobRunRepository keeps the history of job runs, newest first.
+type JobRunRepository interface {
+	Create(ctx context.Context, run *entity.JobRun) error
+	Update(ctx context.Context, run *entity.JobRun) error
+	FindByJob(ctx context.Context, jobName string, limit int) (*[]entity.JobRun, error)
+	// ExistsForSlot reports whether a run of jobName was recorded for the schedule slot.
+	ExistsForSlot(ctx context.Context, jobName string, slot time.Time) (bool, error)
+}
+
+type jobRunRepository struct {
+	dataBase *tracedDB
+	timeouts operationTimeouts
+}
+
+func NewJobRunRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig) JobRunRepository {
+	return &jobRunRepository{
+		dataBase: newTracedDB(dbProvider, dbSystemSqlServer),
+		timeouts: newOperationTimeouts(timeouts),
+	}
+}
+
+func (r *jobRunRepository) Create(ctx context.Context, run *entity.JobRun) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	if run.ID == "" {
+		run.ID = values.NewGUID().String()
+	}
+
+	_, err := r.dataBase.ExecContext(ctx, createJobRunQuery,
+		run.ID, run.JobName, run.TriggeredBy, run.DryRun, string(run.Status), run.StartedAt.UTC(), nullableSlot(run.ScheduledFor))
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	return nil
+}
+
+func (r *jobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	args, err := jobRunUpdateArgs(run)
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	result, err := r.dataBase.ExecContext(ctx, updateJobRunQuery, args...)
+	return singleRowResult(result, err)
+}
+
+func (r *jobRunRepository) FindByJob(ctx context.Context, jobName string, limit int) (*[]entity.JobRun, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	rows, err := r.dataBase.QueryContext(ctx, findJobRunsQuery, jobName, limit)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer rows.Close()
+
+	var runs []entity.JobRun
+	for rows.Next() {
+		var id values.GUID
+		var row jobRunRow
+
+		if err := rows.Scan(append([]any{&id}, row.targets()...)...); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+
+		run, err := row.toEntity(id.String())
+		if err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	}
+
+	safeRuns := converter.ListSafe(runs)
+	return &safeRuns, nil
+}
+
+func (r *jobRunRepository) ExistsForSlot(ctx context.Context, jobName string, slot time.Time) (bool, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	return countSlotRuns(ctx, r.dataBase, slotRunQuery, jobName, slot)
+}
+
+// countSlotRuns runs query, which counts the runs of a job and slot, for both SQL dialects.
+func countSlotRuns(ctx context.Context, dataBase *tracedDB, query string, jobName string, slot time.Time) (bool, error) {
+	var count int
+	if err := dataBase.QueryRowContext(ctx, query, jobName, slot.UTC()).Scan(&count); err != nil {
+		return false, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	return count > 0, nil
+}
+
+// nullableSlot writes the slot of a scheduled run, or NULL for a manual one.
+func nullableSlot(slot *time.Time) dbProvider.NullTime {
+	if slot == nil {
+		return dbProvider.NullTime{}
+	}
+	return dbProvider.NullTime{Time: slot.UTC(), Valid: true}
+}
+
+// jobRunRow holds the columns shared by the SQL job run repositories, except the id,
+// whose representation depends on the driver.
+type jobRunRow struct {
+	jobName      string
+	triggeredBy  string
+	dryRun       bool
+	status       string
+	startedAt    time.Time
+	finishedAt   dbProvider.NullTime
+	processed    int
+	updated      int
+	failed       int
+	errorText    dbProvider.NullString
+	itemErrors   dbProvider.NullString
+	scheduledFor dbProvider.NullTime
+}
+
+func (row *jobRunRow) targets() []any {
+	return []any{
+		&row.jobName,
+		&row.triggeredBy,
+		&row.dryRun,
+		&row.status,
+		&row.startedAt,
+		&row.finishedAt,
+		&row.processed,
+		&row.updated,
+		&row.failed,
+		&row.errorText,
+		&row.itemErrors,
+		&row.scheduledFor,
+	}
+}
+
+func (row *jobRunRow) toEntity(id string) (entity.JobRun, error) {
+	run := entity.JobRun{
+		ID:          id,
+		JobName:     row.jobName,
+		TriggeredBy: row.triggeredBy,
+		DryRun:      row.dryRun,
+		Status:      entity.JobRunStatus(row.status),
+		StartedAt:   row.startedAt,
+		Processed:   row.processed,
+		Updated:     row.updated,
+		Failed:      row.failed,
+		Error:       row.errorText.String,
+	}
+
+	if row.finishedAt.Valid {
+		finishedAt := row.finishedAt.Time
+		run.FinishedAt = &finishedAt
+	}
+
+	if row.scheduledFor.Valid {
+		scheduledFor := row.scheduledFor.Time
+		run.ScheduledFor = &scheduledFor
+	}
+
+	if row.itemErrors.Valid && row.itemErrors.String != "" {
+		if err := setJson.Unmarshal([]byte(row.itemErrors.String), &run.Errors); err != nil {
+			return entity.JobRun{}, err
+		}
+	}
+
+	return run, nil
+}
+
+// jobRunUpdateArgs returns the arguments of the update statements, in the order
+// status, finished_at, processed, updated, failed, error, item_errors, id.
+func jobRunUpdateArgs(run *entity.JobRun) ([]any, error) {
+	var finishedAt dbProvider.NullTime
+	if run.FinishedAt != nil {
+		finishedAt = dbProvider.NullTime{Time: run.FinishedAt.UTC(), Valid: true}
+	}
+
+	var itemErrors dbProvider.NullString
+	if len(run.Errors) > 0 {
+		encoded, err := setJson.Marshal(run.Errors)
+		if err != nil {
+			return nil, err
+		}
+		itemErrors = dbProvider.NullString{String: string(encoded), Valid: true}
+	}
+
+	errorText := dbProvider.NullString{String: run.Error, Valid: run.Error != ""}
+
+	return []any{string(run.Status), finishedAt, run.Processed, run.Updated, run.Failed, errorText, itemErrors, run.ID}, nil
+}
+
+// singleRowResult turns the outcome of a write that must touch a row into a DomainError.
+func singleRowResult(result dbProvider.Result, err error) error {
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	if rowsAffected == 0 {
+		return notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryApiKeyRepository keeps API keys in memory; they are lost on restart.
+type MemoryApiKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[values.GUID]entity.ApiKey
+}
+
+func NewMemoryApiKeyRepository() *MemoryApiKeyRepository {
+	return &MemoryApiKeyRepository{
+		keys: make(map[values.GUID]entity.ApiKey),
+	}
+}
+
+func (r *MemoryApiKeyRepository) Create(ctx context.Context, key *entity.ApiKey) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.keys {
+		if existing.Hash == key.Hash {
+			return notify.CreateCustomNotification(notify.Conflict, "Chave de API", "hash já cadastrado")
+		}
+	}
+
+	if key.ID.IsZero() {
+		key.ID = values.NewGUID()
+	}
+	r.keys[key.ID] = copyApiKey(*key)
+	return nil
+}
+
+func (r *MemoryApiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash == hash {
+			found := copyApiKey(key)
+			return &found, nil
+		}
+	}
+	return nil, notify.CreateCustomNotification(notify.NotFound, "Chave de API", nil)
+}
+
+func (r *MemoryApiKeyRepository) Revoke(ctx context.Context, id values.GUID, revokedAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key, exists := r.keys[id]
+	if !exists || key.RevokedAt != nil {
+		return notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+
+	key.RevokedAt = &revokedAt
+	r.keys[id] = key
+	return nil
+}
+
+// copyApiKey detaches the stored key from the caller's slices and pointers.
+func copyApiKey(key entity.ApiKey) entity.ApiKey {
+	key.Roles = append([]string{}, key.Roles...)
+	if key.ExpiresAt != nil {
+		expiresAt := *key.ExpiresAt
+		key.ExpiresAt = &expiresAt
+	}
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	converter "PocGo/internal/configuration/converters"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryJobRunRepository keeps job runs in memory; the history is lost on restart.
+type MemoryJobRunRepository struct {
+	mu   sync.RWMutex
+	runs map[string]entity.JobRun
+}
+
+func NewMemoryJobRunRepository() *MemoryJobRunRepository {
+	return &MemoryJobRunRepository{
+		runs: make(map[string]entity.JobRun),
+	}
+}
+
+func (r *MemoryJobRunRepository) Create(ctx context.Context, run *entity.JobRun) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if run.ID == "" {
+		run.ID = values.NewGUID().String()
+	}
+
+	if run.ScheduledFor != nil && r.existsForSlot(run.JobName, *run.ScheduledFor) {
+		return notify.CreateCustomNotification(notify.Conflict, "Job", "execução já registrada para o horário")
+	}
+
+	r.runs[run.ID] = copyJobRun(*run)
+	return nil
+}
+
+func (r *MemoryJobRunRepository) ExistsForSlot(ctx context.Context, jobName string, slot time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.existsForSlot(jobName, slot), nil
+}
+
+// existsForSlot must be called with r.mu held.
+func (r *MemoryJobRunRepository) existsForSlot(jobName string, slot time.Time) bool {
+	for _, run := range r.runs {
+		if run.JobName == jobName && run.ScheduledFor != nil && run.ScheduledFor.Equal(slot) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryJobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.runs[run.ID]; !exists {
+		return notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+
+	r.runs[run.ID] = copyJobRun(*run)
+	return nil
+}
+
+func (r *MemoryJobRunRepository) FindByJob(ctx context.Context, jobName string, limit int) (*[]entity.JobRun, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var runs []entity.JobRun
+	for _, run := range r.runs {
+		if run.JobName == jobName {
+			runs = append(runs, copyJobRun(run))
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+
+	if limit > 0 && len(runs) > limit {
+		runs = runs[:limit]
+	}
+
+	safeRuns := converter.ListSafe(runs)
+	return &safeRuns, nil
+}
+
+// copyJobRun detaches the stored run from the caller's slices and pointers.
+func copyJobRun(run entity.JobRun) entity.JobRun {
+	if run.FinishedAt != nil {
+		finishedAt := *run.FinishedAt
+		run.FinishedAt = &finishedAt
+	}
+	if run.ScheduledFor != nil {
+		scheduledFor := *run.ScheduledFor
+		run.ScheduledFor = &scheduledFor
+	}
+	run.Errors = append([]entity.ItemError(nil), run.Errors...)
+	return run
+}
@@ -0,0 +1,423 @@
+package repositories
+
+import (
+	converter "PocGo/internal/configuration/converters"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	configIO "fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// filterDateLayouts are the date formats accepted by FindAll, mirroring what SQL Server converts implicitly.
+var filterDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+type memoryUserRecord struct {
+	user            entity.User
+	normalizedLogin string
+	creationDate    time.Time
+	deletedAt       *time.Time
+}
+
+// MemoryUserRepository keeps users in process memory. It is meant for local runs and tests.
+type MemoryUserRepository struct {
+	mu      sync.RWMutex
+	records map[values.GUID]*memoryUserRecord
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{
+		records: make(map[values.GUID]*memoryUserRecord),
+	}
+}
+
+// Seed stores user as if it had been created at creationDate, replacing any user with the same ID.
+// A zero Version becomes the initial one.
+func (r *MemoryUserRepository) Seed(user entity.User, creationDate time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user.Version == 0 {
+		user.Version = initialVersion
+	}
+
+	r.records[user.ID] = &memoryUserRecord{
+		user:            user,
+		normalizedLogin: NormalizeLogin(user.Email),
+		creationDate:    creationDate,
+	}
+}
+
+// active returns the record of a user that was not soft-deleted. Callers must hold the lock.
+func (r *MemoryUserRepository) active(id values.GUID) (*memoryUserRecord, bool) {
+	record, exists := r.records[id]
+	if !exists || record.deletedAt != nil {
+		return nil, false
+	}
+	return record, true
+}
+
+func (r *MemoryUserRepository) FindById(ctx context.Context, id values.GUID) (*entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, exists := r.active(id)
+	if !exists {
+		return nil, notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+
+	user := record.user
+	return &user, nil
+}
+
+func (r *MemoryUserRepository) FindAll(ctx context.Context, date string) (*[]entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	after, err := parseFilterDate(date)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	return r.filter(func(record *memoryUserRecord) bool {
+		return record.creationDate.After(after)
+	}), nil
+}
+
+// List mirrors the SQL listing, comparing prefixes case-insensitively as the default collations do.
+func (r *MemoryUserRepository) List(ctx context.Context, query UserListQuery) (*UserPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	cursor, err := validateListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	var listed []listedUser
+	for _, record := range r.records {
+		if record.deletedAt == nil && matchesUserFilter(record, query.Filter) {
+			listed = append(listed, listedUser{
+				user:            record.user,
+				normalizedLogin: record.normalizedLogin,
+				creationDate:    record.creationDate,
+			})
+		}
+	}
+	r.mu.RUnlock()
+
+	compare := func(a, b listedUser) int {
+		result := strings.Compare(a.sortValue(query.Sort), b.sortValue(query.Sort))
+		if query.Sort == SortByCreationDate {
+			result = a.creationDate.Compare(b.creationDate)
+		}
+		if result == 0 {
+			result = a.user.ID.Compare(b.user.ID)
+		}
+		if query.Descending {
+			return -result
+		}
+		return result
+	}
+	slices.SortFunc(listed, compare)
+
+	if cursor != nil {
+		position := listedUser{user: entity.User{ID: cursor.ID, Email: cursor.Value}, normalizedLogin: cursor.Value}
+		position.creationDate, _ = time.Parse(time.RFC3339Nano, cursor.Value)
+		start, found := slices.BinarySearchFunc(listed, position, compare)
+		if found {
+			start++
+		}
+		listed = listed[start:]
+	}
+
+	return buildUserPage(query, listed[:min(len(listed), query.Limit+1)]), nil
+}
+
+func matchesUserFilter(record *memoryUserRecord, filter UserFilter) bool {
+	if len(filter.Statuses) > 0 && !containsStatus(filter.Statuses, record.user.Status) {
+		return false
+	}
+	if filter.EmailPrefix != "" && !strings.HasPrefix(strings.ToUpper(record.user.Email), strings.ToUpper(filter.EmailPrefix)) {
+		return false
+	}
+	if filter.LoginPrefix != "" && !strings.HasPrefix(record.normalizedLogin, NormalizeLogin(filter.LoginPrefix)) {
+		return false
+	}
+	if !filter.CreatedFrom.IsZero() && record.creationDate.Before(filter.CreatedFrom) {
+		return false
+	}
+	if !filter.CreatedTo.IsZero() && !record.creationDate.Before(filter.CreatedTo) {
+		return false
+	}
+	return true
+}
+
+func (r *MemoryUserRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.active(user.ID)
+	if !exists || record.user.Version != user.Version {
+		return notify.CreateCustomNotification(notify.PreconditionFailed, "", configIO.Sprintf("versão %d", user.Version))
+	}
+
+	normalizedLogin := NormalizeLogin(user.Email)
+	if r.loginTaken(normalizedLogin, user.ID) {
+		return notify.CreateCustomNotification(notify.Conflict, "", "login duplicado")
+	}
+
+	record.user.Name = user.Name
+	record.user.Email = user.Email
+	record.normalizedLogin = normalizedLogin
+	record.user.Status = user.Status
+	record.user.Version++
+	user.Version = record.user.Version
+	return nil
+}
+
+func (r *MemoryUserRepository) UpdateStatus(ctx context.Context, id values.GUID, status entity.UserStatus) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.active(id)
+	if !exists {
+		return notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+
+	record.user.Status = status
+	record.user.Version++
+	return nil
+}
+
+// UpdateStatusBatch mirrors the SQL backends: users in ID order, Size per chunk, with Progress after each chunk.
+// Chunks cannot fail in memory, so Failed is always empty.
+func (r *MemoryUserRepository) UpdateStatusBatch(ctx context.Context, batch StatusBatch) (BatchResult, error) {
+	batch = normalizeBatch(batch)
+
+	var result BatchResult
+	if len(batch.From) == 0 {
+		return result, nil
+	}
+
+	candidates := r.filter(func(record *memoryUserRecord) bool {
+		return record.creationDate.Before(batch.CreatedBefore)
+	})
+
+	for start := 0; start < len(*candidates); start += batch.Size {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		end := min(start+batch.Size, len(*candidates))
+		r.updateStatusChunk(batch, (*candidates)[start:end], &result)
+
+		if batch.Progress != nil {
+			batch.Progress(result)
+		}
+	}
+
+	return result, nil
+}
+
+// updateStatusChunk re-checks each user under the lock, as the SQL update does, since it may have changed after filter.
+func (r *MemoryUserRepository) updateStatusChunk(batch StatusBatch, chunk []entity.User, result *BatchResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range chunk {
+		result.Processed++
+
+		record, exists := r.active(user.ID)
+		if !exists || !containsStatus(batch.From, record.user.Status) {
+			result.Skipped = append(result.Skipped, user.ID.String())
+			continue
+		}
+
+		if !batch.DryRun {
+			record.user.Status = batch.To
+			record.user.Version++
+		}
+		result.Updated = append(result.Updated, user.ID.String())
+	}
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *entity.User) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user.ID.IsZero() {
+		user.ID = values.NewGUID()
+	}
+	user.Version = initialVersion
+
+	if _, exists := r.records[user.ID]; exists {
+		return notify.CreateCustomNotification(notify.InvalidData, "", "id duplicado")
+	}
+	if r.loginTaken(NormalizeLogin(user.Email), values.GUID{}) {
+		return notify.CreateCustomNotification(notify.Conflict, "", "login duplicado")
+	}
+
+	r.records[user.ID] = &memoryUserRecord{
+		user:            *user,
+		normalizedLogin: NormalizeLogin(user.Email),
+		creationDate:    time.Now(),
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) Delete(ctx context.Context, id values.GUID) error {
+	return r.mutate(ctx, id, func(record *memoryUserRecord) bool {
+		if record.deletedAt != nil {
+			return false
+		}
+		now := time.Now()
+		record.deletedAt = &now
+		record.user.Status = entity.StatusDeleted
+		return true
+	})
+}
+
+func (r *MemoryUserRepository) Restore(ctx context.Context, id values.GUID) error {
+	return r.mutate(ctx, id, func(record *memoryUserRecord) bool {
+		if record.deletedAt == nil {
+			return false
+		}
+		record.deletedAt = nil
+		record.user.Status = entity.StatusInactive
+		return true
+	})
+}
+
+func (r *MemoryUserRepository) Reactivate(ctx context.Context, id values.GUID) error {
+	return r.mutate(ctx, id, func(record *memoryUserRecord) bool {
+		if record.deletedAt != nil || record.user.Status != entity.StatusInactive {
+			return false
+		}
+		record.user.Status = entity.StatusActive
+		return true
+	})
+}
+
+func (r *MemoryUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	normalizedEmail := NormalizeEmail(email)
+	return r.any(ctx, func(record *memoryUserRecord) bool {
+		return record.normalizedLogin == normalizedEmail
+	})
+}
+
+func (r *MemoryUserRepository) ExistsByLogin(ctx context.Context, normalizedLogin string) (bool, error) {
+	return r.any(ctx, func(record *memoryUserRecord) bool {
+		return record.normalizedLogin == normalizedLogin
+	})
+}
+
+// loginTaken mirrors the unique index on normalized_login, ignoring the user with exceptId.
+// Callers must hold the lock.
+func (r *MemoryUserRepository) loginTaken(normalizedLogin string, exceptId values.GUID) bool {
+	for id, record := range r.records {
+		if id != exceptId && record.normalizedLogin == normalizedLogin {
+			return true
+		}
+	}
+	return false
+}
+
+// mutate applies change to the user with id, reporting NOT_FOUND when change declines to touch it,
+// the same way a SQL UPDATE that affects no rows does.
+func (r *MemoryUserRepository) mutate(ctx context.Context, id values.GUID, change func(record *memoryUserRecord) bool) error {
+	if err := ctx.Err(); err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.records[id]
+	if !exists || !change(record) {
+		return notify.CreateSimpleNotification(notify.NotFound, nil)
+	}
+	record.user.Version++
+	return nil
+}
+
+func (r *MemoryUserRepository) any(ctx context.Context, match func(record *memoryUserRecord) bool) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, record := range r.records {
+		if match(record) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *MemoryUserRepository) filter(match func(record *memoryUserRecord) bool) *[]entity.User {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var users []entity.User
+	for _, record := range r.records {
+		if record.deletedAt == nil && match(record) {
+			users = append(users, record.user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID.Compare(users[j].ID) < 0
+	})
+
+	safeUsers := converter.ListSafe(users)
+	return &safeUsers
+}
+
+func parseFilterDate(date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+
+	var lastErr error
+	for _, layout := range filterDateLayouts {
+		parsed, err := time.ParseInLocation(layout, date, time.Local)
+		if err == nil {
+			return parsed, nil
+		}
+		lastErr = err
+	}
+
+	return time.Time{}, lastErr
+}
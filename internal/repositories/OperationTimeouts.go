@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"context"
+	"time"
+)
+
+// operationTimeouts derives the per-statement contexts used by the SQL repositories.
+type operationTimeouts struct {
+	read  time.Duration
+	write time.Duration
+}
+
+func newOperationTimeouts(config *provider.TimeoutConfig) operationTimeouts {
+	if config == nil {
+		return operationTimeouts{}
+	}
+
+	return operationTimeouts{
+		read:  config.Read,
+		write: config.Write,
+	}
+}
+
+func (timeouts operationTimeouts) forRead(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withOptionalTimeout(ctx, timeouts.read)
+}
+
+func (timeouts operationTimeouts) forWrite(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withOptionalTimeout(ctx, timeouts.write)
+}
+
+func withOptionalTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -1,21 +1,49 @@
 package repositories
 
 import (
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/lock"
+	"PocGo/internal/logging"
 	sqlServer "database/sql"
+	"log/slog"
 )
 
 type Repositories struct {
-	db   *sqlServer.DB
-	User UserRepository
+	db      *sqlServer.DB
+	backend string
+	User    UserRepository
+	JobRun  JobRunRepository
+	ApiKey  ApiKeyRepository
+	Locker  lock.Locker
 	// Outros repositórios aqui
 }
 
-func NewRepositories(db *sqlServer.DB) (*Repositories, error) {
+// NewRepositories builds the repositories of the backend registered for driver.
+// timeouts may be nil, in which case statements only honour the caller's context, and logger may be nil
+// to use slog.Default.
+func NewRepositories(driver string, db *sqlServer.DB, timeouts *provider.TimeoutConfig, logger *slog.Logger) (*Repositories, error) {
+	backend, err := GetBackend(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	if backend.RequiresConnection && db == nil {
+		return nil, notify.CreateCustomNotification(notify.ErrorNoConnection, "", driver)
+	}
+
 	repos := &Repositories{
-		db: db,
+		db:      db,
+		backend: backend.Name,
 	}
 
-	repos.User = NewUserRepository(db)
+	repos.User = backend.NewUserRepository(db, timeouts, logging.OrDefault(logger))
+	repos.JobRun = backend.NewJobRunRepository(db, timeouts)
+	repos.ApiKey = backend.NewApiKeyRepository(db, timeouts)
+	if backend.NewLocker != nil {
+		repos.Locker = backend.NewLocker(db)
+	}
 
 	return repos, nil
 }
@@ -23,3 +51,18 @@ func NewRepositories(db *sqlServer.DB) (*Repositories, error) {
 func (repos *Repositories) GetDB() *sqlServer.DB {
 	return repos.db
 }
+
+func (repos *Repositories) GetBackend() string {
+	return repos.backend
+}
+
+// NormalizeLogin returns the value stored in normalized_login for login, as values.Login.Normalized does.
+func NormalizeLogin(login string) string {
+	return values.Login(login).Normalized()
+}
+
+// NormalizeEmail returns the normalized form of email, as values.Email.Normalized does. The login is the email,
+// so it is the value normalized_login holds for the user with that email.
+func NormalizeEmail(email string) string {
+	return values.Email(email).Normalized()
+}
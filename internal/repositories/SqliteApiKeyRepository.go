@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
+	"context"
+	dbProvider "database/sql"
+	"time"
+)
+
+const (
+	sqliteCreateApiKeyQuery     = `INSERT INTO api_key (id, name, prefix, key_hash, roles, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	sqliteFindApiKeyByHashQuery = `SELECT id, name, prefix, key_hash, roles, created_at, expires_at, revoked_at FROM api_key WHERE key_hash = ?`
+	sqliteRevokeApiKeyQuery     = `UPDATE api_key SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`
+)
+
+type sqliteApiKeyRepository struct {
+	dataBase *tracedDB
+	timeouts operationTimeouts
+}
+
+// NewSqliteApiKeyRepository builds an ApiKeyRepository over the api_key table of a SQLite database.
+func NewSqliteApiKeyRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig) ApiKeyRepository {
+	return &sqliteApiKeyRepository{
+		dataBase: newTracedDB(dbProvider, dbSystemSqlite),
+		timeouts: newOperationTimeouts(timeouts),
+	}
+}
+
+func (r *sqliteApiKeyRepository) Create(ctx context.Context, key *entity.ApiKey) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	return createApiKey(ctx, r.dataBase, sqliteCreateApiKeyQuery, key)
+}
+
+func (r *sqliteApiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.ApiKey, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	var id values.GUID
+	var row apiKeyRow
+	err := r.dataBase.QueryRowContext(ctx, sqliteFindApiKeyByHashQuery, hash).Scan(append([]any{&id}, row.targets()...)...)
+	return row.toEntity(id, err)
+}
+
+func (r *sqliteApiKeyRepository) Revoke(ctx context.Context, id values.GUID, revokedAt time.Time) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, sqliteRevokeApiKeyQuery, revokedAt.UTC(), id)
+	return singleRowResult(result, err)
+}
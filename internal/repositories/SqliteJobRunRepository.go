@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	converter "PocGo/internal/configuration/converters"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	dbProvider "database/sql"
+	"time"
+)
+
+const (
+	sqliteCreateJobRunQuery = `INSERT INTO job_run (id, job_name, triggered_by, dry_run, status, started_at, scheduled_for) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	sqliteUpdateJobRunQuery = `UPDATE job_run SET status = ?, finished_at = ?, processed = ?, updated = ?, failed = ?, error = ?, item_errors = ? WHERE id = ?`
+	sqliteFindJobRunsQuery  = `SELECT id, job_name, triggered_by, dry_run, status, started_at, finished_at, processed, updated, failed, error, item_errors, scheduled_for FROM job_run WHERE job_name = ? ORDER BY started_at DESC LIMIT ?`
+	sqliteSlotRunQuery      = `SELECT COUNT(1) FROM job_run WHERE job_name = ? AND scheduled_for = ?`
+)
+
+type sqliteJobRunRepository struct {
+	dataBase *tracedDB
+	timeouts operationTimeouts
+}
+
+// NewSqliteJobRunRepository builds a JobRunRepository over the job_run table of a SQLite database.
+func NewSqliteJobRunRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig) JobRunRepository {
+	return &sqliteJobRunRepository{
+		dataBase: newTracedDB(dbProvider, dbSystemSqlite),
+		timeouts: newOperationTimeouts(timeouts),
+	}
+}
+
+func (r *sqliteJobRunRepository) Create(ctx context.Context, run *entity.JobRun) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	if run.ID == "" {
+		run.ID = values.NewGUID().String()
+	}
+
+	_, err := r.dataBase.ExecContext(ctx, sqliteCreateJobRunQuery,
+		run.ID, run.JobName, run.TriggeredBy, run.DryRun, string(run.Status), run.StartedAt.UTC(), nullableSlot(run.ScheduledFor))
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	return nil
+}
+
+func (r *sqliteJobRunRepository) Update(ctx context.Context, run *entity.JobRun) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	args, err := jobRunUpdateArgs(run)
+	if err != nil {
+		return notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+
+	result, err := r.dataBase.ExecContext(ctx, sqliteUpdateJobRunQuery, args...)
+	return singleRowResult(result, err)
+}
+
+func (r *sqliteJobRunRepository) FindByJob(ctx context.Context, jobName string, limit int) (*[]entity.JobRun, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	rows, err := r.dataBase.QueryContext(ctx, sqliteFindJobRunsQuery, jobName, limit)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer rows.Close()
+
+	var runs []entity.JobRun
+	for rows.Next() {
+		var id string
+		var row jobRunRow
+
+		if err := rows.Scan(append([]any{&id}, row.targets()...)...); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+
+		run, err := row.toEntity(id)
+		if err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+		runs = append(runs, run)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	}
+
+	safeRuns := converter.ListSafe(runs)
+	return &safeRuns, nil
+}
+
+func (r *sqliteJobRunRepository) ExistsForSlot(ctx context.Context, jobName string, slot time.Time) (bool, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	return countSlotRuns(ctx, r.dataBase, sqliteSlotRunQuery, jobName, slot)
+}
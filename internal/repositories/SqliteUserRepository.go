@@ -0,0 +1,227 @@
+package repositories
+
+import (
+	converter "PocGo/internal/configuration/converters"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	dbProvider "database/sql"
+	"errors"
+	configIO "fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	sqliteFindByIdQuery      = `SELECT id, COALESCE(name, ''), COALESCE(email, login), status, version FROM auth_user WHERE id = ? AND deleted_at IS NULL`
+	sqliteFindAllQuery       = `SELECT id, COALESCE(name, ''), COALESCE(email, login), status FROM auth_user WHERE creation_date > ? AND deleted_at IS NULL`
+	sqliteUpdateQuery        = `UPDATE auth_user SET name = ?, email = ?, login = ?, normalized_login = ?, status = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL`
+	sqliteUpdateStatusQuery  = `UPDATE auth_user SET status = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL`
+	sqliteCreateQuery        = `INSERT INTO auth_user (id, normalized_login, login, name, email, status, creation_date) VALUES (?, ?, ?, ?, ?, ?, datetime('now'))`
+	sqliteDeleteQuery        = `UPDATE auth_user SET deleted_at = datetime('now'), status = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL`
+	sqliteRestoreQuery       = `UPDATE auth_user SET deleted_at = NULL, status = ?, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL`
+	sqliteReactivateQuery    = `UPDATE auth_user SET status = ?, version = version + 1 WHERE id = ? AND status = ? AND deleted_at IS NULL`
+	sqliteExistsByEmailQuery = `SELECT COUNT(1) FROM auth_user WHERE normalized_login = ?`
+	sqliteExistsByLoginQuery = `SELECT COUNT(1) FROM auth_user WHERE normalized_login = ?`
+
+	sqliteSelectStatusChunkQuery = `SELECT id, status FROM auth_user WHERE creation_date < ? AND deleted_at IS NULL AND id > ? ORDER BY id LIMIT ?`
+	sqliteUpdateStatusChunkQuery = `UPDATE auth_user SET status = ?, version = version + 1 WHERE id >= ? AND id <= ? AND creation_date < ? AND deleted_at IS NULL AND status IN (%s) RETURNING id`
+)
+
+// sqliteDateLayout is the format of datetime('now'), used to compare against creation_date.
+const sqliteDateLayout = "2006-01-02 15:04:05"
+
+type sqliteUserRepository struct {
+	dataBase *tracedDB
+	timeouts operationTimeouts
+	logger   *slog.Logger
+}
+
+// NewSqliteUserRepository builds a UserRepository over the auth_user table of a SQLite database.
+func NewSqliteUserRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig, logger *slog.Logger) UserRepository {
+	return &sqliteUserRepository{
+		dataBase: newTracedDB(dbProvider, dbSystemSqlite),
+		timeouts: newOperationTimeouts(timeouts),
+		logger:   logger,
+	}
+}
+
+func (r *sqliteUserRepository) FindById(ctx context.Context, id values.GUID) (*entity.User, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	var user entity.User
+
+	err := r.dataBase.QueryRowContext(ctx, sqliteFindByIdQuery, id).Scan(
+		&user.ID,
+		&user.Name,
+		&user.Email,
+		&user.Status,
+		&user.Version,
+	)
+
+	if err != nil {
+		if errors.Is(err, dbProvider.ErrNoRows) {
+			return nil, notify.CreateSimpleNotification(notify.NotFound, err)
+		}
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	return &user, nil
+}
+
+func (r *sqliteUserRepository) FindAll(ctx context.Context, date string) (*[]entity.User, error) {
+	return r.queryUsers(ctx, sqliteFindAllQuery, date)
+}
+
+func (r *sqliteUserRepository) List(ctx context.Context, query UserListQuery) (*UserPage, error) {
+	return listUsers(ctx, r.dataBase, r.timeouts, sqliteListDialect, query)
+}
+
+var sqliteListDialect = listDialect{
+	table:   "auth_user",
+	columns: "id, COALESCE(name, ''), COALESCE(email, login), normalized_login, status, creation_date",
+	sortColumns: map[string]string{
+		SortByCreationDate: "creation_date",
+		SortByEmail:        "COALESCE(email, login)",
+		SortByLogin:        "normalized_login",
+	},
+	idColumn:    "id",
+	statusCol:   "status",
+	emailCol:    "login",
+	loginCol:    "normalized_login",
+	createdCol:  "creation_date",
+	deletedCol:  "deleted_at",
+	placeholder: func(int) string { return "?" },
+	limit: func(query string, placeholder string) string {
+		return query + " LIMIT " + placeholder
+	},
+	dateArg: func(t time.Time) any { return t.UTC().Format(sqliteDateLayout) },
+}
+
+func (r *sqliteUserRepository) Update(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, sqliteUpdateQuery,
+		user.Name, user.Email, user.Email, NormalizeLogin(user.Email), user.Status, user.ID, user.Version)
+	return versionedResult(user, result, err)
+}
+
+func (r *sqliteUserRepository) UpdateStatusBatch(ctx context.Context, batch StatusBatch) (BatchResult, error) {
+	return runStatusBatch(ctx, r.dataBase, r.timeouts, r.logger, batch, sqliteBatchQueries)
+}
+
+func (r *sqliteUserRepository) UpdateStatus(ctx context.Context, id values.GUID, status entity.UserStatus) error {
+	return r.exec(ctx, sqliteUpdateStatusQuery, status, id)
+}
+
+var sqliteBatchQueries = batchQueries{
+	// Text ids compare greater than the empty string, so the first chunk starts from "".
+	selectChunk: func(batch StatusBatch, after values.GUID) (string, []any) {
+		var lastId any = ""
+		if !after.IsZero() {
+			lastId = after
+		}
+		return sqliteSelectStatusChunkQuery, []any{batch.CreatedBefore.UTC().Format(sqliteDateLayout), lastId, batch.Size}
+	},
+	updateChunk: func(batch StatusBatch, first, last values.GUID) (string, []any) {
+		statuses, args := statusPlaceholders(batch.From, 0, func(int) string { return "?" },
+			[]any{batch.To, first, last, batch.CreatedBefore.UTC().Format(sqliteDateLayout)})
+		return configIO.Sprintf(sqliteUpdateStatusChunkQuery, statuses), args
+	},
+}
+
+func (r *sqliteUserRepository) Create(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	if user.ID.IsZero() {
+		user.ID = values.NewGUID()
+	}
+
+	_, err := r.dataBase.ExecContext(ctx, sqliteCreateQuery,
+		user.ID, NormalizeLogin(user.Email), user.Email, user.Name, user.Email, user.Status)
+	if err != nil {
+		return writeError(err)
+	}
+
+	user.Version = initialVersion
+	return nil
+}
+
+func (r *sqliteUserRepository) Delete(ctx context.Context, id values.GUID) error {
+	return r.exec(ctx, sqliteDeleteQuery, entity.StatusDeleted, id)
+}
+
+func (r *sqliteUserRepository) Restore(ctx context.Context, id values.GUID) error {
+	return r.exec(ctx, sqliteRestoreQuery, entity.StatusInactive, id)
+}
+
+func (r *sqliteUserRepository) Reactivate(ctx context.Context, id values.GUID) error {
+	return r.exec(ctx, sqliteReactivateQuery, entity.StatusActive, id, entity.StatusInactive)
+}
+
+func (r *sqliteUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.exists(ctx, sqliteExistsByEmailQuery, NormalizeEmail(email))
+}
+
+func (r *sqliteUserRepository) ExistsByLogin(ctx context.Context, normalizedLogin string) (bool, error) {
+	return r.exists(ctx, sqliteExistsByLoginQuery, normalizedLogin)
+}
+
+func (r *sqliteUserRepository) exists(ctx context.Context, query string, args ...any) (bool, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.dataBase.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *sqliteUserRepository) queryUsers(ctx context.Context, query string, args ...any) (*[]entity.User, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	rows, err := r.dataBase.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer rows.Close()
+
+	var users []entity.User
+	for rows.Next() {
+		var user entity.User
+
+		if err := rows.Scan(
+			&user.ID,
+			&user.Name,
+			&user.Email,
+			&user.Status,
+		); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	}
+
+	safeUsers := converter.ListSafe(users)
+	return &safeUsers, nil
+}
+
+func (r *sqliteUserRepository) exec(ctx context.Context, query string, args ...any) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, query, args...)
+	return singleRowResult(result, err)
+}
@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/logging"
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultBatchSize = 500
+	// MaxBatchSize keeps each chunk transaction short, so row locks are held briefly.
+	MaxBatchSize = 5000
+)
+
+// StatusBatch moves the users created before CreatedBefore whose status is in From to To.
+// Users are visited in ID order, Size per transaction, so a failed chunk is rolled back and reported
+// without stopping the next ones.
+type StatusBatch struct {
+	CreatedBefore time.Time
+	From          []entity.UserStatus
+	To            entity.UserStatus
+	Size          int
+	// DryRun classifies the users without writing anything.
+	DryRun bool
+	// Progress, when set, is called after each chunk with the totals so far.
+	Progress func(BatchResult)
+}
+
+// BatchResult reports what a StatusBatch did or, on a dry run, would do. Skipped holds the users
+// that matched the age filter but not From; Failed holds the users of chunks that were rolled back.
+type BatchResult struct {
+	Processed int
+	Updated   []string
+	Skipped   []string
+	Failed    []entity.ItemError
+}
+
+// batchUser is a user read at the start of a chunk.
+type batchUser struct {
+	id     values.GUID
+	status entity.UserStatus
+}
+
+// batchQueries builds the dialect-specific statements of a chunk. selectChunk reads up to
+// batch.Size users after the given id (zero on the first chunk), ordered by id, and
+// updateChunk changes the eligible ones between first and last, returning their ids.
+type batchQueries struct {
+	selectChunk func(batch StatusBatch, after values.GUID) (string, []any)
+	updateChunk func(batch StatusBatch, first, last values.GUID) (string, []any)
+}
+
+func normalizeBatch(batch StatusBatch) StatusBatch {
+	switch {
+	case batch.Size <= 0:
+		batch.Size = DefaultBatchSize
+	case batch.Size > MaxBatchSize:
+		batch.Size = MaxBatchSize
+	}
+	return batch
+}
+
+// runStatusBatch walks the users chunk by chunk with keyset pagination on id, each chunk in its own transaction.
+func runStatusBatch(ctx context.Context, db *tracedDB, timeouts operationTimeouts, logger *slog.Logger, batch StatusBatch, queries batchQueries) (BatchResult, error) {
+	batch = normalizeBatch(batch)
+
+	var result BatchResult
+	if len(batch.From) == 0 {
+		return result, nil
+	}
+
+	var after values.GUID
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		chunk, err := runStatusChunk(ctx, db, timeouts, logger, batch, queries, after, &result)
+		if err != nil {
+			return result, err
+		}
+		if len(chunk) == 0 {
+			return result, nil
+		}
+
+		if batch.Progress != nil {
+			batch.Progress(result)
+		}
+
+		if len(chunk) < batch.Size {
+			return result, nil
+		}
+		after = chunk[len(chunk)-1].id
+	}
+}
+
+// runStatusChunk processes one chunk, adding its users to result. Only a failure to read the chunk is returned;
+// a failed update is rolled back, logged and recorded in result.Failed.
+func runStatusChunk(ctx context.Context, db *tracedDB, timeouts operationTimeouts, logger *slog.Logger, batch StatusBatch, queries batchQueries, after values.GUID, result *BatchResult) ([]batchUser, error) {
+	ctx, cancel := timeouts.forWrite(ctx)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer tx.Rollback()
+
+	chunk, err := selectBatchUsers(ctx, tx, batch, queries, after)
+	if err != nil || len(chunk) == 0 {
+		return chunk, err
+	}
+
+	eligible, skipped := classifyBatchUsers(chunk, batch.From)
+	result.Processed += len(chunk)
+
+	if batch.DryRun || len(eligible) == 0 {
+		result.Updated = append(result.Updated, eligible...)
+		result.Skipped = append(result.Skipped, skipped...)
+		return chunk, nil
+	}
+
+	updated, err := updateBatchUsers(ctx, tx, batch, queries, chunk[0].id, chunk[len(chunk)-1].id)
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		logging.FromContext(ctx, logger).Warn("Lote de usuários revertido",
+			"first_id", chunk[0].id.String(), "users", len(eligible), logging.KeyError, err)
+		result.Skipped = append(result.Skipped, skipped...)
+		for _, id := range eligible {
+			result.Failed = append(result.Failed, entity.ItemError{ItemID: id, Message: err.Error()})
+		}
+		return chunk, nil
+	}
+
+	// Users that changed status between the read and the update are not returned by it and count as skipped.
+	updatedSet := make(map[values.GUID]bool, len(updated))
+	for _, id := range updated {
+		updatedSet[id] = true
+	}
+	for _, user := range chunk {
+		if updatedSet[user.id] {
+			result.Updated = append(result.Updated, user.id.String())
+		} else {
+			result.Skipped = append(result.Skipped, user.id.String())
+		}
+	}
+
+	return chunk, nil
+}
+
+func selectBatchUsers(ctx context.Context, tx *tracedTx, batch StatusBatch, queries batchQueries, after values.GUID) ([]batchUser, error) {
+	query, args := queries.selectChunk(batch, after)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer rows.Close()
+
+	var chunk []batchUser
+	for rows.Next() {
+		var user batchUser
+		if err := rows.Scan(&user.id, &user.status); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+		chunk = append(chunk, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	}
+	return chunk, nil
+}
+
+func updateBatchUsers(ctx context.Context, tx *tracedTx, batch StatusBatch, queries batchQueries, first, last values.GUID) ([]values.GUID, error) {
+	query, args := queries.updateChunk(batch, first, last)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+	defer rows.Close()
+
+	var updated []values.GUID
+	for rows.Next() {
+		var id values.GUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+		updated = append(updated, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, err)
+	}
+	return updated, nil
+}
+
+func classifyBatchUsers(chunk []batchUser, from []entity.UserStatus) (eligible []string, skipped []string) {
+	for _, user := range chunk {
+		if containsStatus(from, user.status) {
+			eligible = append(eligible, user.id.String())
+		} else {
+			skipped = append(skipped, user.id.String())
+		}
+	}
+	return eligible, skipped
+}
+
+func containsStatus(statuses []entity.UserStatus, status entity.UserStatus) bool {
+	for _, candidate := range statuses {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+// statusPlaceholders returns the placeholders of an IN list of the statuses, produced by placeholder
+// for parameter positions starting at first, and appends the statuses to args.
+func statusPlaceholders(statuses []entity.UserStatus, first int, placeholder func(position int) string, args []any) (string, []any) {
+	placeholders := make([]string, len(statuses))
+	for index, status := range statuses {
+		placeholders[index] = placeholder(first + index)
+		args = append(args, status)
+	}
+	return strings.Join(placeholders, ", "), args
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"PocGo/internal/tracing"
+	"context"
+	dbProvider "database/sql"
+)
+
+// db.system values of the SQL backends.
+const (
+	dbSystemSqlServer = "mssql"
+	dbSystemSqlite    = "sqlite"
+)
+
+// tracedDB runs each statement in a span of its own, see tracing.StartStatement. Spans of queries end once
+// the statement has run; reading the rows is left to the parent span.
+type tracedDB struct {
+	*dbProvider.DB
+	system string
+}
+
+func newTracedDB(db *dbProvider.DB, system string) *tracedDB {
+	return &tracedDB{DB: db, system: system}
+}
+
+func (db *tracedDB) ExecContext(ctx context.Context, query string, args ...any) (dbProvider.Result, error) {
+	ctx, span := tracing.StartStatement(ctx, db.system, query)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (db *tracedDB) QueryContext(ctx context.Context, query string, args ...any) (*dbProvider.Rows, error) {
+	ctx, span := tracing.StartStatement(ctx, db.system, query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
+
+func (db *tracedDB) QueryRowContext(ctx context.Context, query string, args ...any) *dbProvider.Row {
+	ctx, span := tracing.StartStatement(ctx, db.system, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	tracing.End(span, row.Err())
+	return row
+}
+
+func (db *tracedDB) BeginTx(ctx context.Context, options *dbProvider.TxOptions) (*tracedTx, error) {
+	tx, err := db.DB.BeginTx(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, system: db.system}, nil
+}
+
+// tracedTx is tracedDB within a transaction.
+type tracedTx struct {
+	*dbProvider.Tx
+	system string
+}
+
+func (tx *tracedTx) ExecContext(ctx context.Context, query string, args ...any) (dbProvider.Result, error) {
+	ctx, span := tracing.StartStatement(ctx, tx.system, query)
+	result, err := tx.Tx.ExecContext(ctx, query, args...)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (tx *tracedTx) QueryContext(ctx context.Context, query string, args ...any) (*dbProvider.Rows, error) {
+	ctx, span := tracing.StartStatement(ctx, tx.system, query)
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	tracing.End(span, err)
+	return rows, err
+}
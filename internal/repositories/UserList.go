@@ -0,0 +1,266 @@
+package repositories
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
+	"encoding/base64"
+	setJson "encoding/json"
+	configIO "fmt"
+	"strings"
+	"time"
+)
+
+// Fields accepted by UserListQuery.Sort. The user ID breaks ties, so every order is total.
+const (
+	SortByCreationDate = "creationDate"
+	SortByEmail        = "email"
+	SortByLogin        = "login"
+)
+
+// UserFilter narrows a listing; zero values do not filter. Prefixes are case-insensitive,
+// CreatedFrom is inclusive and CreatedTo exclusive.
+type UserFilter struct {
+	Statuses    []entity.UserStatus
+	EmailPrefix string
+	LoginPrefix string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// UserListQuery asks for one page of users. Cursor is the NextCursor of the previous page and
+// only continues a listing with the same Sort and Descending.
+type UserListQuery struct {
+	Filter     UserFilter
+	Sort       string
+	Descending bool
+	Limit      int
+	Cursor     string
+}
+
+// UserPage is a page of users; NextCursor is empty on the last page.
+type UserPage struct {
+	Items      []entity.User `json:"items"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+	HasMore    bool          `json:"hasMore"`
+}
+
+// userCursor is the position after the last user of a page, for the sort it was produced with.
+type userCursor struct {
+	Sort       string      `json:"s"`
+	Descending bool        `json:"d,omitempty"`
+	Value      string      `json:"v"`
+	ID         values.GUID `json:"id"`
+}
+
+// listedUser is a user together with the columns a cursor may need.
+type listedUser struct {
+	user            entity.User
+	normalizedLogin string
+	creationDate    time.Time
+}
+
+func (listed listedUser) sortValue(sort string) string {
+	switch sort {
+	case SortByEmail:
+		return listed.user.Email
+	case SortByLogin:
+		return listed.normalizedLogin
+	default:
+		return listed.creationDate.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+func isUserSortField(sort string) bool {
+	return sort == SortByCreationDate || sort == SortByEmail || sort == SortByLogin
+}
+
+// validateListQuery rejects unknown sort fields and cursors produced by another sort, returning the decoded cursor.
+func validateListQuery(query UserListQuery) (*userCursor, error) {
+	if !isUserSortField(query.Sort) {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, configIO.Errorf("campo de ordenação inválido: %q", query.Sort))
+	}
+	if query.Limit <= 0 {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, configIO.Errorf("limite inválido: %d", query.Limit))
+	}
+	if query.Cursor == "" {
+		return nil, nil
+	}
+
+	cursor, err := decodeUserCursor(query.Cursor)
+	if err != nil || cursor.Sort != query.Sort || cursor.Descending != query.Descending {
+		return nil, notify.CreateSimpleNotification(notify.InvalidData, configIO.Errorf("cursor inválido para esta ordenação"))
+	}
+	if query.Sort == SortByCreationDate {
+		if _, err := time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.InvalidData, configIO.Errorf("cursor inválido para esta ordenação"))
+		}
+	}
+	return cursor, nil
+}
+
+// buildUserPage trims the extra row fetched to detect a next page and encodes the cursor after the last item.
+func buildUserPage(query UserListQuery, listed []listedUser) *UserPage {
+	page := &UserPage{Items: make([]entity.User, 0, min(len(listed), query.Limit))}
+
+	if len(listed) > query.Limit {
+		listed = listed[:query.Limit]
+		page.HasMore = true
+	}
+	for _, user := range listed {
+		page.Items = append(page.Items, user.user)
+	}
+
+	if page.HasMore {
+		last := listed[len(listed)-1]
+		page.NextCursor = encodeUserCursor(userCursor{
+			Sort:       query.Sort,
+			Descending: query.Descending,
+			Value:      last.sortValue(query.Sort),
+			ID:         last.user.ID,
+		})
+	}
+	return page
+}
+
+func encodeUserCursor(cursor userCursor) string {
+	data, _ := setJson.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(value string) (*userCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor userCursor
+	if err := setJson.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// escapeLike escapes the LIKE wildcards of a prefix, for use with ESCAPE '\'.
+func escapeLike(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `[`, `\[`).Replace(prefix)
+}
+
+// listDialect holds what differs between the SQL backends when building a listing query.
+type listDialect struct {
+	table   string
+	columns string
+	// sortColumns must select the same expression the cursor value is read from, or pages skip and repeat rows.
+	sortColumns map[string]string
+	idColumn    string
+	statusCol   string
+	// emailCol is the login column, which always holds the email and, unlike email, is never NULL.
+	emailCol    string
+	loginCol    string
+	createdCol  string
+	deletedCol  string
+	placeholder func(position int) string
+	// limit wraps the query with the row limit, passed as its last parameter.
+	limit   func(query string, placeholder string) string
+	dateArg func(t time.Time) any
+}
+
+// buildListQuery builds a keyset query fetching query.Limit+1 rows after cursor.
+func (dialect listDialect) buildListQuery(query UserListQuery, cursor *userCursor) (string, []any) {
+	var conditions []string
+	var args []any
+
+	next := func(value any) string {
+		args = append(args, value)
+		return dialect.placeholder(len(args))
+	}
+
+	conditions = append(conditions, dialect.deletedCol+" IS NULL")
+
+	filter := query.Filter
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for index, status := range filter.Statuses {
+			placeholders[index] = next(status)
+		}
+		conditions = append(conditions, configIO.Sprintf("%s IN (%s)", dialect.statusCol, strings.Join(placeholders, ", ")))
+	}
+	if filter.EmailPrefix != "" {
+		conditions = append(conditions, configIO.Sprintf(`%s LIKE %s ESCAPE '\'`, dialect.emailCol, next(escapeLike(filter.EmailPrefix)+"%")))
+	}
+	if filter.LoginPrefix != "" {
+		conditions = append(conditions, configIO.Sprintf(`%s LIKE %s ESCAPE '\'`, dialect.loginCol, next(escapeLike(NormalizeLogin(filter.LoginPrefix))+"%")))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, configIO.Sprintf("%s >= %s", dialect.createdCol, next(dialect.dateArg(filter.CreatedFrom))))
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, configIO.Sprintf("%s < %s", dialect.createdCol, next(dialect.dateArg(filter.CreatedTo))))
+	}
+
+	sortColumn := dialect.sortColumns[query.Sort]
+	comparison, direction := ">", "ASC"
+	if query.Descending {
+		comparison, direction = "<", "DESC"
+	}
+
+	if cursor != nil {
+		var value any = cursor.Value
+		if query.Sort == SortByCreationDate {
+			parsed, _ := time.Parse(time.RFC3339Nano, cursor.Value)
+			value = dialect.dateArg(parsed)
+		}
+		first, second, id := next(value), next(value), next(cursor.ID)
+		conditions = append(conditions, configIO.Sprintf("(%s %s %s OR (%s = %s AND %s %s %s))",
+			sortColumn, comparison, first, sortColumn, second, dialect.idColumn, comparison, id))
+	}
+
+	statement := configIO.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s %s, %s %s",
+		dialect.columns, dialect.table, strings.Join(conditions, " AND "), sortColumn, direction, dialect.idColumn, direction)
+
+	return dialect.limit(statement, next(query.Limit+1)), args
+}
+
+// listUsers runs a listing on a SQL backend. The selected columns must be id, name, email, normalized_login,
+// status and creation_date.
+func listUsers(ctx context.Context, db *tracedDB, timeouts operationTimeouts, dialect listDialect, query UserListQuery) (*UserPage, error) {
+	cursor, err := validateListQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := timeouts.forRead(ctx)
+	defer cancel()
+
+	statement, args := dialect.buildListQuery(query, cursor)
+	rows, err := db.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+	}
+	defer rows.Close()
+
+	var listed []listedUser
+	for rows.Next() {
+		var user listedUser
+
+		if err := rows.Scan(
+			&user.user.ID,
+			&user.user.Name,
+			&user.user.Email,
+			&user.normalizedLogin,
+			&user.user.Status,
+			&user.creationDate,
+		); err != nil {
+			return nil, notify.CreateSimpleNotification(notify.ScanErrorRepository, err)
+		}
+
+		listed = append(listed, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	}
+
+	return buildUserPage(query, listed), nil
+}
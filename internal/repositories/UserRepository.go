@@ -2,62 +2,107 @@ package repositories
 
 import (
 	converter "PocGo/internal/configuration/converters"
+	provider "PocGo/internal/configuration/providers"
 	entity "PocGo/internal/domain/entities"
 	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"context"
 	dbProvider "database/sql"
 	"errors"
+	configIO "fmt"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 const (
-	findByIdQuery     = `SELECT [id], [normalized_login], [login], [status] FROM [Auth].[User] WHERE id = @p1`
-	findAllQuery      = `SELECT [id], [normalized_login], [login], [status] FROM [Auth].[User] WHERE creation_date > @p1`
-	updateQuery       = `UPDATE [Auth].[User] SET name = @p1, email = @p2, status = @p3 WHERE id = @p4`
-	findOldUsersQuery = `SELECT [id], [normalized_login], [login], [status] FROM [Auth].[User] WHERE creation_date < DATEADD(month, -5, GETDATE())`
-	updateStatusQuery = `UPDATE [Auth].[User] SET status = @p1 WHERE id = @p2`
+	// Rows created by other applications may lack name and email; the login is the email there too.
+	findByIdQuery      = `SELECT [id], COALESCE([name], N''), COALESCE([email], [login]), [status], [version] FROM [Auth].[User] WHERE id = @p1 AND [deleted_at] IS NULL`
+	findAllQuery       = `SELECT [id], COALESCE([name], N''), COALESCE([email], [login]), [status] FROM [Auth].[User] WHERE creation_date > @p1 AND [deleted_at] IS NULL`
+	updateQuery        = `UPDATE [Auth].[User] SET name = @p1, email = @p2, [login] = @p2, [normalized_login] = @p6, status = @p3, [version] = [version] + 1 WHERE id = @p4 AND [version] = @p5 AND [deleted_at] IS NULL`
+	updateStatusQuery  = `UPDATE [Auth].[User] SET status = @p1, [version] = [version] + 1 WHERE id = @p2 AND [deleted_at] IS NULL`
+	createQuery        = `INSERT INTO [Auth].[User] ([id], [normalized_login], [login], [name], [email], [status]) VALUES (@p1, @p2, @p3, @p4, @p5, @p6)`
+	deleteQuery        = `UPDATE [Auth].[User] SET [deleted_at] = SYSUTCDATETIME(), [status] = @p2, [version] = [version] + 1 WHERE id = @p1 AND [deleted_at] IS NULL`
+	restoreQuery       = `UPDATE [Auth].[User] SET [deleted_at] = NULL, [status] = @p2, [version] = [version] + 1 WHERE id = @p1 AND [deleted_at] IS NOT NULL`
+	reactivateQuery    = `UPDATE [Auth].[User] SET status = @p1, [version] = [version] + 1 WHERE id = @p2 AND status = @p3 AND [deleted_at] IS NULL`
+	existsByEmailQuery = `SELECT COUNT(1) FROM [Auth].[User] WHERE [normalized_login] = @p1`
+	existsByLoginQuery = `SELECT COUNT(1) FROM [Auth].[User] WHERE [normalized_login] = @p1`
+
+	// The first chunk passes NULL as the last id seen.
+	selectStatusChunkQuery = `SELECT TOP (@p1) [id], [status] FROM [Auth].[User] WHERE [creation_date] < @p2 AND [deleted_at] IS NULL AND (@p3 IS NULL OR [id] > @p3) ORDER BY [id]`
+	updateStatusChunkQuery = `UPDATE [Auth].[User] SET [status] = @p1, [version] = [version] + 1 OUTPUT inserted.[id] WHERE [id] >= @p2 AND [id] <= @p3 AND [creation_date] < @p4 AND [deleted_at] IS NULL AND [status] IN (%s)`
 )
 
+// UserRepository reads and writes users. Soft-deleted users are invisible to every method
+// except Restore and the Exists* checks, which keep their email and login reserved.
+// The login is the email: every write keeps login and normalized_login in sync with it, and a
+// write that would repeat another user's normalized login is a CONFLICT.
+// Delete moves the user to StatusDeleted and Restore brings it back as StatusInactive.
 type UserRepository interface {
-	Update(user *entity.User) error
-	FindById(id string) (*entity.User, error)
-	FindAll(date string) (*[]entity.User, error)
-	FindOldUsers() (*[]entity.User, error)
-	UpdateStatus(id string, status int) error
+	// Update writes user only while its stored version is still user.Version, then increments both.
+	// Otherwise, including when the user was removed meanwhile, it returns PRECONDITION_FAILED.
+	Update(ctx context.Context, user *entity.User) error
+	FindById(ctx context.Context, id values.GUID) (*entity.User, error)
+	FindAll(ctx context.Context, date string) (*[]entity.User, error)
+	// List returns one page of users with keyset pagination; invalid sorts and cursors are INVALID_DATA.
+	List(ctx context.Context, query UserListQuery) (*UserPage, error)
+	UpdateStatus(ctx context.Context, id values.GUID, status entity.UserStatus) error
+	// UpdateStatusBatch applies batch chunk by chunk; the result is returned even when an error stops it.
+	UpdateStatusBatch(ctx context.Context, batch StatusBatch) (BatchResult, error)
+	Create(ctx context.Context, user *entity.User) error
+	Delete(ctx context.Context, id values.GUID) error
+	Restore(ctx context.Context, id values.GUID) error
+	Reactivate(ctx context.Context, id values.GUID) error
+	// ExistsByEmail reports whether any user, removed ones included, has email, ignoring case as every backend
+	// does: by the normalized email kept in normalized_login.
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	ExistsByLogin(ctx context.Context, normalizedLogin string) (bool, error)
 }
 
 type userRepository struct {
-	dataBase *dbProvider.DB
+	dataBase *tracedDB
+	timeouts operationTimeouts
+	logger   *slog.Logger
 }
 
-func NewUserRepository(dbProvider *dbProvider.DB) UserRepository {
+func NewUserRepository(dbProvider *dbProvider.DB, timeouts *provider.TimeoutConfig, logger *slog.Logger) UserRepository {
 	return &userRepository{
-		dataBase: dbProvider,
+		dataBase: newTracedDB(dbProvider, dbSystemSqlServer),
+		timeouts: newOperationTimeouts(timeouts),
+		logger:   logger,
 	}
 }
 
 type userTemp struct {
-	ID     []byte
-	Name   string
-	Email  string
-	Status int
+	ID      values.GUID
+	Name    string
+	Email   string
+	Status  entity.UserStatus
+	Version int64
 }
 
 func mapToUser(temp userTemp) entity.User {
 	return entity.User{
-		ID:     converter.BytesToString(temp.ID),
-		Name:   temp.Name,
-		Email:  temp.Email,
-		Status: temp.Status,
+		ID:      temp.ID,
+		Name:    temp.Name,
+		Email:   temp.Email,
+		Status:  temp.Status,
+		Version: temp.Version,
 	}
 }
 
-func (r *userRepository) FindById(id string) (*entity.User, error) {
+func (r *userRepository) FindById(ctx context.Context, id values.GUID) (*entity.User, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
 	var temp userTemp
 
-	err := r.dataBase.QueryRow(findByIdQuery, id).Scan(
+	err := r.dataBase.QueryRowContext(ctx, findByIdQuery, id).Scan(
 		&temp.ID,
 		&temp.Name,
 		&temp.Email,
 		&temp.Status,
+		&temp.Version,
 	)
 
 	if err != nil {
@@ -71,8 +116,11 @@ func (r *userRepository) FindById(id string) (*entity.User, error) {
 	return &user, nil
 }
 
-func (r *userRepository) FindAll(date string) (*[]entity.User, error) {
-	rows, err := r.dataBase.Query(findAllQuery, date)
+func (r *userRepository) FindAll(ctx context.Context, date string) (*[]entity.User, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	rows, err := r.dataBase.QueryContext(ctx, findAllQuery, date)
 	if err != nil {
 		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
 	}
@@ -91,8 +139,46 @@ func (r *userRepository) FindAll(date string) (*[]entity.User, error) {
 	return &safeUsers, nil
 }
 
-func (r *userRepository) Update(user *entity.User) error {
-	result, err := r.dataBase.Exec(updateQuery, user.Name, user.Email, user.Status, user.ID)
+func (r *userRepository) List(ctx context.Context, query UserListQuery) (*UserPage, error) {
+	return listUsers(ctx, r.dataBase, r.timeouts, sqlServerListDialect, query)
+}
+
+var sqlServerListDialect = listDialect{
+	table:   "[Auth].[User]",
+	columns: "[id], COALESCE([name], N''), COALESCE([email], [login]), [normalized_login], [status], [creation_date]",
+	sortColumns: map[string]string{
+		SortByCreationDate: "[creation_date]",
+		SortByEmail:        "COALESCE([email], [login])",
+		SortByLogin:        "[normalized_login]",
+	},
+	idColumn:    "[id]",
+	statusCol:   "[status]",
+	emailCol:    "[login]",
+	loginCol:    "[normalized_login]",
+	createdCol:  "[creation_date]",
+	deletedCol:  "[deleted_at]",
+	placeholder: func(position int) string { return configIO.Sprintf("@p%d", position) },
+	limit: func(query string, placeholder string) string {
+		return strings.Replace(query, "SELECT ", "SELECT TOP ("+placeholder+") ", 1)
+	},
+	// creation_date holds UTC (SYSUTCDATETIME), so filters and cursors are compared in UTC.
+	dateArg: func(t time.Time) any { return t.UTC() },
+}
+
+func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, updateQuery,
+		user.Name, user.Email, user.Status, user.ID, user.Version, NormalizeLogin(user.Email))
+	return versionedResult(user, result, err)
+}
+
+func (r *userRepository) UpdateStatus(ctx context.Context, id values.GUID, status entity.UserStatus) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, updateStatusQuery, status, id)
 	if err != nil {
 		return notify.CreateSimpleNotification(notify.InvalidData, err)
 	}
@@ -110,45 +196,132 @@ func (r *userRepository) Update(user *entity.User) error {
 	return nil
 }
 
-func (r *userRepository) FindOldUsers() (*[]entity.User, error) {
-	rows, err := r.dataBase.Query(findOldUsersQuery)
-	if err != nil {
-		return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
+func (r *userRepository) UpdateStatusBatch(ctx context.Context, batch StatusBatch) (BatchResult, error) {
+	return runStatusBatch(ctx, r.dataBase, r.timeouts, r.logger, batch, sqlServerBatchQueries)
+}
+
+var sqlServerBatchQueries = batchQueries{
+	selectChunk: func(batch StatusBatch, after values.GUID) (string, []any) {
+		var lastId any
+		if !after.IsZero() {
+			lastId = after
+		}
+		return selectStatusChunkQuery, []any{batch.Size, batch.CreatedBefore, lastId}
+	},
+	updateChunk: func(batch StatusBatch, first, last values.GUID) (string, []any) {
+		statuses, args := statusPlaceholders(batch.From, 5, func(position int) string {
+			return configIO.Sprintf("@p%d", position)
+		}, []any{batch.To, first, last, batch.CreatedBefore})
+		return configIO.Sprintf(updateStatusChunkQuery, statuses), args
+	},
+}
+
+// Create inserts user, generating its ID when empty. The login is the email, as in ASP.NET Identity.
+func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	if user.ID.IsZero() {
+		user.ID = values.NewGUID()
 	}
-	defer rows.Close()
 
-	users, err := scanUsers(rows)
+	_, err := r.dataBase.ExecContext(ctx, createQuery,
+		user.ID, NormalizeLogin(user.Email), user.Email, user.Name, user.Email, user.Status)
 	if err != nil {
-		return nil, err
+		return writeError(err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, notify.CreateSimpleNotification(notify.FindAllErrorRepository, err)
+	user.Version = initialVersion
+	return nil
+}
+
+func (r *userRepository) Delete(ctx context.Context, id values.GUID) error {
+	return r.execSingleRow(ctx, deleteQuery, id, entity.StatusDeleted)
+}
+
+func (r *userRepository) Restore(ctx context.Context, id values.GUID) error {
+	return r.execSingleRow(ctx, restoreQuery, id, entity.StatusInactive)
+}
+
+func (r *userRepository) Reactivate(ctx context.Context, id values.GUID) error {
+	return r.execSingleRow(ctx, reactivateQuery, entity.StatusActive, id, entity.StatusInactive)
+}
+
+func (r *userRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.exists(ctx, existsByEmailQuery, NormalizeEmail(email))
+}
+
+func (r *userRepository) ExistsByLogin(ctx context.Context, normalizedLogin string) (bool, error) {
+	return r.exists(ctx, existsByLoginQuery, normalizedLogin)
+}
+
+func (r *userRepository) exists(ctx context.Context, query string, args ...any) (bool, error) {
+	ctx, cancel := r.timeouts.forRead(ctx)
+	defer cancel()
+
+	var count int
+	if err := r.dataBase.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, notify.CreateSimpleNotification(notify.FindErrorRepository, err)
 	}
 
-	safeUsers := converter.ListSafe(users)
-	return &safeUsers, nil
+	return count > 0, nil
 }
 
-func (r *userRepository) UpdateStatus(id string, status int) error {
-	result, err := r.dataBase.Exec(updateStatusQuery, status, id)
+// execSingleRow runs a write that must touch a row, reporting NOT_FOUND otherwise.
+func (r *userRepository) execSingleRow(ctx context.Context, query string, args ...any) error {
+	ctx, cancel := r.timeouts.forWrite(ctx)
+	defer cancel()
+
+	result, err := r.dataBase.ExecContext(ctx, query, args...)
+	return singleRowResult(result, err)
+}
+
+// initialVersion is the version of a newly created user, the default of the version column.
+const initialVersion = 1
+
+// versionedResult finishes a conditional update of user: no affected row means the version no longer matched.
+func versionedResult(user *entity.User, result dbProvider.Result, err error) error {
 	if err != nil {
-		return notify.CreateSimpleNotification(notify.InvalidData, err)
+		return writeError(err)
 	}
 
-	// Check if any rows were affected
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return notify.CreateSimpleNotification(notify.InvalidData, err)
 	}
 
 	if rowsAffected == 0 {
-		return notify.CreateSimpleNotification(notify.NotFound, nil)
+		return notify.CreateCustomNotification(notify.PreconditionFailed, "", configIO.Sprintf("versão %d", user.Version))
 	}
 
+	user.Version++
 	return nil
 }
 
+// writeError classifies a failed insert or update, reporting unique index violations as CONFLICT.
+func writeError(err error) error {
+	if isUniqueViolation(err) {
+		return notify.CreateSimpleNotification(notify.Conflict, err)
+	}
+	return notify.CreateSimpleNotification(notify.InvalidData, err)
+}
+
+// isUniqueViolation recognizes the duplicate key errors of both SQL drivers without importing them:
+// SQL Server errors 2601 and 2627, and SQLite's SQLITE_CONSTRAINT_UNIQUE (2067).
+func isUniqueViolation(err error) bool {
+	var sqlServerError interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &sqlServerError) {
+		number := sqlServerError.SQLErrorNumber()
+		return number == 2601 || number == 2627
+	}
+
+	var sqliteError interface{ Code() int }
+	if errors.As(err, &sqliteError) {
+		return sqliteError.Code() == 2067
+	}
+	return false
+}
+
 func scanUsers(rows *dbProvider.Rows) ([]entity.User, error) {
 	var users []entity.User
 
@@ -0,0 +1,263 @@
+package scheduler
+
+import (
+	configIO "fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchYears bounds the search for the next activation, so impossible expressions
+// such as "0 0 30 2 *" end instead of looping forever.
+const searchYears = 5
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var weekdayNames = map[string]int{
+	"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField  = cronField{name: "segundo", min: 0, max: 59}
+	minuteField  = cronField{name: "minuto", min: 0, max: 59}
+	hourField    = cronField{name: "hora", min: 0, max: 23}
+	dayField     = cronField{name: "dia do mês", min: 1, max: 31}
+	monthField   = cronField{name: "mês", min: 1, max: 12, names: monthNames}
+	weekdayField = cronField{name: "dia da semana", min: 0, max: 7, names: weekdayNames}
+)
+
+// bitSet holds the allowed values of one field; every field fits in 64 bits.
+type bitSet uint64
+
+func (set bitSet) has(value int) bool {
+	return set&(1<<uint(value)) != 0
+}
+
+// CronSchedule is a parsed cron expression evaluated in a fixed time zone.
+type CronSchedule struct {
+	expression string
+	location   *time.Location
+
+	seconds, minutes, hours, days, months, weekdays bitSet
+
+	// Standard cron rule: when both day fields are restricted, a day matching either one is enough.
+	daysRestricted, weekdaysRestricted bool
+}
+
+// ParseCron parses a 5-field (minute hour day month weekday) or 6-field (second first)
+// cron expression, or one of the @yearly/@monthly/@weekly/@daily/@hourly macros.
+// Fields accept *, ?, lists, ranges, steps and, for month and weekday, three-letter names.
+// A nil location means UTC.
+func ParseCron(expression string, location *time.Location) (*CronSchedule, error) {
+	if location == nil {
+		location = time.UTC
+	}
+
+	normalized := strings.TrimSpace(expression)
+	if macro, exists := cronMacros[strings.ToLower(normalized)]; exists {
+		normalized = macro
+	}
+
+	fields := strings.Fields(normalized)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, configIO.Errorf("expressão cron %q deve ter 5 ou 6 campos", expression)
+	}
+
+	schedule := &CronSchedule{expression: expression, location: location}
+
+	specs := []struct {
+		target *bitSet
+		field  cronField
+	}{
+		{&schedule.seconds, secondField},
+		{&schedule.minutes, minuteField},
+		{&schedule.hours, hourField},
+		{&schedule.days, dayField},
+		{&schedule.months, monthField},
+		{&schedule.weekdays, weekdayField},
+	}
+
+	for index, spec := range specs {
+		set, err := parseField(fields[index], spec.field)
+		if err != nil {
+			return nil, configIO.Errorf("expressão cron %q: %w", expression, err)
+		}
+		*spec.target = set
+	}
+
+	// 7 is an alias for Sunday.
+	if schedule.weekdays.has(7) {
+		schedule.weekdays |= 1
+	}
+
+	schedule.daysRestricted = !isWildcard(fields[3])
+	schedule.weekdaysRestricted = !isWildcard(fields[5])
+	return schedule, nil
+}
+
+// Next returns the first activation strictly after after, or the zero time when there is none
+// in the next few years. Local times skipped by a daylight saving change are not run that day.
+func (schedule *CronSchedule) Next(after time.Time) time.Time {
+	loc := schedule.location
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + searchYears
+
+	for t.Year() <= limit {
+		switch {
+		case !schedule.months.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !schedule.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !schedule.hours.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !schedule.minutes.has(t.Minute()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		case !schedule.seconds.has(t.Second()):
+			t = t.Add(time.Second)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// prevWindows are the spans searched backwards by Prev, growing so frequent schedules stay cheap.
+var prevWindows = []time.Duration{time.Minute, time.Hour, 24 * time.Hour, 32 * 24 * time.Hour, 367 * 24 * time.Hour}
+
+// Prev returns the last activation at or before before, or the zero time when there is none in the
+// previous year.
+func (schedule *CronSchedule) Prev(before time.Time) time.Time {
+	for _, window := range prevWindows {
+		last := schedule.Next(before.Add(-window))
+		if last.IsZero() || last.After(before) {
+			continue
+		}
+		for next := schedule.Next(last); !next.IsZero() && !next.After(before); next = schedule.Next(next) {
+			last = next
+		}
+		return last
+	}
+	return time.Time{}
+}
+
+func (schedule *CronSchedule) String() string {
+	return schedule.expression
+}
+
+// Location returns the time zone the expression is evaluated in.
+func (schedule *CronSchedule) Location() *time.Location {
+	return schedule.location
+}
+
+func (schedule *CronSchedule) dayMatches(t time.Time) bool {
+	dayOk := schedule.days.has(t.Day())
+	weekdayOk := schedule.weekdays.has(int(t.Weekday()))
+
+	if schedule.daysRestricted && schedule.weekdaysRestricted {
+		return dayOk || weekdayOk
+	}
+	return dayOk && weekdayOk
+}
+
+func isWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+func parseField(value string, field cronField) (bitSet, error) {
+	var set bitSet
+
+	for _, part := range strings.Split(value, ",") {
+		partSet, err := parsePart(part, field)
+		if err != nil {
+			return 0, err
+		}
+		set |= partSet
+	}
+
+	return set, nil
+}
+
+// parsePart parses one list item: "*", "N", "N-M", each optionally followed by "/step".
+func parsePart(part string, field cronField) (bitSet, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		parsed, err := strconv.Atoi(stepPart)
+		if err != nil || parsed <= 0 {
+			return 0, configIO.Errorf("passo inválido %q no campo %s", stepPart, field.name)
+		}
+		step = parsed
+	}
+
+	var start, end int
+	switch {
+	case isWildcard(rangePart):
+		start, end = field.min, field.max
+	case strings.Contains(rangePart, "-"):
+		low, high, _ := strings.Cut(rangePart, "-")
+		var err error
+		if start, err = parseValue(low, field); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(high, field); err != nil {
+			return 0, err
+		}
+	default:
+		value, err := parseValue(rangePart, field)
+		if err != nil {
+			return 0, err
+		}
+		start, end = value, value
+		// "N/step" means from N to the end of the field.
+		if hasStep {
+			end = field.max
+		}
+	}
+
+	if start > end {
+		return 0, configIO.Errorf("intervalo inválido %q no campo %s", rangePart, field.name)
+	}
+
+	var set bitSet
+	for value := start; value <= end; value += step {
+		set |= 1 << uint(value)
+	}
+	return set, nil
+}
+
+func parseValue(value string, field cronField) (int, error) {
+	if named, exists := field.names[strings.ToUpper(value)]; exists {
+		return named, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < field.min || parsed > field.max {
+		return 0, configIO.Errorf("valor inválido %q no campo %s (%d-%d)", value, field.name, field.min, field.max)
+	}
+	return parsed, nil
+}
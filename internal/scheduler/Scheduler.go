@@ -0,0 +1,588 @@
+package scheduler
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/lock"
+	"PocGo/internal/logging"
+	"PocGo/internal/tracing"
+	"context"
+	configIO "fmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// JobFunc is the work of a job. ctx is cancelled when the job timeout expires or the scheduler
+// gives up waiting on Stop, so long runs must check it. The job reports its counts and item
+// errors on run and must not change anything when run.DryRun is set.
+type JobFunc func(ctx context.Context, run *entity.JobRun) error
+
+// RunRecorder persists the runs; repositories.JobRunRepository satisfies it.
+type RunRecorder interface {
+	Create(ctx context.Context, run *entity.JobRun) error
+	Update(ctx context.Context, run *entity.JobRun) error
+	// ExistsForSlot reports whether a run of the job was recorded for the schedule slot.
+	ExistsForSlot(ctx context.Context, jobName string, slot time.Time) (bool, error)
+}
+
+// Job describes a recurring job.
+type Job struct {
+	Name string
+	// Schedule is a cron expression, see ParseCron.
+	Schedule string
+	// TimeZone is an IANA name (ex: "America/Sao_Paulo"); empty uses the scheduler location.
+	TimeZone string
+	// Jitter delays each run by a random duration in [0, Jitter), spreading instances that share a schedule.
+	Jitter time.Duration
+	// Timeout bounds each run; zero means no limit.
+	Timeout time.Duration
+	// RunOnStart also runs the latest slot of the schedule as soon as the scheduler starts, unless a
+	// replica already ran it.
+	RunOnStart bool
+	Run        JobFunc
+}
+
+// RunObserver is told about finished runs and lock skips; metrics.Metrics satisfies it.
+type RunObserver interface {
+	RunFinished(run entity.JobRun)
+	LockSkipped(job string)
+}
+
+// Options configures a Scheduler. Every field is optional.
+type Options struct {
+	// Location is used by jobs without TimeZone; time.Local when nil.
+	Location *time.Location
+	// Recorder persists the runs; when nil they are only logged.
+	Recorder RunRecorder
+	// Locker makes a run take the lease "job:<name>" first, so only one replica runs each job at a time.
+	// Together with Recorder, whose history tells which slots already ran, each scheduled slot runs once
+	// across the replicas, whatever their jitter or clock skew.
+	Locker lock.Locker
+	// LockTTL is the lease duration, renewed while the run lasts; lock.DefaultTTL when zero.
+	LockTTL time.Duration
+	// Logger receives the scheduler events; slog.Default when nil. Each run gets a child carrying
+	// the job name and run ID, stored in the context passed to JobFunc (see logging.FromContext).
+	Logger *slog.Logger
+	// Observer, when set, is called after each run and each lock skip.
+	Observer RunObserver
+	// HeartbeatInterval is how often a started scheduler refreshes Heartbeat; defaultHeartbeatInterval when zero.
+	HeartbeatInterval time.Duration
+}
+
+const defaultHeartbeatInterval = 10 * time.Second
+
+// JobInfo is a snapshot of a registered job.
+type JobInfo struct {
+	Name      string
+	Schedule  string
+	TimeZone  string
+	Running   bool
+	NextRun   time.Time
+	LastRun   time.Time
+	LastError string
+	// LockSkips counts the runs skipped because another replica held the job lease.
+	LockSkips int64
+}
+
+type scheduledJob struct {
+	job       Job
+	schedule  *CronSchedule
+	running   atomic.Bool
+	lockSkips atomic.Int64
+
+	mu      sync.Mutex
+	nextRun time.Time
+	lastRun time.Time
+	lastErr error
+}
+
+// Scheduler runs registered jobs on their cron schedules. A run is skipped while the
+// previous run of the same job is still in progress.
+type Scheduler struct {
+	mu       sync.Mutex
+	location *time.Location
+	recorder RunRecorder
+	locker   lock.Locker
+	lockTTL  time.Duration
+	logger   *slog.Logger
+	observer RunObserver
+	jobs     map[string]*scheduledJob
+	order    []string
+
+	heartbeatInterval time.Duration
+	// heartbeat holds the Unix nanoseconds of the last beat, 0 while stopped.
+	heartbeat atomic.Int64
+
+	started    bool
+	loopsCtx   context.Context
+	stopLoops  context.CancelFunc
+	runsCtx    context.Context
+	cancelRuns context.CancelFunc
+	loops      sync.WaitGroup
+	runs       sync.WaitGroup
+}
+
+// New creates a scheduler; see Options for the defaults.
+func New(options Options) *Scheduler {
+	location := options.Location
+	if location == nil {
+		location = time.Local
+	}
+	heartbeatInterval := options.HeartbeatInterval
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultHeartbeatInterval
+	}
+
+	return &Scheduler{
+		location: location,
+		recorder: options.Recorder,
+		locker:   options.Locker,
+		lockTTL:  options.LockTTL,
+		logger:   logging.OrDefault(options.Logger),
+		observer: options.Observer,
+		jobs:     make(map[string]*scheduledJob),
+
+		heartbeatInterval: heartbeatInterval,
+	}
+}
+
+// Register validates and adds job. Jobs registered after Start are scheduled immediately.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return notify.CreateCustomNotification(notify.ErrorJobInvalid, "", "nome e função são obrigatórios")
+	}
+
+	location := s.location
+	if job.TimeZone != "" {
+		loaded, err := time.LoadLocation(job.TimeZone)
+		if err != nil {
+			return notify.CreateCustomNotification(notify.ErrorJobInvalid, "", configIO.Sprintf("%s: %v", job.Name, err))
+		}
+		location = loaded
+	}
+
+	schedule, err := ParseCron(job.Schedule, location)
+	if err != nil {
+		return notify.CreateCustomNotification(notify.ErrorJobInvalid, "", configIO.Sprintf("%s: %v", job.Name, err))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.jobs[job.Name]; exists {
+		return notify.CreateCustomNotification(notify.ErrorJobDuplicate, "", job.Name)
+	}
+
+	scheduled := &scheduledJob{job: job, schedule: schedule}
+	s.jobs[job.Name] = scheduled
+	s.order = append(s.order, job.Name)
+
+	if s.started {
+		s.startLoop(scheduled)
+	}
+	return nil
+}
+
+// Start schedules every registered job. Cancelling ctx stops scheduling new runs,
+// but runs in progress are only cancelled by Stop.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return
+	}
+
+	s.started = true
+	s.loopsCtx, s.stopLoops = context.WithCancel(ctx)
+	s.runsCtx, s.cancelRuns = context.WithCancel(context.WithoutCancel(ctx))
+
+	s.heartbeat.Store(time.Now().UnixNano())
+	s.loops.Add(1)
+	go s.heartbeatLoop(s.loopsCtx)
+
+	for _, name := range s.order {
+		s.startLoop(s.jobs[name])
+	}
+}
+
+// Heartbeat returns when the scheduler loops last proved alive, or the zero time when the scheduler is not running.
+func (s *Scheduler) Heartbeat() time.Time {
+	beat := s.heartbeat.Load()
+	if beat == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, beat)
+}
+
+func (s *Scheduler) heartbeatLoop(ctx context.Context) {
+	defer s.loops.Done()
+	defer s.heartbeat.Store(0)
+
+	ticker := time.NewTicker(s.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.heartbeat.Store(time.Now().UnixNano())
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop stops scheduling new runs and waits for the runs in progress. When ctx ends first,
+// the runs are cancelled and Stop still waits for them to return, reporting ctx.Err().
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	s.started = false
+	stopLoops, cancelRuns := s.stopLoops, s.cancelRuns
+	s.mu.Unlock()
+
+	stopLoops()
+	s.loops.Wait()
+
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+
+	defer cancelRuns()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		cancelRuns()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// Jobs returns the registered jobs in registration order.
+func (s *Scheduler) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infos := make([]JobInfo, 0, len(s.order))
+	for _, name := range s.order {
+		infos = append(infos, s.jobs[name].info())
+	}
+	return infos
+}
+
+// startLoop must be called with s.mu held.
+func (s *Scheduler) startLoop(job *scheduledJob) {
+	s.loops.Add(1)
+	go s.loop(s.loopsCtx, job)
+}
+
+func (s *Scheduler) loop(ctx context.Context, job *scheduledJob) {
+	defer s.loops.Done()
+
+	if job.job.RunOnStart {
+		s.dispatchScheduled(job, job.schedule.Prev(time.Now()))
+	}
+
+	scheduled := time.Now()
+	for {
+		next := job.schedule.Next(scheduled)
+		if now := time.Now(); !next.IsZero() && next.Before(now) {
+			next = job.schedule.Next(now)
+		}
+		if next.IsZero() {
+			s.jobLogger(job).Warn("Agendador: Job sem próxima execução, removido do agendamento")
+			return
+		}
+
+		fireAt := next.Add(jitter(job.job.Jitter))
+		job.setNextRun(fireAt)
+		s.jobLogger(job).Info("Agendador: Job agendado",
+			"next_run", fireAt.Format(time.RFC3339), "wait", time.Until(fireAt).Round(time.Second))
+
+		timer := time.NewTimer(time.Until(fireAt))
+		select {
+		case <-timer.C:
+			s.dispatchScheduled(job, next)
+			scheduled = next
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// Trigger starts a run of the named job now, outside its schedule, and returns it as started.
+func (s *Scheduler) Trigger(name string, dryRun bool) (entity.JobRun, error) {
+	s.mu.Lock()
+	job, exists := s.jobs[name]
+	s.mu.Unlock()
+
+	if !exists {
+		return entity.JobRun{}, notify.CreateCustomNotification(notify.NotFound, "Job", name)
+	}
+
+	return s.dispatch(job, entity.TriggerManual, dryRun, time.Time{})
+}
+
+// dispatchScheduled runs the schedule slot, the activation time before jitter; a zero slot, as when
+// RunOnStart finds no previous activation, runs without checking the history.
+func (s *Scheduler) dispatchScheduled(job *scheduledJob, slot time.Time) {
+	_, err := s.dispatch(job, entity.TriggerSchedule, false, slot)
+	if err != nil && !notify.HasCode(err, notify.CodeJobLocked) && !notify.HasCode(err, notify.CodeJobSlotDone) {
+		s.jobLogger(job).Warn("Agendador: Execução do job ignorada", logging.KeyError, err)
+	}
+}
+
+// dispatch starts a run unless the previous one is still in progress, the scheduler is stopped,
+// another replica holds the job lease or, for a scheduled slot, already ran it.
+func (s *Scheduler) dispatch(job *scheduledJob, trigger string, dryRun bool, slot time.Time) (entity.JobRun, error) {
+	if !job.running.CompareAndSwap(false, true) {
+		return entity.JobRun{}, notify.CreateCustomNotification(notify.ErrorJobRunning, "", job.job.Name)
+	}
+
+	s.mu.Lock()
+	if !s.started {
+		s.mu.Unlock()
+		job.running.Store(false)
+		return entity.JobRun{}, notify.CreateCustomNotification(notify.ErrorSchedulerStopped, "", job.job.Name)
+	}
+	runsCtx := s.runsCtx
+	s.runs.Add(1)
+	s.mu.Unlock()
+
+	lease, err := s.acquire(runsCtx, job)
+	if err == nil {
+		err = s.checkSlot(runsCtx, job, slot)
+		if err != nil && lease != nil {
+			_ = lease.Release(context.WithoutCancel(runsCtx))
+		}
+	}
+	if err != nil {
+		s.runs.Done()
+		job.running.Store(false)
+		return entity.JobRun{}, err
+	}
+
+	run := &entity.JobRun{
+		ID:          values.NewGUID().String(),
+		JobName:     job.job.Name,
+		TriggeredBy: trigger,
+		DryRun:      dryRun,
+		Status:      entity.JobRunRunning,
+		StartedAt:   time.Now(),
+	}
+	if !slot.IsZero() {
+		run.ScheduledFor = &slot
+	}
+
+	// Each run is the root of a trace of its own, holding the spans of the services it calls.
+	runCtx, span := tracing.Start(runsCtx, "job "+job.job.Name, trace.WithNewRoot(), trace.WithAttributes(
+		attribute.String("job.name", job.job.Name),
+		attribute.String("job.run_id", run.ID),
+		attribute.String("job.trigger", trigger),
+		attribute.Bool("job.dry_run", dryRun),
+	))
+
+	runLogger := s.jobLogger(job).With(logging.KeyRunId, run.ID)
+	if spanContext := span.SpanContext(); spanContext.IsValid() {
+		runLogger = runLogger.With(logging.KeyTraceId, spanContext.TraceID().String())
+	}
+	runCtx = logging.WithLogger(runCtx, runLogger)
+
+	// The history is written even when the run itself is cancelled.
+	recordCtx := context.WithoutCancel(runCtx)
+	s.recordStart(recordCtx, run)
+	started := *run
+
+	go func() {
+		defer s.runs.Done()
+		defer job.running.Store(false)
+
+		err := s.executeLeased(runCtx, job, run, lease)
+		run.Finish(time.Now(), err)
+		job.finish(run.StartedAt, err)
+		s.recordFinish(recordCtx, run)
+
+		span.SetAttributes(
+			attribute.String("job.status", string(run.Status)),
+			attribute.Int("job.processed", run.Processed),
+			attribute.Int("job.updated", run.Updated),
+			attribute.Int("job.failed", run.Failed),
+		)
+		tracing.End(span, err)
+		if s.observer != nil {
+			s.observer.RunFinished(*run)
+		}
+
+		elapsed := run.FinishedAt.Sub(run.StartedAt)
+		if err != nil {
+			runLogger.Error("Agendador: Job falhou", "duration", elapsed, logging.KeyError, err)
+		} else {
+			runLogger.Info("Agendador: Job concluído", "duration", elapsed,
+				"processed", run.Processed, "updated", run.Updated, "failed", run.Failed)
+		}
+	}()
+
+	return started, nil
+}
+
+// acquire takes the job lease when a Locker is configured; a nil lease means no locking.
+func (s *Scheduler) acquire(ctx context.Context, job *scheduledJob) (lock.Lease, error) {
+	if s.locker == nil {
+		return nil, nil
+	}
+
+	lease, acquired, err := s.locker.TryAcquire(ctx, "job:"+job.job.Name, s.lockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		skips := job.lockSkips.Add(1)
+		if s.observer != nil {
+			s.observer.LockSkipped(job.job.Name)
+		}
+		s.jobLogger(job).Info("Agendador: Job ignorado, lock mantido por outra instância", "lock_skips", skips)
+		return nil, notify.CreateCustomNotification(notify.ErrorJobLocked, "", job.job.Name)
+	}
+
+	return lease, nil
+}
+
+// checkSlot rejects a slot already recorded by a replica. It runs under the job lease, so a replica
+// firing the slot later, delayed by jitter or clock skew, finds the run of the first one.
+func (s *Scheduler) checkSlot(ctx context.Context, job *scheduledJob, slot time.Time) error {
+	if slot.IsZero() || s.recorder == nil {
+		return nil
+	}
+
+	done, err := s.recorder.ExistsForSlot(ctx, job.job.Name, slot)
+	if err != nil {
+		return err
+	}
+	if done {
+		s.jobLogger(job).Info("Agendador: Job ignorado, horário já executado por outra instância", "slot", slot.Format(time.RFC3339))
+		return notify.CreateCustomNotification(notify.ErrorJobSlotDone, "", job.job.Name)
+	}
+	return nil
+}
+
+// executeLeased runs the job, cancelling it if the lease is lost, and releases the lease afterwards.
+func (s *Scheduler) executeLeased(ctx context.Context, job *scheduledJob, run *entity.JobRun, lease lock.Lease) error {
+	if lease == nil {
+		return s.execute(ctx, job, run)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	finished := make(chan struct{})
+	defer close(finished)
+
+	go func() {
+		select {
+		case <-lease.Lost():
+			logging.FromContext(ctx, s.logger).Warn("Agendador: Lock do job perdido, execução cancelada")
+			cancel()
+		case <-finished:
+		}
+	}()
+
+	defer func() {
+		if err := lease.Release(context.WithoutCancel(ctx)); err != nil {
+			logging.FromContext(ctx, s.logger).Warn("Agendador: Erro ao liberar o lock do job", logging.KeyError, err)
+		}
+	}()
+
+	return s.execute(ctx, job, run)
+}
+
+// recordStart and recordFinish persist the run; failures only cost the history, so they are logged.
+func (s *Scheduler) recordStart(ctx context.Context, run *entity.JobRun) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.Create(ctx, run); err != nil {
+		logging.FromContext(ctx, s.logger).Error("Agendador: Erro ao gravar o histórico do job", logging.KeyError, err)
+	}
+}
+
+func (s *Scheduler) recordFinish(ctx context.Context, run *entity.JobRun) {
+	if s.recorder == nil {
+		return
+	}
+	if err := s.recorder.Update(ctx, run); err != nil {
+		logging.FromContext(ctx, s.logger).Error("Agendador: Erro ao gravar o histórico do job", logging.KeyError, err)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, job *scheduledJob, run *entity.JobRun) (err error) {
+	if job.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.job.Timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = configIO.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	return job.job.Run(ctx, run)
+}
+
+func (s *Scheduler) jobLogger(job *scheduledJob) *slog.Logger {
+	return s.logger.With(logging.KeyJob, job.job.Name)
+}
+
+func (job *scheduledJob) setNextRun(next time.Time) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.nextRun = next
+}
+
+func (job *scheduledJob) finish(started time.Time, err error) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.lastRun = started
+	job.lastErr = err
+}
+
+func (job *scheduledJob) info() JobInfo {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+
+	info := JobInfo{
+		Name:      job.job.Name,
+		Schedule:  job.schedule.String(),
+		TimeZone:  job.schedule.Location().String(),
+		Running:   job.running.Load(),
+		NextRun:   job.nextRun,
+		LastRun:   job.lastRun,
+		LockSkips: job.lockSkips.Load(),
+	}
+	if job.lastErr != nil {
+		info.LastError = job.lastErr.Error()
+	}
+	return info
+}
+
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(max)))
+}
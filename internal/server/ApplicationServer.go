@@ -1,90 +1,319 @@
 package server
 
 import (
+	"PocGo/internal/auth"
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
 	handlers "PocGo/internal/handler"
+	handlerBase "PocGo/internal/handler/base"
+	"PocGo/internal/health"
+	"PocGo/internal/logging"
+	"PocGo/internal/metrics"
 	"PocGo/internal/middleware"
+	"PocGo/internal/ratelimit"
 	applicationService "PocGo/internal/services"
 	"context"
 	"errors"
-	configIO "fmt"
 	muxRouter "github.com/gorilla/mux"
+	"log/slog"
+	"net"
 	httpclient "net/http"
+	"net/url"
+	setIO "os"
+	"sync"
 	"time"
 )
 
 type ApplicationServer struct {
 	userHandler handlers.UserHandler
+	jobHandler  handlers.JobHandler
 	router      *muxRouter.Router
 	httpServer  *httpclient.Server
+	logger      *slog.Logger
+	accessLog   func(httpclient.Handler) httpclient.Handler
+	metrics     *metrics.Metrics
+	health      *health.Health
+	// authenticator guards the protected routes; nil leaves every route public.
+	authenticator *auth.Authenticator
+	// limiter applies the per-client rate limits to the protected routes; nil disables them.
+	limiter *ratelimit.Limiter
+	// legacySunset is announced in the Sunset header of the deprecated routes.
+	legacySunset time.Time
+	// shutdownDelay is how long Start keeps serving once readiness reports the shutdown.
+	shutdownDelay time.Duration
+	// stopping is closed by Shutdown, ending the shutdown delay early.
+	stopping     chan struct{}
+	stoppingOnce sync.Once
 }
 
+// NewServer builds the server; logger is the base of the request loggers and may be nil to use slog.Default.
+// telemetry may be nil, in which case /metrics is not served, and checks may be nil for a readiness without checkers.
+// authenticator may be nil to serve the protected routes without authentication, as with AUTH_ENABLED=false,
+// and limiter nil to serve them without rate limits.
 func NewServer(
 	services *applicationService.Services,
+	configuration *config.Config,
+	logger *slog.Logger,
+	telemetry *metrics.Metrics,
+	checks *health.Health,
+	authenticator *auth.Authenticator,
+	limiter *ratelimit.Limiter,
 ) *ApplicationServer {
+	if checks == nil {
+		checks = health.New(health.Options{})
+	}
+
+	handlerBase.ConfigureProblemResponses(configuration.App.IsProduction())
+
 	server := &ApplicationServer{
-		userHandler: handlers.NewUserHandler(services.User),
-		router:      muxRouter.NewRouter(),
+		userHandler:  handlers.NewUserHandler(services.User),
+		router:       muxRouter.NewRouter(),
+		legacySunset: configuration.App.LegacySunset,
+		logger:       logging.OrDefault(logger),
+		metrics:      telemetry,
+		health:       checks,
+
+		authenticator: authenticator,
+		limiter:       limiter,
+		stopping:      make(chan struct{}),
+	}
+	server.accessLog = middleware.AccessLog(server.accessLogOptions(configuration))
+	if configuration.Health != nil {
+		server.shutdownDelay = configuration.Health.ShutdownDelay
+	}
+
+	if services.Job != nil {
+		server.jobHandler = handlers.NewJobHandler(services.Job)
 	}
 
 	server.setupRoutes()
 	return server
 }
 
+// accessLogOptions reads the access log settings; an output that cannot be opened falls back to stdout.
+func (server *ApplicationServer) accessLogOptions(configuration *config.Config) middleware.AccessLogOptions {
+	options := middleware.AccessLogOptions{
+		TrustedProxies: configuration.App.TrustedProxies,
+		Output:         setIO.Stdout,
+	}
+	if server.metrics != nil {
+		options.Observe = server.metrics.ObserveRequest
+	}
+	if configuration.Log == nil {
+		return options
+	}
+
+	options.Format = configuration.Log.AccessFormat
+	if options.Format != "" && options.Format != provider.AccessLogSlog {
+		output, err := logging.OpenOutput(configuration.Log.AccessOutput)
+		if err != nil {
+			server.logger.Error("Erro ao abrir o log de acesso, usando stdout", logging.KeyError, err)
+		} else {
+			options.Output = output
+		}
+	}
+	return options
+}
+
+// legacyDeprecatedAt is when the unversioned routes were superseded by /api/v1.
+var legacyDeprecatedAt = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+const apiV1 = "v1"
+
+// setupRoutes registers every route as protected, requiring credentials and subject to the rate limits, or
+// public. Only the probes and /metrics, polled by the infrastructure, are public.
 func (server *ApplicationServer) setupRoutes() {
 
 	server.router = muxRouter.NewRouter()
+	server.router.NotFoundHandler = httpclient.HandlerFunc(server.handleNotFound)
+	server.router.MethodNotAllowedHandler = httpclient.HandlerFunc(server.handleMethodNotAllowed)
+	server.router.Use(middleware.RouteLogger)
+
+	server.setupUserRoutes(server.apiRouter(apiV1), server.protected)
+	server.setupLegacyRoutes()
+
+	if server.jobHandler != nil {
+		server.setupAdminRoutes()
+	}
+
+	// Public routes.
+	server.router.HandleFunc("/health/live", server.handleLive).Methods(httpclient.MethodGet)
+	server.router.HandleFunc("/health/ready", server.handleReady).Methods(httpclient.MethodGet)
+	// /health predates the split and is polled as a readiness check.
+	server.router.HandleFunc("/health", server.handleReady).Methods(httpclient.MethodGet)
+	if server.metrics != nil {
+		server.router.Handle("/metrics", server.metrics.Handler()).Methods(httpclient.MethodGet)
+	}
+}
+
+// apiRouter returns the sub-tree of one API version, e.g. /api/v1, so versions can be served side by side.
+func (server *ApplicationServer) apiRouter(version string) *muxRouter.Router {
+	return server.router.PathPrefix("/api/" + version).Subrouter()
+}
+
+// setupUserRoutes registers the users resource on router, wrapping each handler with wrap.
+func (server *ApplicationServer) setupUserRoutes(router *muxRouter.Router, wrap func(httpclient.Handler) httpclient.Handler) {
+	handle := func(path string, handler httpclient.HandlerFunc, method string) {
+		router.Handle(path, wrap(handler)).Methods(method)
+	}
+
+	handle("/users", server.userHandler.List, httpclient.MethodGet)
+	handle("/users", server.userHandler.Create, httpclient.MethodPost)
+	handle("/users/{id}", server.userHandler.GetById, httpclient.MethodGet)
+	handle("/users/{id}", server.userHandler.Update, httpclient.MethodPut)
+	handle("/users/{id}", server.userHandler.Patch, httpclient.MethodPatch)
+	handle("/users/{id}", server.userHandler.Delete, httpclient.MethodDelete)
+	handle("/users/{id}/restore", server.userHandler.Restore, httpclient.MethodPost)
+	handle("/users/{id}/reactivate", server.userHandler.Reactivate, httpclient.MethodPost)
+}
+
+// protected limits next per client address, makes it require an authenticated principal, see
+// auth.Authenticator.Require, and then applies the rate limit of the principal.
+func (server *ApplicationServer) protected(next httpclient.Handler) httpclient.Handler {
+	limited := server.limiter.Middleware(next)
+	if server.authenticator != nil {
+		limited = server.authenticator.Require(limited)
+	}
+	return server.limiter.AddressMiddleware(limited)
+}
+
+// setupLegacyRoutes keeps the unversioned routes as deprecated aliases of /api/v1, announcing their successor.
+// They are protected like their successors.
+func (server *ApplicationServer) setupLegacyRoutes() {
+	deprecated := func(successor func(request *httpclient.Request) string) func(httpclient.Handler) httpclient.Handler {
+		deprecation := middleware.Deprecated(legacyDeprecatedAt, server.legacySunset, successor)
+		return func(next httpclient.Handler) httpclient.Handler {
+			return deprecation(server.protected(next))
+		}
+	}
+	v1Path := func(path string) func(*httpclient.Request) string {
+		return func(*httpclient.Request) string { return "/api/" + apiV1 + path }
+	}
 
 	server.router.Handle("/user/get_user_by_id",
-		middleware.Logging(httpclient.HandlerFunc(server.userHandler.GetById))).
+		deprecated(func(request *httpclient.Request) string {
+			return "/api/" + apiV1 + "/users/" + url.PathEscape(handlerBase.GetFromQuery(request, "id"))
+		})(httpclient.HandlerFunc(server.userHandler.GetById))).
 		Methods(httpclient.MethodGet).
 		Queries("id", "{id}")
 
 	server.router.Handle("/user/get_all_users",
-		middleware.Logging(httpclient.HandlerFunc(server.userHandler.GetAll))).
-		Methods(httpclient.MethodGet).
-		Queries("data", "{data}")
+		deprecated(v1Path("/users"))(httpclient.HandlerFunc(server.userHandler.GetAll))).
+		Methods(httpclient.MethodGet)
 
 	server.router.Handle("/user/update_user",
-		middleware.Logging(httpclient.HandlerFunc(server.userHandler.Update))).
+		deprecated(v1Path("/users"))(httpclient.HandlerFunc(server.userHandler.Update))).
 		Methods(httpclient.MethodPut)
 
-	server.router.Handle("/health",
-		middleware.Logging(httpclient.HandlerFunc(server.handleHealth))).
-		Methods(httpclient.MethodGet)
+	server.setupUserRoutes(server.router, deprecated(func(request *httpclient.Request) string {
+		return "/api/" + apiV1 + request.URL.Path
+	}))
+}
+
+func (server *ApplicationServer) setupAdminRoutes() {
+	handle := func(path string, handler httpclient.HandlerFunc, method string) {
+		server.router.Handle(path, server.protected(handler)).Methods(method)
+	}
 
+	handle("/admin/jobs", server.jobHandler.GetAll, httpclient.MethodGet)
+	handle("/admin/jobs/{name}/runs", server.jobHandler.GetRuns, httpclient.MethodGet)
+	handle("/admin/jobs/{name}/trigger", server.jobHandler.Trigger, httpclient.MethodPost)
 }
 
 func (server *ApplicationServer) Start(ctx context.Context) error {
-	configIO.Println("Servidor iniciado na porta 8080")
+	server.logger.Info("Servidor iniciado", "addr", ":8080")
 
-	handler := middleware.Logging(server.router)
+	handler := server.Handler()
+
+	// Requests outlive ctx while the load balancer drains the instance and the server shuts down; their
+	// queries are only cancelled once Shutdown returns or gives up.
+	serveCtx, cancelServe := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelServe()
 
 	server.httpServer = &httpclient.Server{
 		Addr:    ":8080",
 		Handler: handler,
+		BaseContext: func(net.Listener) context.Context {
+			return serveCtx
+		},
 	}
 
 	go func() {
 		if err := server.httpServer.ListenAndServe(); err != nil && !errors.Is(err, httpclient.ErrServerClosed) {
-			configIO.Printf("Erro ao iniciar servidor: %v\n", err)
+			server.logger.Error("Erro ao iniciar servidor", logging.KeyError, err)
 		}
 	}()
 
 	<-ctx.Done()
+	server.health.BeginShutdown()
+	server.awaitShutdownDelay()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	return server.httpServer.Shutdown(shutdownCtx)
+	err := server.httpServer.Shutdown(shutdownCtx)
+	cancelServe()
+	return err
+}
+
+// awaitShutdownDelay keeps serving for shutdownDelay, so the load balancer sees the failing readiness before
+// the listener closes. Shutdown ends the wait early.
+func (server *ApplicationServer) awaitShutdownDelay() {
+	if server.shutdownDelay <= 0 {
+		return
+	}
+	server.logger.Info("Aguardando a retirada do balanceador", "delay", server.shutdownDelay)
+
+	timer := time.NewTimer(server.shutdownDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-server.stopping:
+	}
 }
 
+// Shutdown ends the shutdown delay of Start, if it is waiting, and gracefully stops the server.
 func (server *ApplicationServer) Shutdown(ctx context.Context) error {
+	server.stoppingOnce.Do(func() { close(server.stopping) })
 	if server.httpServer != nil {
 		return server.httpServer.Shutdown(ctx)
 	}
 	return nil
 }
 
-func (server *ApplicationServer) handleHealth(w httpclient.ResponseWriter, _ *httpclient.Request) {
-	w.WriteHeader(httpclient.StatusOK)
+func (server *ApplicationServer) handleLive(w httpclient.ResponseWriter, r *httpclient.Request) {
+	sendHealth(w, r, server.health.Live())
+}
+
+func (server *ApplicationServer) handleReady(w httpclient.ResponseWriter, r *httpclient.Request) {
+	sendHealth(w, r, server.health.Ready(r.Context()))
+}
+
+// sendHealth answers 200 when report is up and 503 otherwise, telling proxies not to cache the answer.
+func sendHealth(w httpclient.ResponseWriter, r *httpclient.Request, report health.Report) {
+	status := httpclient.StatusOK
+	if !report.IsUp() {
+		status = httpclient.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	_ = handlerBase.SendJsonResponseWithStatus(w, r, report, status)
+}
+
+func (server *ApplicationServer) handleNotFound(w httpclient.ResponseWriter, r *httpclient.Request) {
+	handlerBase.SendErrorResponse(w, r, notify.CreateCustomNotification(notify.NotFound, "Recurso", nil))
+}
+
+func (server *ApplicationServer) handleMethodNotAllowed(w httpclient.ResponseWriter, r *httpclient.Request) {
+	handlerBase.SendErrorResponse(w, r, notify.CreateNotification(notify.InvalidMethod))
+}
+
+// Handler returns the routed handler wrapped with the server-wide middlewares, as served by Start.
+// From the outside in: RequestId, Tracing, RequestLogger (which reads both) and AccessLog.
+func (server *ApplicationServer) Handler() httpclient.Handler {
+	handler := server.accessLog(server.router)
+	handler = middleware.RequestLogger(server.logger)(handler)
+	handler = middleware.Tracing(handler)
+	return middleware.RequestId(handler)
 }
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"PocGo/internal/authorization"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
+	"context"
+	"time"
+)
+
+const (
+	JobEntity = "Job"
+
+	DefaultJobRunsLimit = 20
+	MaxJobRunsLimit     = 100
+)
+
+// JobSummary is a registered job with its next and last runs.
+type JobSummary struct {
+	Name     string         `json:"name"`
+	Schedule string         `json:"schedule"`
+	TimeZone string         `json:"timeZone"`
+	Running  bool           `json:"running"`
+	NextRun  *time.Time     `json:"nextRun,omitempty"`
+	LastRun  *entity.JobRun `json:"lastRun,omitempty"`
+	// LockSkips counts the runs this replica skipped because another one held the job lock.
+	LockSkips int64 `json:"lockSkips"`
+}
+
+type JobService interface {
+	GetAll(ctx context.Context) ([]JobSummary, error)
+	GetRuns(ctx context.Context, name string, limit int) (*[]entity.JobRun, error)
+	Trigger(ctx context.Context, name string, dryRun bool) (*entity.JobRun, error)
+}
+
+type jobService struct {
+	scheduler        *scheduler.Scheduler
+	jobRunRepository repository.JobRunRepository
+	policy           *authorization.Policy
+}
+
+func NewJobService(jobScheduler *scheduler.Scheduler, jobRunRepository repository.JobRunRepository, policy *authorization.Policy) JobService {
+	return &jobService{
+		scheduler:        jobScheduler,
+		jobRunRepository: jobRunRepository,
+		policy:           policy,
+	}
+}
+
+func (service *jobService) GetAll(ctx context.Context) ([]JobSummary, error) {
+	if err := service.policy.Authorize(ctx, authorization.JobsRead, ""); err != nil {
+		return nil, err
+	}
+
+	jobs := service.scheduler.Jobs()
+	summaries := make([]JobSummary, 0, len(jobs))
+
+	for _, job := range jobs {
+		summary := JobSummary{
+			Name:      job.Name,
+			Schedule:  job.Schedule,
+			TimeZone:  job.TimeZone,
+			Running:   job.Running,
+			LockSkips: job.LockSkips,
+		}
+		if !job.NextRun.IsZero() {
+			nextRun := job.NextRun
+			summary.NextRun = &nextRun
+		}
+
+		runs, err := service.jobRunRepository.FindByJob(ctx, job.Name, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(*runs) > 0 {
+			summary.LastRun = &(*runs)[0]
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// GetRuns returns the latest runs of a registered job; limit is clamped to MaxJobRunsLimit.
+func (service *jobService) GetRuns(ctx context.Context, name string, limit int) (*[]entity.JobRun, error) {
+	if err := service.policy.Authorize(ctx, authorization.JobsRead, ""); err != nil {
+		return nil, err
+	}
+
+	if !service.exists(name) {
+		return nil, notify.CreateCustomNotification(notify.NotFound, JobEntity, name)
+	}
+
+	if limit <= 0 {
+		limit = DefaultJobRunsLimit
+	}
+	if limit > MaxJobRunsLimit {
+		limit = MaxJobRunsLimit
+	}
+
+	return service.jobRunRepository.FindByJob(ctx, name, limit)
+}
+
+// Trigger starts the job outside its schedule. The run continues after the request ends.
+func (service *jobService) Trigger(ctx context.Context, name string, dryRun bool) (*entity.JobRun, error) {
+	if err := service.policy.Authorize(ctx, authorization.JobsRun, ""); err != nil {
+		return nil, err
+	}
+
+	run, err := service.scheduler.Trigger(name, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+func (service *jobService) exists(name string) bool {
+	for _, job := range service.scheduler.Jobs() {
+		if job.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -1,17 +1,28 @@
 package service
 
 import (
+	"PocGo/internal/authorization"
 	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
+	"log/slog"
 )
 
 type Services struct {
 	User UserService
+	Job  JobService
 	// Outros serviços aqui
 }
 
-func NewServices(repositories *repository.Repositories) *Services {
+// NewServices builds the application services. jobScheduler may be nil when jobs are not used,
+// in which case Job is nil. logger may be nil to use slog.Default, and a nil policy authorizes everything.
+func NewServices(repositories *repository.Repositories, jobScheduler *scheduler.Scheduler, logger *slog.Logger, policy *authorization.Policy) *Services {
+	services := &Services{
+		User: NewUserService(repositories.User, logger, policy),
+	}
 
-	return &Services{
-		User: NewUserService(repositories.User),
+	if jobScheduler != nil {
+		services.Job = NewJobService(jobScheduler, repositories.JobRun, policy)
 	}
+
+	return services
 }
@@ -0,0 +1,89 @@
+package service
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/patch"
+	repository "PocGo/internal/repositories"
+	"PocGo/internal/tracing"
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const userIdAttribute = attribute.Key("user.id")
+
+// tracedUserService wraps each call of a UserService in a span named after the method, e.g. userService.Update,
+// so the repository spans of a request are grouped by the service operation that issued them.
+type tracedUserService struct {
+	inner UserService
+}
+
+func withUserId(id string) trace.SpanStartOption {
+	return trace.WithAttributes(userIdAttribute.String(id))
+}
+
+func (service tracedUserService) GetById(ctx context.Context, id string) (user *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetById", withUserId(id))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.GetById(ctx, id)
+}
+
+func (service tracedUserService) GetAll(ctx context.Context, date string) (users *[]entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetAll")
+	defer func() { tracing.End(span, err) }()
+	return service.inner.GetAll(ctx, date)
+}
+
+func (service tracedUserService) List(ctx context.Context, query repository.UserListQuery) (page *repository.UserPage, err error) {
+	ctx, span := tracing.Start(ctx, "userService.List")
+	defer func() { tracing.End(span, err) }()
+	return service.inner.List(ctx, query)
+}
+
+func (service tracedUserService) Update(ctx context.Context, toUpdate *entity.User) (err error) {
+	ctx, span := tracing.Start(ctx, "userService.Update", withUserId(toUpdate.ID.String()))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Update(ctx, toUpdate)
+}
+
+func (service tracedUserService) Patch(ctx context.Context, id string, version int64, changes patch.Patch) (user *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.Patch", withUserId(id))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Patch(ctx, id, version, changes)
+}
+
+func (service tracedUserService) UpdateOldUsersStatus(ctx context.Context, options SweepOptions) (result repository.BatchResult, err error) {
+	ctx, span := tracing.Start(ctx, "userService.UpdateOldUsersStatus", trace.WithAttributes(
+		attribute.Int("sweep.inactive_after_months", options.InactiveAfterMonths),
+		attribute.Bool("sweep.dry_run", options.DryRun),
+	))
+	defer func() {
+		span.SetAttributes(attribute.Int("sweep.processed", result.Processed), attribute.Int("sweep.updated", len(result.Updated)))
+		tracing.End(span, err)
+	}()
+	return service.inner.UpdateOldUsersStatus(ctx, options)
+}
+
+func (service tracedUserService) Create(ctx context.Context, toCreate *entity.User) (err error) {
+	ctx, span := tracing.Start(ctx, "userService.Create")
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Create(ctx, toCreate)
+}
+
+func (service tracedUserService) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := tracing.Start(ctx, "userService.Delete", withUserId(id))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Delete(ctx, id)
+}
+
+func (service tracedUserService) Restore(ctx context.Context, id string) (user *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.Restore", withUserId(id))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Restore(ctx, id)
+}
+
+func (service tracedUserService) Reactivate(ctx context.Context, id string) (user *entity.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.Reactivate", withUserId(id))
+	defer func() { tracing.End(span, err) }()
+	return service.inner.Reactivate(ctx, id)
+}
@@ -1,69 +1,184 @@
 package service
 
 import (
+	"PocGo/internal/authorization"
+	"PocGo/internal/domain/dto"
 	entity "PocGo/internal/domain/entities"
 	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	"PocGo/internal/logging"
+	"PocGo/internal/patch"
 	repository "PocGo/internal/repositories"
+	"PocGo/internal/validation"
+	"bytes"
+	"context"
+	setJson "encoding/json"
+	"errors"
+	configIO "fmt"
+	"log/slog"
+	"strings"
+	"time"
 )
 
 const (
 	Entity = "Usuário"
 )
 
+const (
+	DefaultUserPageSize = 20
+	MaxUserPageSize     = 100
+)
+
 type UserService interface {
-	GetById(id string) (*entity.User, error)
-	GetAll(date string) (*[]entity.User, error)
-	Update(toUpdate *entity.User) error
-	UpdateOldUsersStatus() (int, error)
+	GetById(ctx context.Context, id string) (*entity.User, error)
+	GetAll(ctx context.Context, date string) (*[]entity.User, error)
+	List(ctx context.Context, query repository.UserListQuery) (*repository.UserPage, error)
+	// Update and Patch only write over the given version of the user, reporting PRECONDITION_FAILED otherwise.
+	Update(ctx context.Context, toUpdate *entity.User) error
+	Patch(ctx context.Context, id string, version int64, changes patch.Patch) (*entity.User, error)
+	UpdateOldUsersStatus(ctx context.Context, options SweepOptions) (repository.BatchResult, error)
+	Create(ctx context.Context, toCreate *entity.User) error
+	Delete(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) (*entity.User, error)
+	Reactivate(ctx context.Context, id string) (*entity.User, error)
+}
+
+const DefaultInactiveAfterMonths = 5
+
+// SweepOptions configures UpdateOldUsersStatus. Zero values fall back to DefaultInactiveAfterMonths
+// and repository.DefaultBatchSize.
+type SweepOptions struct {
+	InactiveAfterMonths int
+	BatchSize           int
+	DryRun              bool
+	// Progress, when set, is called after each chunk with the totals so far.
+	Progress func(repository.BatchResult)
 }
 
 type userService struct {
 	userRepository repository.UserRepository
+	logger         *slog.Logger
+	policy         *authorization.Policy
 }
 
-func NewUserService(repository repository.UserRepository) UserService {
-	return &userService{
+// NewUserService builds the user service, traced by tracedUserService; logger may be nil to use slog.Default
+// and a nil policy leaves every operation allowed.
+func NewUserService(repository repository.UserRepository, logger *slog.Logger, policy *authorization.Policy) UserService {
+	return tracedUserService{inner: &userService{
 		userRepository: repository,
+		logger:         logging.OrDefault(logger),
+		policy:         policy,
+	}}
+}
+
+// log returns the request or job logger of ctx, which already carries the user ID of /users/{id} routes.
+func (service *userService) log(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx, service.logger)
+}
+
+func (service *userService) GetById(ctx context.Context, id string) (*entity.User, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersRead, id); err != nil {
+		return nil, err
 	}
+
+	guid, err := parseId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.findById(ctx, guid)
 }
 
-func (service *userService) GetById(id string) (*entity.User, error) {
-	user, err := service.userRepository.FindById(id)
+func (service *userService) findById(ctx context.Context, id values.GUID) (*entity.User, error) {
+	user, err := service.userRepository.FindById(ctx, id)
 
 	if err != nil {
-		return nil, notify.CreateCustomNotification(notify.NotFound, Entity, err)
+		return nil, wrapRepositoryError(notify.NotFound, err)
 	}
 
 	return user, nil
 }
 
-func (service *userService) GetAll(date string) (*[]entity.User, error) {
-	users, err := service.userRepository.FindAll(date)
+func (service *userService) GetAll(ctx context.Context, date string) (*[]entity.User, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersRead, ""); err != nil {
+		return nil, err
+	}
+
+	users, err := service.userRepository.FindAll(ctx, date)
 
 	if err != nil {
-		return nil, notify.CreateCustomNotification(notify.NotFound, Entity, err)
+		return nil, wrapRepositoryError(notify.NotFound, err)
 	}
 
 	return users, nil
 }
 
-func (service *userService) Update(dtoUpdate *entity.User) error {
-	user, err := service.GetById(dtoUpdate.ID)
+// List returns a page of users, sorted by creation date unless query.Sort says otherwise;
+// the limit defaults to DefaultUserPageSize and is clamped to MaxUserPageSize.
+func (service *userService) List(ctx context.Context, query repository.UserListQuery) (*repository.UserPage, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersRead, ""); err != nil {
+		return nil, err
+	}
+
+	if query.Sort == "" {
+		query.Sort = repository.SortByCreationDate
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultUserPageSize
+	}
+	if query.Limit > MaxUserPageSize {
+		query.Limit = MaxUserPageSize
+	}
+
+	page, err := service.userRepository.List(ctx, query)
 	if err != nil {
-		return notify.CreateCustomNotification(notify.NotFound, Entity, err)
+		return nil, wrapRepositoryError(notify.FindErrorRepository, err)
+	}
+
+	return page, nil
+}
+
+func (service *userService) Update(ctx context.Context, dtoUpdate *entity.User) error {
+	if err := service.authorizeUpdate(ctx, dtoUpdate.ID.String()); err != nil {
+		return err
+	}
+
+	user, err := service.findById(ctx, dtoUpdate.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(user, dtoUpdate.Version); err != nil {
+		return err
 	}
+	previous := *user
 
 	if dtoUpdate.Name != "" {
 		user.Name = dtoUpdate.Name
 	}
+
+	// A request without status keeps the current one; deletion has its own endpoint.
+	if dtoUpdate.Status != entity.StatusUndefined {
+		if dtoUpdate.Status == entity.StatusDeleted {
+			return notify.CreateCustomNotification(notify.InvalidStatusTransition, Entity, "use a remoção para excluir o usuário")
+		}
+		if err := checkTransition(user.Status, dtoUpdate.Status); err != nil {
+			return err
+		}
+		user.Status = dtoUpdate.Status
+	}
+
 	if dtoUpdate.Email != "" {
-		user.Email = dtoUpdate.Email
+		if user.Email, err = service.resolveEmail(ctx, dtoUpdate.Email, user.Email); err != nil {
+			return err
+		}
 	}
 
-	user.Status = dtoUpdate.Status
+	if err := service.authorizeChanges(ctx, &previous, user); err != nil {
+		return err
+	}
 
-	if err := service.userRepository.Update(user); err != nil {
-		return notify.CreateCustomNotification(notify.InvalidData, Entity, err)
+	if err := service.userRepository.Update(ctx, user); err != nil {
+		return wrapRepositoryError(notify.InvalidData, err)
 	}
 
 	*dtoUpdate = *user
@@ -71,27 +186,318 @@ func (service *userService) Update(dtoUpdate *entity.User) error {
 	return nil
 }
 
-func (service *userService) UpdateOldUsersStatus() (int, error) {
-	oldUsers, err := service.userRepository.FindOldUsers()
+// Patch applies changes to the JSON representation of the stored user and saves the result. Unlike Update,
+// a patch can clear the name; the email and status stay required, the ID cannot change and a new status
+// must be a permitted transition.
+func (service *userService) Patch(ctx context.Context, id string, version int64, changes patch.Patch) (*entity.User, error) {
+	if err := service.authorizeUpdate(ctx, id); err != nil {
+		return nil, err
+	}
+
+	current, err := service.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(current, version); err != nil {
+		return nil, err
+	}
+
+	document, err := setJson.Marshal(current)
+	if err != nil {
+		return nil, notify.CreateCustomNotification(notify.InvalidData, Entity, err)
+	}
+
+	patched, err := changes.Apply(document)
+	if err != nil {
+		if errors.Is(err, patch.ErrTestFailed) {
+			return nil, notify.CreateCustomNotification(notify.PatchTestFailed, Entity, err)
+		}
+		return nil, notify.CreateCustomNotification(notify.InvalidData, Entity, err)
+	}
+
+	var result dto.UserDocument
+	if err := validation.DecodeJSON(bytes.NewReader(patched), &result, Entity); err != nil {
+		return nil, err
+	}
+	user := result.ToUser()
+
+	switch {
+	case user.ID != current.ID:
+		return nil, notify.CreateValidationNotification(Entity, []notify.FieldError{{Field: "id", Message: "não pode ser alterado"}})
+	case user.Status == entity.StatusDeleted:
+		return nil, notify.CreateCustomNotification(notify.InvalidStatusTransition, Entity, "use a remoção para excluir o usuário")
+	}
+
+	if err := checkTransition(current.Status, user.Status); err != nil {
+		return nil, err
+	}
+
+	if user.Email, err = service.resolveEmail(ctx, user.Email, current.Email); err != nil {
+		return nil, err
+	}
+
+	if err := service.authorizeChanges(ctx, current, &user); err != nil {
+		return nil, err
+	}
+
+	// The version is not part of the JSON document, so it is carried over from the stored user.
+	user.Version = current.Version
+
+	if err := service.userRepository.Update(ctx, &user); err != nil {
+		return nil, wrapRepositoryError(notify.InvalidData, err)
+	}
+
+	return &user, nil
+}
+
+// UpdateOldUsersStatus marks users created more than options.InactiveAfterMonths ago as inactive, in chunks
+// of options.BatchSize users per transaction. Users whose status cannot become inactive are skipped, and a failed
+// chunk is reported in the result without stopping the others; with DryRun nothing is written.
+func (service *userService) UpdateOldUsersStatus(ctx context.Context, options SweepOptions) (repository.BatchResult, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersUpdateStatus, ""); err != nil {
+		return repository.BatchResult{}, err
+	}
+
+	months := options.InactiveAfterMonths
+	if months <= 0 {
+		months = DefaultInactiveAfterMonths
+	}
+
+	result, err := service.userRepository.UpdateStatusBatch(ctx, repository.StatusBatch{
+		CreatedBefore: time.Now().AddDate(0, -months, 0),
+		From:          statusesMovableTo(entity.StatusInactive),
+		To:            entity.StatusInactive,
+		Size:          options.BatchSize,
+		DryRun:        options.DryRun,
+		Progress:      options.Progress,
+	})
+	if err != nil && ctx.Err() == nil {
+		return result, wrapRepositoryError(notify.NotFound, err)
+	}
+
+	return result, err
+}
+
+func (service *userService) Create(ctx context.Context, toCreate *entity.User) error {
+	if err := service.policy.Authorize(ctx, authorization.UsersCreate, ""); err != nil {
+		return err
+	}
+
+	toCreate.Name = strings.TrimSpace(toCreate.Name)
+
+	if toCreate.Name == "" || strings.TrimSpace(toCreate.Email) == "" {
+		return notify.CreateCustomNotification(notify.InvalidData, Entity, "nome e email são obrigatórios")
+	}
+
+	if toCreate.Status == entity.StatusUndefined {
+		toCreate.Status = entity.StatusActive
+	}
+
+	if err := checkTransition(entity.StatusUndefined, toCreate.Status); err != nil {
+		return err
+	}
+
+	var err error
+	if toCreate.Email, err = service.resolveEmail(ctx, toCreate.Email, ""); err != nil {
+		return err
+	}
+
+	if err := service.userRepository.Create(ctx, toCreate); err != nil {
+		return wrapRepositoryError(notify.InvalidData, err)
+	}
+
+	service.log(ctx).Info("Usuário criado", logging.KeyUserId, toCreate.ID.String(), "status", toCreate.Status.String())
+	return nil
+}
+
+func (service *userService) Delete(ctx context.Context, id string) error {
+	if err := service.policy.Authorize(ctx, authorization.UsersDelete, id); err != nil {
+		return err
+	}
+
+	guid, err := parseId(id)
 	if err != nil {
-		return 0, notify.CreateCustomNotification(notify.NotFound, Entity, err)
+		return err
+	}
+
+	user, err := service.findById(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	if err := checkTransition(user.Status, entity.StatusDeleted); err != nil {
+		return err
+	}
+
+	if err := service.userRepository.Delete(ctx, guid); err != nil {
+		return wrapRepositoryError(notify.NotFound, err)
+	}
+
+	service.log(ctx).Info("Usuário excluído", "previous_status", user.Status.String())
+	return nil
+}
+
+// Restore undoes a soft delete, leaving the user inactive. Users that are not deleted are reported as not found;
+// the repository only touches deleted rows, which is the only state allowed to become inactive this way.
+func (service *userService) Restore(ctx context.Context, id string) (*entity.User, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersRestore, id); err != nil {
+		return nil, err
+	}
+
+	guid, err := parseId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.userRepository.Restore(ctx, guid); err != nil {
+		return nil, wrapRepositoryError(notify.NotFound, err)
+	}
+
+	service.log(ctx).Info("Usuário restaurado")
+	return service.findById(ctx, guid)
+}
+
+// Reactivate moves an inactive user, typically one caught by UpdateOldUsersStatus, back to active.
+func (service *userService) Reactivate(ctx context.Context, id string) (*entity.User, error) {
+	if err := service.policy.Authorize(ctx, authorization.UsersUpdateStatus, id); err != nil {
+		return nil, err
+	}
+
+	guid, err := parseId(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := service.findById(ctx, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Status != entity.StatusInactive {
+		return nil, notify.CreateCustomNotification(notify.InvalidState, Entity, "apenas usuários inativos podem ser reativados")
 	}
 
-	if oldUsers == nil || len(*oldUsers) == 0 {
-		return 0, nil
+	if err := service.userRepository.Reactivate(ctx, guid); err != nil {
+		return nil, wrapRepositoryError(notify.NotFound, err)
 	}
 
-	updatedCount := 0
-	for _, user := range *oldUsers {
-		if user.Status == 2 {
-			continue
+	// The repository bumped the version along with the status.
+	user.Status = entity.StatusActive
+	user.Version++
+	service.log(ctx).Info("Usuário reativado")
+	return user, nil
+}
+
+// authorizeUpdate rejects, before the user is loaded, callers that can change nothing about it.
+func (service *userService) authorizeUpdate(ctx context.Context, id string) error {
+	return service.policy.AuthorizeAny(ctx, id, authorization.UsersUpdate, authorization.UsersUpdateStatus)
+}
+
+// authorizeChanges checks the permissions needed by the fields that differ between previous and updated:
+// users:update_status for the status and users:update for the name and email, which is also required
+// when nothing changes.
+func (service *userService) authorizeChanges(ctx context.Context, previous, updated *entity.User) error {
+	owner := previous.ID.String()
+	if previous.Status != updated.Status {
+		if err := service.policy.Authorize(ctx, authorization.UsersUpdateStatus, owner); err != nil {
+			return err
 		}
+	}
+	if previous.Name != updated.Name || previous.Email != updated.Email || previous.Status == updated.Status {
+		return service.policy.Authorize(ctx, authorization.UsersUpdate, owner)
+	}
+	return nil
+}
+
+// parseId reads an ID received from a client. A malformed ID matches no user, so it is reported as NOT_FOUND.
+func parseId(id string) (values.GUID, error) {
+	guid, err := values.ParseGUID(id)
+	if err != nil {
+		return values.GUID{}, notify.CreateCustomNotification(notify.NotFound, Entity, id)
+	}
+	return guid, nil
+}
+
+// checkVersion rejects a write based on a version of the user other than the stored one.
+func checkVersion(current *entity.User, version int64) error {
+	if current.Version == version {
+		return nil
+	}
+	return notify.CreateCustomNotification(notify.PreconditionFailed, Entity, configIO.Sprintf("versão %d, atual %d", version, current.Version))
+}
+
+// checkTransition rejects moving a user from current to target when the status transition table does not allow it.
+func checkTransition(current, target entity.UserStatus) error {
+	if target.IsValid() && current.CanTransitionTo(target) {
+		return nil
+	}
+
+	from := current.String()
+	if current == entity.StatusUndefined {
+		from = "novo"
+	}
+	return notify.CreateCustomNotification(notify.InvalidStatusTransition, Entity, configIO.Sprintf("%s -> %s", from, target))
+}
 
-		if err := service.userRepository.UpdateStatus(user.ID, 2); err != nil {
-			continue
+// statusesMovableTo lists the states that may transition to target, excluding target itself and
+// StatusDeleted, since soft-deleted users only leave that state through Restore.
+func statusesMovableTo(target entity.UserStatus) []entity.UserStatus {
+	var statuses []entity.UserStatus
+	for status := entity.StatusActive; status.IsValid(); status++ {
+		if status != target && status != entity.StatusDeleted && status.CanTransitionTo(target) {
+			statuses = append(statuses, status)
 		}
-		updatedCount++
+	}
+	return statuses
+}
+
+// resolveEmail returns the canonical form of email, checking that no other user has its login unless it is
+// the login of current, the email the user already has (empty on creation).
+func (service *userService) resolveEmail(ctx context.Context, email string, current string) (string, error) {
+	canonical, err := values.ParseEmail(email)
+	if err != nil {
+		return "", notify.CreateValidationNotification(Entity, []notify.FieldError{{Field: "email", Message: "deve ser um email válido"}})
+	}
+
+	login := values.LoginFromEmail(canonical)
+	if current != "" && login.Normalized() == repository.NormalizeLogin(current) {
+		return canonical.String(), nil
+	}
+
+	emailTaken, err := service.userRepository.ExistsByEmail(ctx, canonical.String())
+	if err != nil {
+		return "", err
+	}
+	if emailTaken {
+		return "", notify.CreateCustomNotification(notify.Conflict, Entity, "email já cadastrado: "+canonical.String())
+	}
+
+	loginTaken, err := service.userRepository.ExistsByLogin(ctx, login.Normalized())
+	if err != nil {
+		return "", err
+	}
+	if loginTaken {
+		return "", notify.CreateCustomNotification(notify.Conflict, Entity, "login já cadastrado: "+login.String())
 	}
 
-	return updatedCount, nil
+	return canonical.String(), nil
+}
+
+// wrapRepositoryError adds the entity to not-found, invalid-data and conflict errors, keeping
+// infrastructure failures (query, scan, timeouts) with their original code so they are not reported as 404/400.
+func wrapRepositoryError(template string, err error) error {
+	switch notify.CodeOf(err) {
+	case notify.CodeNotFound:
+		return notify.CreateCustomNotification(notify.NotFound, Entity, err)
+	case notify.CodeInvalidData:
+		return notify.CreateCustomNotification(notify.InvalidData, Entity, err)
+	case notify.CodePreconditionFailed:
+		return notify.CreateCustomNotification(notify.PreconditionFailed, Entity, err)
+	case notify.CodeConflict:
+		return notify.CreateCustomNotification(notify.Conflict, Entity, err)
+	case "":
+		return notify.CreateCustomNotification(template, Entity, err)
+	default:
+		return err
+	}
 }
@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"context"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// StartStatement starts the client span of one SQL statement, named by its operation (SELECT, UPDATE...)
+// and carrying the sanitized query text. system is the db.system value, such as "mssql" or "sqlite".
+func StartStatement(ctx context.Context, system string, query string) (context.Context, trace.Span) {
+	text := SanitizeQuery(query)
+	operation, _, _ := strings.Cut(text, " ")
+	operation = strings.ToUpper(operation)
+
+	return Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemKey.String(system),
+		semconv.DBOperationNameKey.String(operation),
+		semconv.DBQueryTextKey.String(text),
+	))
+}
+
+// SanitizeQuery replaces the string and numeric literals of query with ? and collapses its whitespace, so
+// values written into the SQL never reach the traces. Placeholders such as @p1 and ? are kept.
+func SanitizeQuery(query string) string {
+	var builder strings.Builder
+	builder.Grow(len(query))
+
+	var previous byte = ' '
+	for index := 0; index < len(query); {
+		current := query[index]
+
+		switch {
+		case current == '\'':
+			index = skipString(query, index)
+			builder.WriteByte('?')
+			previous = '?'
+		case isDigit(current) && !isIdentifier(previous):
+			for index < len(query) && (isDigit(query[index]) || query[index] == '.') {
+				index++
+			}
+			builder.WriteByte('?')
+			previous = '?'
+		case isSpace(current):
+			for index < len(query) && isSpace(query[index]) {
+				index++
+			}
+			if builder.Len() > 0 && index < len(query) {
+				builder.WriteByte(' ')
+				previous = ' '
+			}
+		default:
+			builder.WriteByte(current)
+			previous = current
+			index++
+		}
+	}
+	return builder.String()
+}
+
+// skipString returns the index after the literal opened at start, where a doubled quote is an escaped one.
+func skipString(query string, start int) int {
+	for index := start + 1; index < len(query); index++ {
+		if query[index] != '\'' {
+			continue
+		}
+		if index+1 < len(query) && query[index+1] == '\'' {
+			index++
+			continue
+		}
+		return index + 1
+	}
+	return len(query)
+}
+
+func isDigit(character byte) bool {
+	return character >= '0' && character <= '9'
+}
+
+func isIdentifier(character byte) bool {
+	return character == '_' || character == '@' || character == '$' || isDigit(character) ||
+		character >= 'a' && character <= 'z' || character >= 'A' && character <= 'Z'
+}
+
+func isSpace(character byte) bool {
+	return character == ' ' || character == '\t' || character == '\n' || character == '\r'
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"context"
+	configIO "fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// instrumentationName identifies the spans of this application among those of its libraries.
+const instrumentationName = "PocGo"
+
+// Setup installs the W3C trace context propagator and, unless the exporter is none, a tracer provider
+// exporting to stdout or OTLP. The returned function flushes and stops the provider.
+func Setup(ctx context.Context, configuration *provider.TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	noop := func(context.Context) error { return nil }
+	if configuration == nil {
+		return noop, nil
+	}
+
+	var exporter sdkTrace.SpanExporter
+	var err error
+	switch strings.ToLower(configuration.Exporter) {
+	case provider.TraceExporterNone, "":
+		return noop, nil
+	case provider.TraceExporterStdout:
+		exporter, err = stdouttrace.New()
+	case provider.TraceExporterOtlp:
+		exporter, err = newOtlpExporter(ctx, configuration)
+	default:
+		return nil, configIO.Errorf("tracing: exportador desconhecido %q", configuration.Exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tracerProvider := sdkTrace.NewTracerProvider(
+		sdkTrace.WithBatcher(exporter),
+		sdkTrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(configuration.ServiceName))),
+		sdkTrace.WithSampler(sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(configuration.SampleRatio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	return tracerProvider.Shutdown, nil
+}
+
+func newOtlpExporter(ctx context.Context, configuration *provider.TracingConfig) (sdkTrace.SpanExporter, error) {
+	var options []otlptracehttp.Option
+	if configuration.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(configuration.Endpoint))
+	}
+	if configuration.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	return otlptracehttp.New(ctx, options...)
+}
+
+// Start starts a span child of the one in ctx, on the global tracer provider. Tests can install an
+// sdk/trace provider with a tracetest.SpanRecorder to inspect the spans.
+func Start(ctx context.Context, name string, options ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, options...)
+}
+
+// End records err, if any, as the span status and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,45 @@
+package validation
+
+import (
+	notify "PocGo/internal/domain/notification"
+	setJson "encoding/json"
+	"errors"
+	"io"
+	"strings"
+)
+
+// unknownFieldPrefix starts the message encoding/json gives for a member missing from the target struct.
+const unknownFieldPrefix = `json: unknown field "`
+
+// DecodeJSON decodes a single JSON value from reader into target, rejecting members target does not declare,
+// and validates it. Unknown members, values of the wrong type and rule failures are all reported as one
+// INVALID_DATA error of entity listing the failing fields.
+func DecodeJSON(reader io.Reader, target any, entity string) error {
+	decoder := setJson.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(target); err != nil {
+		if field, ok := decodeFieldError(err); ok {
+			return notify.CreateValidationNotification(entity, []notify.FieldError{field})
+		}
+		return notify.CreateCustomNotification(notify.InvalidData, entity, err)
+	}
+
+	if failures := Validate(target); len(failures) > 0 {
+		return notify.CreateValidationNotification(entity, failures)
+	}
+	return nil
+}
+
+// decodeFieldError maps the decoding errors tied to a single member to a field error.
+func decodeFieldError(err error) (notify.FieldError, bool) {
+	var typeError *setJson.UnmarshalTypeError
+	if errors.As(err, &typeError) && typeError.Field != "" {
+		return notify.FieldError{Field: typeError.Field, Message: "tipo inválido, esperado " + typeError.Type.String()}, true
+	}
+
+	if name, found := strings.CutPrefix(err.Error(), unknownFieldPrefix); found {
+		return notify.FieldError{Field: strings.TrimSuffix(name, `"`), Message: "campo desconhecido"}, true
+	}
+	return notify.FieldError{}, false
+}
@@ -0,0 +1,155 @@
+package validation
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	setJson "encoding/json"
+	configIO "fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TagName is the struct tag holding the comma-separated rules of a field.
+const TagName = "validate"
+
+// rule checks one field, returning the failure message or an empty string.
+type rule func(field reflect.Value, param string) string
+
+// rules are the names accepted in a validate tag:
+//
+//	required  the field must not be empty (blank strings and JSON null count as empty)
+//	omitempty skips the other rules when the field is empty
+//	max=N     strings up to N characters
+//	email     a bare address such as "user@example.com"
+//	guid      a GUID in one of the forms of values.ParseGUID, such as 8-4-4-4-12 or wrapped in braces
+//	oneof=a b one of the space-separated values
+//	status    a user status name or, for older clients, its number
+var rules = map[string]rule{
+	"required": func(field reflect.Value, _ string) string {
+		if isEmpty(field) {
+			return "é obrigatório"
+		}
+		return ""
+	},
+	"max": func(field reflect.Value, param string) string {
+		limit, err := strconv.Atoi(param)
+		if err != nil {
+			panic(configIO.Sprintf("validation: max inválido %q", param))
+		}
+		if utf8.RuneCountInString(field.String()) > limit {
+			return configIO.Sprintf("deve ter no máximo %d caracteres", limit)
+		}
+		return ""
+	},
+	"email": func(field reflect.Value, _ string) string {
+		if _, err := values.ParseEmail(field.String()); err != nil {
+			return "deve ser um email válido"
+		}
+		return ""
+	},
+	"guid": func(field reflect.Value, _ string) string {
+		if _, err := values.ParseGUID(field.String()); err != nil {
+			return "deve ser um GUID no formato xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+		}
+		return ""
+	},
+	"oneof": func(field reflect.Value, param string) string {
+		allowed := strings.Fields(param)
+		for _, value := range allowed {
+			if field.String() == value {
+				return ""
+			}
+		}
+		return "deve ser um de: " + strings.Join(allowed, ", ")
+	},
+	"status": func(field reflect.Value, _ string) string {
+		raw, _ := field.Interface().(setJson.RawMessage)
+		var status entity.UserStatus
+		if err := status.UnmarshalJSON(raw); err != nil || !status.IsValid() {
+			return "deve ser um de: pending, active, inactive, blocked, deleted"
+		}
+		return ""
+	},
+}
+
+// Validate checks value, a struct or a pointer to one, against the validate tags of its fields and returns
+// every failure, in field order. Fields are named by their JSON name and embedded structs are checked as part
+// of the outer one. An unknown rule is a programming error and panics.
+func Validate(value any) []notify.FieldError {
+	target := reflect.Indirect(reflect.ValueOf(value))
+	if target.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var failures []notify.FieldError
+	validateStruct(target, &failures)
+	return failures
+}
+
+func validateStruct(target reflect.Value, failures *[]notify.FieldError) {
+	targetType := target.Type()
+
+	for index := 0; index < targetType.NumField(); index++ {
+		fieldType := targetType.Field(index)
+		field := target.Field(index)
+
+		if fieldType.Anonymous && field.Kind() == reflect.Struct {
+			validateStruct(field, failures)
+			continue
+		}
+
+		tag := fieldType.Tag.Get(TagName)
+		if tag == "" || !fieldType.IsExported() {
+			continue
+		}
+
+		if message := validateField(field, tag); message != "" {
+			*failures = append(*failures, notify.FieldError{Field: jsonName(fieldType), Message: message})
+		}
+	}
+}
+
+// validateField applies the rules of tag in order, stopping at the first failure.
+func validateField(field reflect.Value, tag string) string {
+	for _, entry := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(entry), "=")
+
+		if name == "omitempty" {
+			if isEmpty(field) {
+				return ""
+			}
+			continue
+		}
+
+		check, exists := rules[name]
+		if !exists {
+			panic(configIO.Sprintf("validation: regra desconhecida %q", name))
+		}
+		if message := check(field, param); message != "" {
+			return message
+		}
+	}
+	return ""
+}
+
+func isEmpty(field reflect.Value) bool {
+	switch value := field.Interface().(type) {
+	case string:
+		return strings.TrimSpace(value) == ""
+	case setJson.RawMessage:
+		return len(value) == 0 || string(value) == "null"
+	default:
+		return field.IsZero()
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
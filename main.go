@@ -2,8 +2,9 @@ package main
 
 import (
 	bootstrap "PocGo/internal/bootStrap"
+	"PocGo/internal/logging"
 	"context"
-	logger "log"
+	"log/slog"
 	setIO "os"
 	"os/signal"
 	"syscall"
@@ -20,12 +21,21 @@ func main() {
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 		<-quit
 
+		// Readiness fails first, so the load balancer drains this instance while it stops.
+		app.Health.BeginShutdown()
 		app.StopScheduler()
 
 		cancel()
+
+		// A second signal skips what is left of the shutdown delay.
+		<-quit
+		_ = app.Server.Shutdown(context.Background())
 	}()
 
-	if err := app.Run(ctx); err != nil {
-		logger.Fatal("Erro ao executar aplicação:", err)
+	err := app.Run(ctx)
+	app.StopTracing()
+	if err != nil {
+		slog.Error("Erro ao executar aplicação", logging.KeyError, err)
+		setIO.Exit(1)
 	}
 }
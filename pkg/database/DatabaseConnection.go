@@ -2,12 +2,20 @@ package database
 
 import (
 	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
 	notify "PocGo/internal/domain/notification"
 	sqlDB "database/sql"
 	_ "github.com/denisenkom/go-mssqldb"
+	_ "modernc.org/sqlite"
 	"sync"
 )
 
+// driverNames maps the configured DB_DRIVER to the database/sql driver name.
+var driverNames = map[string]string{
+	provider.DriverSqlServer: "sqlserver",
+	provider.DriverSqlite:    "sqlite",
+}
+
 type Database struct {
 	db *sqlDB.DB
 	mu sync.RWMutex
@@ -18,7 +26,12 @@ func NewConnection(cfg *config.Config) (*Database, error) {
 		return nil, notify.CreateNotification(notify.ErrorConfigDb)
 	}
 
-	db, err := sqlDB.Open("sqlserver", cfg.Database.ConnectionString)
+	driverName, ok := driverNames[cfg.Database.Driver]
+	if !ok {
+		return nil, notify.CreateCustomNotification(notify.ErrorUnknownDriver, "", cfg.Database.Driver)
+	}
+
+	db, err := sqlDB.Open(driverName, cfg.Database.ConnectionString)
 	if err != nil {
 		return nil, notify.CreateSimpleNotification(notify.ErrorOpenConnection, err)
 	}
@@ -49,3 +62,8 @@ func (d *Database) Close() error {
 	defer d.mu.Unlock()
 	return d.db.Close()
 }
+
+// Stats returns the connection pool statistics, exposed on /metrics.
+func (d *Database) Stats() sqlDB.DBStats {
+	return d.GetConnection().Stats()
+}
@@ -2,6 +2,7 @@ package helpers
 
 import (
 	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
 	"fmt"
 	"reflect"
 	"testing"
@@ -57,9 +58,23 @@ func AssertNoError(t *testing.T, err error, message string) {
 	}
 }
 
+// TestGuid returns the GUID of the test user labelled id, a short hexadecimal number such as "1" or "999".
+func TestGuid(id string) values.GUID {
+	return values.MustParseGUID(fmt.Sprintf("00000000-0000-0000-0000-%012s", id))
+}
+
+// TestGuidStrings returns the text form of TestGuid for each id, as reported by batch results.
+func TestGuidStrings(ids ...string) []string {
+	guids := make([]string, len(ids))
+	for index, id := range ids {
+		guids[index] = TestGuid(id).String()
+	}
+	return guids
+}
+
 func CreateTestUser(id string) *entity.User {
 	return &entity.User{
-		ID:     id,
+		ID:     TestGuid(id),
 		Name:   fmt.Sprintf("Test User %s", id),
 		Email:  fmt.Sprintf("user%s@example.com", id),
 		Status: 1,
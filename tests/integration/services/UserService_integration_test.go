@@ -2,10 +2,10 @@ package services_test
 
 import (
 	entity "PocGo/internal/domain/entities"
-	repository "PocGo/internal/repositories"
 	service "PocGo/internal/services"
 	"PocGo/tests/helpers"
 	"PocGo/tests/integration/testutils"
+	"context"
 	"testing"
 )
 
@@ -16,11 +16,10 @@ func TestUserService_Integration_GetById(t *testing.T) {
 		// Arrange
 		testUser := db.GetTestUser(t, "90FFA97D-110F-4BCE-C6EB-08DDB9C2DAB7")
 
-		userRepo := repository.NewUserRepository(db.DB)
-		userService := service.NewUserService(userRepo)
+		userService := service.NewUserService(db.UserRepo, nil, nil)
 
 		// Act
-		user, err := userService.GetById(testUser.ID)
+		user, err := userService.GetById(context.Background(), testUser.ID.String())
 
 		// Assert
 		helpers.AssertNoError(t, err, "Não deve retornar um erro")
@@ -48,11 +47,10 @@ func TestUserService_Integration_GetAll(t *testing.T) {
 			return
 		}
 
-		userRepo := repository.NewUserRepository(db.DB)
-		userService := service.NewUserService(userRepo)
+		userService := service.NewUserService(db.UserRepo, nil, nil)
 
 		// Act
-		users, err := userService.GetAll("")
+		users, err := userService.GetAll(context.Background(), "")
 
 		// Assert
 		helpers.AssertNoError(t, err, "Não deve retornar um erro")
@@ -87,8 +85,7 @@ func TestUserService_Integration_Update(t *testing.T) {
 		originalEmail := testUser.Email
 		originalStatus := testUser.Status
 
-		userRepo := repository.NewUserRepository(db.DB)
-		userService := service.NewUserService(userRepo)
+		userService := service.NewUserService(db.UserRepo, nil, nil)
 
 		updateUser := &entity.User{
 			ID:     testUser.ID,
@@ -98,12 +95,12 @@ func TestUserService_Integration_Update(t *testing.T) {
 		}
 
 		// Act
-		err := userService.Update(updateUser)
+		err := userService.Update(context.Background(), updateUser)
 
 		// Assert
 		helpers.AssertNoError(t, err, "Não deve retornar um erro")
 
-		updatedUser, err := userService.GetById(testUser.ID)
+		updatedUser, err := userService.GetById(context.Background(), testUser.ID.String())
 		helpers.AssertNoError(t, err, "Não deve retornar um erro ao recuperar o usuário atualizado")
 		helpers.AssertNotNil(t, updatedUser, "Usuário atualizado não deve ser nulo")
 		helpers.AssertEqual(t, updateUser.Name, updatedUser.Name, "Nome do usuário deve ser atualizado")
@@ -117,7 +114,7 @@ func TestUserService_Integration_Update(t *testing.T) {
 			Status: originalStatus,
 		}
 
-		err = userService.Update(restoreUser)
+		err = userService.Update(context.Background(), restoreUser)
 		if err != nil {
 			t.Logf("Aviso: Falha ao restaurar valores originais do usuário: %v", err)
 		}
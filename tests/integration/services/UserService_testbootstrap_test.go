@@ -4,6 +4,7 @@ import (
 	entity "PocGo/internal/domain/entities"
 	"PocGo/tests/helpers"
 	"PocGo/tests/integration/testutils"
+	"context"
 	"testing"
 )
 
@@ -18,7 +19,7 @@ func TestUserService_TestBootstrap_GetById(t *testing.T) {
 		}
 
 		// Act
-		user, err := app.Services.User.GetById(testUser.ID)
+		user, err := app.Services.User.GetById(context.Background(), testUser.ID.String())
 
 		// Assert
 		helpers.AssertNoError(t, err, "Should not return an error")
@@ -39,7 +40,7 @@ func TestUserService_TestBootstrap_GetAll(t *testing.T) {
 		}
 
 		// Act
-		users, err := app.Services.User.GetAll("")
+		users, err := app.Services.User.GetAll(context.Background(), "")
 
 		// Assert
 		helpers.AssertNoError(t, err, "Should not return an error")
@@ -95,12 +96,12 @@ func TestUserService_TestBootstrap_Update(t *testing.T) {
 		}
 
 		// Act
-		err := app.Services.User.Update(updateUser)
+		err := app.Services.User.Update(context.Background(), updateUser)
 
 		// Assert
 		helpers.AssertNoError(t, err, "Should not return an error")
 
-		updatedUser, err := app.Services.User.GetById(testUser.ID)
+		updatedUser, err := app.Services.User.GetById(context.Background(), testUser.ID.String())
 		helpers.AssertNoError(t, err, "Should not return an error when retrieving the updated user")
 		helpers.AssertNotNil(t, updatedUser, "Updated user should not be nil")
 		helpers.AssertEqual(t, updateUser.Name, updatedUser.Name, "User name should be updated")
@@ -114,7 +115,7 @@ func TestUserService_TestBootstrap_Update(t *testing.T) {
 			Status: originalStatus,
 		}
 
-		err = app.Services.User.Update(restoreUser)
+		err = app.Services.User.Update(context.Background(), restoreUser)
 		if err != nil {
 			t.Logf("Warning: Failed to restore original user values: %v", err)
 		}
@@ -2,42 +2,53 @@ package testutils
 
 import (
 	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
 	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
+	migration "PocGo/internal/migrations"
 	repository "PocGo/internal/repositories"
 	dataBaseConnection "PocGo/pkg/database"
+	"context"
 	dbProvider "database/sql"
 	"log"
+	"strings"
 	"testing"
+	"time"
 )
 
 type TestUser struct {
-	ID     string
+	ID     values.GUID
 	Name   string
 	Email  string
-	Status int
+	Status entity.UserStatus
 }
 
 var TestUserRegistry = map[string]TestUser{
 	"user 1": {
-		ID:     "90FFA97D-110F-4BCE-C6EB-08DDB9C2DAB7",
+		ID:     values.MustParseGUID("90FFA97D-110F-4BCE-C6EB-08DDB9C2DAB7"),
 		Name:   "TESTE1@GMAIL.COM",
 		Email:  "Teste1@gmail.com",
 		Status: 1,
 	},
 	"user 2": {
-		ID:     "D64DF1EC-E446-440C-C6EC-08DDB9C2DAB7",
+		ID:     values.MustParseGUID("D64DF1EC-E446-440C-C6EC-08DDB9C2DAB7"),
 		Name:   "TESTE2@GMAIL.COM",
 		Email:  "Teste2@gmail.com",
 		Status: 1,
 	},
 	"user 3": {
-		ID:     "F8E852CA-1D6A-4253-C6ED-08DDB9C2DAB7",
+		ID:     values.MustParseGUID("F8E852CA-1D6A-4253-C6ED-08DDB9C2DAB7"),
 		Name:   "TESTE3@GMAIL.COM",
 		Email:  "Teste3@gmail.com",
 		Status: 1,
 	},
 }
 
+// testUserAliases maps the user names documented in tests/TESTING.md to TestUserRegistry keys.
+var testUserAliases = map[string]string{
+	"standard": "user 1",
+}
+
 type TestDB struct {
 	DB            *dbProvider.DB
 	Config        *config.Config
@@ -45,23 +56,107 @@ type TestDB struct {
 	ExistingUsers map[string]*entity.User
 }
 
+// OpenTestBackend opens the backend selected by DB_DRIVER in the test configuration.
+// The returned Database is nil for backends that do not use a connection.
+func OpenTestBackend(configuration *config.Config) (*dataBaseConnection.Database, *repository.Repositories, error) {
+	backend, err := repository.GetBackend(configuration.Database.Driver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dbInstance *dataBaseConnection.Database
+	var connection *dbProvider.DB
+
+	if backend.RequiresConnection {
+		dbInstance, err = dataBaseConnection.NewConnection(configuration)
+		if err != nil {
+			return nil, nil, err
+		}
+		connection = dbInstance.GetConnection()
+
+		if err := MigrateTestDatabase(configuration.Database.Driver, connection); err != nil {
+			return dbInstance, nil, err
+		}
+	}
+
+	repos, err := repository.NewRepositories(configuration.Database.Driver, connection, configuration.Timeout, nil)
+	if err != nil {
+		return dbInstance, nil, err
+	}
+
+	if configuration.Database.Driver == provider.DriverSqlite {
+		if err := seedSqliteTestUsers(connection); err != nil {
+			return dbInstance, nil, err
+		}
+	}
+
+	SeedTestUsers(repos.User)
+
+	return dbInstance, repos, nil
+}
+
+// MigrateTestDatabase applies the embedded migrations, so an empty test database can be used.
+func MigrateTestDatabase(driver string, db *dbProvider.DB) error {
+	runner, err := migration.NewRunner(driver, db, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = runner.Up(context.Background())
+	return err
+}
+
+// SeedTestUsers loads the TestUserRegistry into in-memory repositories.
+// Backends with a real database are expected to already contain these users.
+func SeedTestUsers(userRepo repository.UserRepository) {
+	memoryRepo, ok := userRepo.(*repository.MemoryUserRepository)
+	if !ok {
+		return
+	}
+
+	for _, testUser := range TestUserRegistry {
+		memoryRepo.Seed(entity.User{
+			ID:     testUser.ID,
+			Name:   testUser.Name,
+			Email:  testUser.Email,
+			Status: testUser.Status,
+		}, time.Now().AddDate(-1, 0, 0))
+	}
+}
+
+// seedSqliteTestUsers inserts the TestUserRegistry into a freshly migrated SQLite database.
+func seedSqliteTestUsers(db *dbProvider.DB) error {
+	for _, testUser := range TestUserRegistry {
+		_, err := db.Exec(
+			`INSERT OR IGNORE INTO auth_user (id, normalized_login, login, status, creation_date)
+			 VALUES (?, ?, ?, ?, datetime('now', '-1 year'))`,
+			testUser.ID, testUser.Name, testUser.Email, testUser.Status)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func SetupTestDB(t *testing.T) *TestDB {
 	t.Helper()
 
 	configuration := config.LoadConfig("test")
 
-	dbInstance, err := dataBaseConnection.NewConnection(configuration)
+	dbInstance, repos, err := OpenTestBackend(configuration)
 	if err != nil {
 		t.Fatalf("Falha ao conectar ao banco de dados de teste: %v", err)
 	}
 
 	testDB := &TestDB{
-		DB:            dbInstance.GetConnection(),
 		Config:        configuration,
+		UserRepo:      repos.User,
 		ExistingUsers: make(map[string]*entity.User),
 	}
 
-	testDB.UserRepo = repository.NewUserRepository(testDB.DB)
+	if dbInstance != nil {
+		testDB.DB = dbInstance.GetConnection()
+	}
 
 	testDB.VerifyTestUsers(t)
 
@@ -89,7 +184,7 @@ func (tdb *TestDB) VerifyTestUsers(t *testing.T) {
 	t.Helper()
 
 	for key, testUser := range TestUserRegistry {
-		user, err := tdb.UserRepo.FindById(testUser.ID)
+		user, err := tdb.UserRepo.FindById(context.Background(), testUser.ID)
 
 		if err != nil {
 			t.Logf("Aviso: Usuário de teste '%s' com ID '%s' não encontrado no banco de dados. Alguns testes podem falhar.", key, testUser.ID)
@@ -108,8 +203,18 @@ func (tdb *TestDB) VerifyTestUsers(t *testing.T) {
 func (tdb *TestDB) GetTestUser(t *testing.T, key string) *entity.User {
 	t.Helper()
 
+	if alias, ok := testUserAliases[key]; ok {
+		key = alias
+	}
+
 	user, exists := tdb.ExistingUsers[key]
 	if !exists {
+		for _, existingUser := range tdb.ExistingUsers {
+			if strings.EqualFold(existingUser.ID.String(), key) {
+				return existingUser
+			}
+		}
+
 		t.Fatalf("Usuário de teste '%s' não encontrado no mapa ExistingUsers. Certifique-se de que ele existe no banco de dados e foi carregado durante VerifyTestUsers.", key)
 	}
 
@@ -133,6 +238,16 @@ func SkipIfNoDatabase(t *testing.T) {
 
 	configuration := config.LoadConfig("test")
 
+	backend, err := repository.GetBackend(configuration.Database.Driver)
+	if err != nil {
+		t.Skip("Pulando teste devido a driver de banco de dados inválido:", err)
+		return
+	}
+
+	if !backend.RequiresConnection {
+		return
+	}
+
 	db, err := dataBaseConnection.NewConnection(configuration)
 	if err != nil {
 		t.Skip("Pulando teste devido a erro de conexão com o banco de dados:", err)
@@ -153,10 +268,15 @@ func SkipIfNoDatabase(t *testing.T) {
 		t.Skip("Pulando teste devido a erro de ping no banco de dados:", err)
 	}
 
-	userRepo := repository.NewUserRepository(db.GetConnection())
+	repos, err := repository.NewRepositories(configuration.Database.Driver, db.GetConnection(), configuration.Timeout, nil)
+	if err != nil {
+		t.Skip("Pulando teste devido a erro ao iniciar os repositórios:", err)
+		return
+	}
+	userRepo := repos.User
 
 	for key, testUser := range TestUserRegistry {
-		_, err := userRepo.FindById(testUser.ID)
+		_, err := userRepo.FindById(context.Background(), testUser.ID)
 		if err != nil {
 			t.Logf("Aviso: Usuário de teste '%s' com ID '%s' não encontrado no banco de dados.", key, testUser.ID)
 		}
@@ -3,9 +3,11 @@ package testutils
 import (
 	config "PocGo/internal/configuration"
 	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
 	repository "PocGo/internal/repositories"
 	service "PocGo/internal/services"
 	dataBaseConnection "PocGo/pkg/database"
+	"context"
 	dbProvider "database/sql"
 	"sync"
 	"testing"
@@ -26,38 +28,31 @@ func NewTestApplication(t *testing.T) *TestApplication {
 
 	configuration := config.LoadConfig("test")
 
-	dbInstance, err := dataBaseConnection.NewConnection(configuration)
+	dbInstance, repos, err := OpenTestBackend(configuration)
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
 
 	app := &TestApplication{
 		Configuration: configuration,
-		Database:      dbInstance.GetConnection(),
+		Repositories:  repos,
 		t:             t,
 		dbInstance:    dbInstance,
 	}
 
-	app.setupRepositories()
+	if dbInstance != nil {
+		app.Database = dbInstance.GetConnection()
+	}
+
 	app.setupServices()
 
 	return app
 }
 
-func (app *TestApplication) setupRepositories() {
-	app.t.Helper()
-
-	repos, err := repository.NewRepositories(app.Database)
-	if err != nil {
-		app.t.Fatalf("Failed to create repositories: %v", err)
-	}
-	app.Repositories = repos
-}
-
 func (app *TestApplication) setupServices() {
 	app.t.Helper()
 
-	app.Services = service.NewServices(app.Repositories)
+	app.Services = service.NewServices(app.Repositories, nil, nil, nil)
 }
 
 func (app *TestApplication) Cleanup() {
@@ -90,8 +85,8 @@ func (app *TestApplication) GetTestUser(t *testing.T, key string) (*entity.User,
 		ExistingUsers: make(map[string]*entity.User),
 	}
 
-	if len(key) == 36 {
-		return app.Repositories.User.FindById(key)
+	if id, err := values.ParseGUID(key); err == nil {
+		return app.Repositories.User.FindById(context.Background(), id)
 	}
 
 	testDB.VerifyTestUsers(t)
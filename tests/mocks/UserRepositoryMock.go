@@ -2,34 +2,52 @@ package mocks
 
 import (
 	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/domain/values"
+	repository "PocGo/internal/repositories"
+	"context"
 	"errors"
 )
 
 type UserRepositoryMock struct {
-	FindByIdFunc     func(id string) (*entity.User, error)
-	FindAllFunc      func(date string) (*[]entity.User, error)
-	UpdateFunc       func(user *entity.User) error
-	FindOldUsersFunc func() (*[]entity.User, error)
-	UpdateStatusFunc func(id string, status int) error
-
-	FindByIdCalls     []string
-	FindAllCalls      []string
-	UpdateCalls       []*entity.User
-	FindOldUsersCalls int
-	UpdateStatusCalls map[string]int
+	FindByIdFunc          func(id values.GUID) (*entity.User, error)
+	FindAllFunc           func(date string) (*[]entity.User, error)
+	ListFunc              func(query repository.UserListQuery) (*repository.UserPage, error)
+	UpdateFunc            func(user *entity.User) error
+	UpdateStatusFunc      func(id values.GUID, status entity.UserStatus) error
+	UpdateStatusBatchFunc func(batch repository.StatusBatch) (repository.BatchResult, error)
+	CreateFunc            func(user *entity.User) error
+	DeleteFunc            func(id values.GUID) error
+	RestoreFunc           func(id values.GUID) error
+	ReactivateFunc        func(id values.GUID) error
+	ExistsByEmailFunc     func(email string) (bool, error)
+	ExistsByLoginFunc     func(normalizedLogin string) (bool, error)
+
+	FindByIdCalls          []values.GUID
+	FindAllCalls           []string
+	ListCalls              []repository.UserListQuery
+	UpdateCalls            []*entity.User
+	UpdateStatusCalls      map[values.GUID]entity.UserStatus
+	UpdateStatusBatchCalls []repository.StatusBatch
+	CreateCalls            []*entity.User
+	DeleteCalls            []values.GUID
+	RestoreCalls           []values.GUID
+	ReactivateCalls        []values.GUID
 }
 
 func NewUserRepositoryMock() *UserRepositoryMock {
 	return &UserRepositoryMock{
-		FindByIdCalls:     []string{},
+		FindByIdCalls:     []values.GUID{},
 		FindAllCalls:      []string{},
 		UpdateCalls:       []*entity.User{},
-		FindOldUsersCalls: 0,
-		UpdateStatusCalls: make(map[string]int),
+		UpdateStatusCalls: make(map[values.GUID]entity.UserStatus),
+		CreateCalls:       []*entity.User{},
+		DeleteCalls:       []values.GUID{},
+		RestoreCalls:      []values.GUID{},
+		ReactivateCalls:   []values.GUID{},
 	}
 }
 
-func (mock *UserRepositoryMock) FindById(id string) (*entity.User, error) {
+func (mock *UserRepositoryMock) FindById(_ context.Context, id values.GUID) (*entity.User, error) {
 	mock.FindByIdCalls = append(mock.FindByIdCalls, id)
 	if mock.FindByIdFunc != nil {
 		return mock.FindByIdFunc(id)
@@ -37,7 +55,7 @@ func (mock *UserRepositoryMock) FindById(id string) (*entity.User, error) {
 	return nil, errors.New("FindByIdFunc not implemented")
 }
 
-func (mock *UserRepositoryMock) FindAll(date string) (*[]entity.User, error) {
+func (mock *UserRepositoryMock) FindAll(_ context.Context, date string) (*[]entity.User, error) {
 	mock.FindAllCalls = append(mock.FindAllCalls, date)
 	if mock.FindAllFunc != nil {
 		return mock.FindAllFunc(date)
@@ -45,7 +63,15 @@ func (mock *UserRepositoryMock) FindAll(date string) (*[]entity.User, error) {
 	return nil, errors.New("FindAllFunc not implemented")
 }
 
-func (mock *UserRepositoryMock) Update(user *entity.User) error {
+func (mock *UserRepositoryMock) List(_ context.Context, query repository.UserListQuery) (*repository.UserPage, error) {
+	mock.ListCalls = append(mock.ListCalls, query)
+	if mock.ListFunc != nil {
+		return mock.ListFunc(query)
+	}
+	return nil, errors.New("ListFunc not implemented")
+}
+
+func (mock *UserRepositoryMock) Update(_ context.Context, user *entity.User) error {
 	mock.UpdateCalls = append(mock.UpdateCalls, user)
 	if mock.UpdateFunc != nil {
 		return mock.UpdateFunc(user)
@@ -53,18 +79,64 @@ func (mock *UserRepositoryMock) Update(user *entity.User) error {
 	return errors.New("UpdateFunc not implemented")
 }
 
-func (mock *UserRepositoryMock) FindOldUsers() (*[]entity.User, error) {
-	mock.FindOldUsersCalls++
-	if mock.FindOldUsersFunc != nil {
-		return mock.FindOldUsersFunc()
+func (mock *UserRepositoryMock) UpdateStatusBatch(_ context.Context, batch repository.StatusBatch) (repository.BatchResult, error) {
+	mock.UpdateStatusBatchCalls = append(mock.UpdateStatusBatchCalls, batch)
+	if mock.UpdateStatusBatchFunc != nil {
+		return mock.UpdateStatusBatchFunc(batch)
 	}
-	return nil, errors.New("FindOldUsersFunc not implemented")
+	return repository.BatchResult{}, errors.New("UpdateStatusBatchFunc not implemented")
 }
 
-func (mock *UserRepositoryMock) UpdateStatus(id string, status int) error {
+func (mock *UserRepositoryMock) UpdateStatus(_ context.Context, id values.GUID, status entity.UserStatus) error {
 	mock.UpdateStatusCalls[id] = status
 	if mock.UpdateStatusFunc != nil {
 		return mock.UpdateStatusFunc(id, status)
 	}
 	return errors.New("UpdateStatusFunc not implemented")
 }
+
+func (mock *UserRepositoryMock) Create(_ context.Context, user *entity.User) error {
+	mock.CreateCalls = append(mock.CreateCalls, user)
+	if mock.CreateFunc != nil {
+		return mock.CreateFunc(user)
+	}
+	return errors.New("CreateFunc not implemented")
+}
+
+func (mock *UserRepositoryMock) Delete(_ context.Context, id values.GUID) error {
+	mock.DeleteCalls = append(mock.DeleteCalls, id)
+	if mock.DeleteFunc != nil {
+		return mock.DeleteFunc(id)
+	}
+	return errors.New("DeleteFunc not implemented")
+}
+
+func (mock *UserRepositoryMock) Restore(_ context.Context, id values.GUID) error {
+	mock.RestoreCalls = append(mock.RestoreCalls, id)
+	if mock.RestoreFunc != nil {
+		return mock.RestoreFunc(id)
+	}
+	return errors.New("RestoreFunc not implemented")
+}
+
+func (mock *UserRepositoryMock) Reactivate(_ context.Context, id values.GUID) error {
+	mock.ReactivateCalls = append(mock.ReactivateCalls, id)
+	if mock.ReactivateFunc != nil {
+		return mock.ReactivateFunc(id)
+	}
+	return errors.New("ReactivateFunc not implemented")
+}
+
+func (mock *UserRepositoryMock) ExistsByEmail(_ context.Context, email string) (bool, error) {
+	if mock.ExistsByEmailFunc != nil {
+		return mock.ExistsByEmailFunc(email)
+	}
+	return false, nil
+}
+
+func (mock *UserRepositoryMock) ExistsByLogin(_ context.Context, normalizedLogin string) (bool, error) {
+	if mock.ExistsByLoginFunc != nil {
+		return mock.ExistsByLoginFunc(normalizedLogin)
+	}
+	return false, nil
+}
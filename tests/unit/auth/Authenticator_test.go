@@ -0,0 +1,270 @@
+package auth_test
+
+import (
+	"PocGo/internal/auth"
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	setJson "encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	httpclient "net/http"
+	"net/http/httptest"
+	setIO "os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	testSecret   = "0123456789abcdef0123456789abcdef"
+	testIssuer   = "https://issuer.example"
+	testAudience = "pocgo-api"
+)
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "user-1",
+		"name":  "Maria",
+		"roles": []string{"admin"},
+		"iss":   testIssuer,
+		"aud":   testAudience,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func with(claims jwt.MapClaims, key string, value any) jwt.MapClaims {
+	if value == nil {
+		delete(claims, key)
+	} else {
+		claims[key] = value
+	}
+	return claims
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+// writeJwks writes the public part of key as a JSON Web Key Set named kid and returns its path.
+func writeJwks(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	t.Helper()
+
+	encode := func(value *big.Int) string { return base64.RawURLEncoding.EncodeToString(value.Bytes()) }
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "EC", "kid": "ignored", "crv": "P-256"},
+		{"kty": "RSA", "kid": kid, "use": "sig", "n": encode(key.N), "e": encode(big.NewInt(int64(key.E)))},
+	}}
+	content, _ := setJson.Marshal(set)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := setIO.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("Failed to write JWKS: %v", err)
+	}
+	return path
+}
+
+func TestJwtVerifier_Verify(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	verifier, err := auth.NewJwtVerifier(&provider.AuthConfig{
+		JwtSecret: testSecret,
+		JwksFile:  writeJwks(t, "key-1", rsaKey),
+		Issuer:    testIssuer,
+		Audience:  testAudience,
+	})
+	helpers.AssertNoError(t, err, "Verifier should be created")
+
+	tests := []struct {
+		name        string
+		token       string
+		expectError bool
+	}{
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims())},
+		{name: "RS256 from the JWKS", token: sign(t, jwt.SigningMethodRS256, rsaKey, "key-1", validClaims())},
+		{name: "RS256 without kid, single key", token: sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims())},
+		{name: "Error - Expired", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", with(validClaims(), "exp", time.Now().Add(-time.Hour).Unix())), expectError: true},
+		{name: "Error - Without exp", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", with(validClaims(), "exp", nil)), expectError: true},
+		{name: "Error - Without sub", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", with(validClaims(), "sub", nil)), expectError: true},
+		{name: "Error - Other issuer", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", with(validClaims(), "iss", "https://evil.example")), expectError: true},
+		{name: "Error - Other audience", token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", with(validClaims(), "aud", "other-api")), expectError: true},
+		{name: "Error - Wrong secret", token: sign(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret-xx"), "", validClaims()), expectError: true},
+		{name: "Error - Unknown kid", token: sign(t, jwt.SigningMethodRS256, rsaKey, "key-2", validClaims()), expectError: true},
+		{name: "Error - Key outside the JWKS", token: sign(t, jwt.SigningMethodRS256, otherKey, "key-1", validClaims()), expectError: true},
+		{name: "Error - Algorithm not configured", token: sign(t, jwt.SigningMethodHS384, []byte(testSecret), "", validClaims()), expectError: true},
+		{name: "Error - Unsigned", token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims()), expectError: true},
+		{name: "Error - Malformed", token: "not-a-token", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			principal, err := verifier.Verify(tt.token)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertError(t, err, "Token should be rejected")
+				return
+			}
+			helpers.AssertNoError(t, err, "Token should be accepted")
+			helpers.AssertEqual(t, auth.Principal{Subject: "user-1", Name: "Maria", Kind: auth.PrincipalUser, Roles: []string{"admin"}},
+				principal, "Principal should come from the claims")
+		})
+	}
+}
+
+func TestNewJwtVerifier(t *testing.T) {
+	tests := []struct {
+		name          string
+		configuration provider.AuthConfig
+		expectNil     bool
+		expectError   bool
+	}{
+		{name: "No key configured", expectNil: true},
+		{name: "Secret", configuration: provider.AuthConfig{JwtSecret: testSecret}},
+		{name: "Error - Short secret", configuration: provider.AuthConfig{JwtSecret: "short"}, expectNil: true, expectError: true},
+		{name: "Error - Missing JWKS", configuration: provider.AuthConfig{JwksFile: filepath.Join(t.TempDir(), "missing.json")}, expectNil: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			verifier, err := auth.NewJwtVerifier(&tt.configuration)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectError, err != nil, "Failure should match")
+			helpers.AssertEqual(t, tt.expectNil, verifier == nil, "Verifier presence should match")
+		})
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) FindByHash(context.Context, string) (*entity.ApiKey, error) {
+	return nil, notify.CreateSimpleNotification(notify.FindErrorRepository, errors.New("connection reset"))
+}
+
+func TestAuthenticator_ApiKey(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	keys := repository.NewMemoryApiKeyRepository()
+	past := time.Now().Add(-time.Hour)
+
+	active, activeKey := auth.NewApiKey("billing", []string{"reader"}, nil, time.Now())
+	expired, expiredKey := auth.NewApiKey("old", nil, &past, time.Now().Add(-2*time.Hour))
+	revoked, revokedKey := auth.NewApiKey("leaked", nil, nil, time.Now())
+	for _, key := range []*entity.ApiKey{&activeKey, &expiredKey, &revokedKey} {
+		helpers.AssertNoError(t, keys.Create(ctx, key), "Key should be stored")
+	}
+	helpers.AssertNoError(t, keys.Revoke(ctx, revokedKey.ID, past), "Key should be revoked")
+
+	tests := []struct {
+		name         string
+		store        auth.ApiKeyStore
+		key          string
+		expectedCode string
+	}{
+		{name: "Active", store: keys, key: active},
+		{name: "Error - Expired", store: keys, key: expired, expectedCode: notify.CodeUnauthenticated},
+		{name: "Error - Revoked", store: keys, key: revoked, expectedCode: notify.CodeUnauthenticated},
+		{name: "Error - Unknown", store: keys, key: auth.ApiKeyPrefix + "unknown", expectedCode: notify.CodeUnauthenticated},
+		{name: "Error - Store unavailable", store: failingStore{}, key: active, expectedCode: notify.CodeFindError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest(httpclient.MethodGet, "/api/v1/users", nil)
+			request.Header.Set(auth.ApiKeyHeader, tt.key)
+
+			// Act
+			principal, err := auth.NewAuthenticator(nil, tt.store).Authenticate(request)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+				return
+			}
+			helpers.AssertNoError(t, err, "Key should be accepted")
+			helpers.AssertEqual(t, activeKey.ID.String(), principal.Subject, "Subject should be the key ID")
+			helpers.AssertEqual(t, auth.PrincipalApiKey, principal.Kind, "Kind should be API key")
+			helpers.AssertEqual(t, true, principal.HasRole("reader"), "Roles should come from the key")
+		})
+	}
+}
+
+func TestAuthenticator_Routes(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	key, apiKey := auth.NewApiKey("billing", nil, nil, time.Now())
+	_ = repos.ApiKey.Create(ctx, &apiKey)
+
+	verifier, _ := auth.NewJwtVerifier(&provider.AuthConfig{JwtSecret: testSecret})
+	authenticator := auth.NewAuthenticator(verifier, repos.ApiKey)
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	handler := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, nil, authenticator, nil).Handler()
+
+	tests := []struct {
+		name           string
+		path           string
+		header         string
+		value          string
+		expectedStatus int
+	}{
+		{name: "Public probe", path: "/health/live", expectedStatus: httpclient.StatusOK},
+		{name: "API key", path: "/api/v1/users", header: auth.ApiKeyHeader, value: key, expectedStatus: httpclient.StatusOK},
+		{name: "Bearer token", path: "/api/v1/users", header: "Authorization",
+			value: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", validClaims()), expectedStatus: httpclient.StatusOK},
+		{name: "Legacy route with API key", path: "/user/get_all_users", header: auth.ApiKeyHeader, value: key, expectedStatus: httpclient.StatusOK},
+		{name: "Error - No credentials", path: "/api/v1/users", expectedStatus: httpclient.StatusUnauthorized},
+		{name: "Error - Legacy route without credentials", path: "/user/get_all_users", expectedStatus: httpclient.StatusUnauthorized},
+		{name: "Error - Basic auth", path: "/api/v1/users", header: "Authorization", value: "Basic dXNlcjpwYXNz", expectedStatus: httpclient.StatusUnauthorized},
+		{name: "Error - Invalid key", path: "/api/v1/users", header: auth.ApiKeyHeader, value: "pocgo_invalid", expectedStatus: httpclient.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest(httpclient.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				request.Header.Set(tt.header, tt.value)
+			}
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status code should match")
+			if tt.expectedStatus == httpclient.StatusUnauthorized {
+				helpers.AssertEqual(t, `Bearer realm="PocGo"`, recorder.Header().Get("WWW-Authenticate"), "Should send the challenge")
+
+				var problem map[string]any
+				_ = setJson.NewDecoder(recorder.Body).Decode(&problem)
+				helpers.AssertEqual(t, notify.CodeUnauthenticated, problem["code"], "Problem code should match")
+			}
+		})
+	}
+}
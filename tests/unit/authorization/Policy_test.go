@@ -0,0 +1,173 @@
+package authorization_test
+
+import (
+	"PocGo/internal/auth"
+	"PocGo/internal/authorization"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"PocGo/tests/mocks"
+	"context"
+	"testing"
+)
+
+const testPolicy = `{
+	"roles": {
+		"admin": ["users:read", "users:create", "users:update", "users:update_status", "users:delete"],
+		"support": ["users:read"],
+		"self": ["users:read", "users:update"]
+	}
+}`
+
+func loadTestPolicy(t *testing.T) *authorization.Policy {
+	t.Helper()
+
+	policy, err := authorization.ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Should parse the test policy: %v", err)
+	}
+	return policy
+}
+
+func principal(id string, roles ...string) auth.Principal {
+	return auth.Principal{Subject: helpers.TestGuid(id).String(), Kind: auth.PrincipalUser, Roles: roles}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{name: "Valid policy", content: testPolicy},
+		{name: "Empty policy", content: `{}`},
+		{name: "Error - Unknown permission", content: `{"roles": {"admin": ["users:write"]}}`, wantErr: true},
+		{name: "Error - Malformed JSON", content: `{"roles": [`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			policy, err := authorization.ParsePolicy([]byte(tt.content))
+
+			// Assert
+			if tt.wantErr {
+				helpers.AssertError(t, err, "Should reject the policy")
+				return
+			}
+			helpers.AssertNoError(t, err, "Should parse the policy")
+			helpers.AssertNotNil(t, policy, "Policy should be returned")
+		})
+	}
+}
+
+func TestLoadPolicy_RepositoryFile(t *testing.T) {
+	// Act
+	policy, err := authorization.LoadPolicy("../../../authorization.json")
+
+	// Assert
+	helpers.AssertNoError(t, err, "The shipped policy should be valid")
+	helpers.AssertEqual(t, true, policy.Allows(principal("1", "admin"), authorization.JobsRun, ""), "Admin should run jobs")
+	helpers.AssertEqual(t, false, policy.Allows(principal("1", "support"), authorization.UsersDelete, helpers.TestGuid("2").String()), "Support should not delete users")
+	helpers.AssertEqual(t, false, policy.Allows(principal("1", "support"), authorization.UsersUpdateStatus, helpers.TestGuid("2").String()), "Only admin should change status")
+	helpers.AssertEqual(t, false, policy.Allows(principal("1"), authorization.UsersUpdateStatus, helpers.TestGuid("1").String()), "Only admin should change status")
+}
+
+func TestPolicy_Allows(t *testing.T) {
+	policy := loadTestPolicy(t)
+	own, other := helpers.TestGuid("1").String(), helpers.TestGuid("2").String()
+
+	tests := []struct {
+		name       string
+		principal  auth.Principal
+		permission authorization.Permission
+		owner      string
+		expected   bool
+	}{
+		{name: "Admin on another user", principal: principal("1", "admin"), permission: authorization.UsersDelete, owner: other, expected: true},
+		{name: "Support cannot change status", principal: principal("1", "support"), permission: authorization.UsersUpdateStatus, owner: other, expected: false},
+		{name: "Support cannot change name", principal: principal("1", "support"), permission: authorization.UsersUpdate, owner: other, expected: false},
+		{name: "Self updates own user", principal: principal("1"), permission: authorization.UsersUpdate, owner: own, expected: true},
+		{name: "Self ID in upper case", principal: principal("a"), permission: authorization.UsersRead, owner: "00000000-0000-0000-0000-00000000000A", expected: true},
+		{name: "Self cannot update another user", principal: principal("1"), permission: authorization.UsersUpdate, owner: other, expected: false},
+		{name: "Self cannot change own status", principal: principal("1"), permission: authorization.UsersUpdateStatus, owner: own, expected: false},
+		{name: "Self does not cover listings", principal: principal("1"), permission: authorization.UsersRead, owner: "", expected: false},
+		{name: "Self is not granted explicitly", principal: principal("1", authorization.RoleSelf), permission: authorization.UsersUpdate, owner: other, expected: false},
+		{name: "API key is never self", principal: auth.Principal{Subject: own, Kind: auth.PrincipalApiKey}, permission: authorization.UsersRead, owner: own, expected: false},
+		{name: "Unknown role", principal: principal("1", "auditor"), permission: authorization.UsersRead, owner: other, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			allowed := policy.Allows(tt.principal, tt.permission, tt.owner)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expected, allowed, "Permission should match the policy")
+		})
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	// Arrange
+	policy := loadTestPolicy(t)
+	denied := auth.WithPrincipal(context.Background(), principal("1"))
+
+	// Act
+	err := policy.Authorize(denied, authorization.UsersDelete, helpers.TestGuid("1").String())
+
+	// Assert
+	helpers.AssertEqual(t, notify.CodeForbidden, notify.CodeOf(err), "Should report FORBIDDEN")
+	helpers.AssertNoError(t, policy.Authorize(context.Background(), authorization.UsersDelete, ""), "Calls without a principal should not be restricted")
+	helpers.AssertNoError(t, (*authorization.Policy)(nil).Authorize(denied, authorization.UsersDelete, ""), "A nil policy should allow everything")
+}
+
+func TestUserService_UpdateAuthorization(t *testing.T) {
+	own, other := helpers.TestGuid("1"), helpers.TestGuid("2")
+
+	tests := []struct {
+		name         string
+		principal    auth.Principal
+		update       entity.User
+		expectedCode string
+	}{
+		{name: "Self changes own name", principal: principal("1"), update: entity.User{ID: own, Name: "Novo nome"}},
+		{name: "Self resends own status", principal: principal("1"), update: entity.User{ID: own, Name: "Novo nome", Status: entity.StatusActive}},
+		{name: "Admin changes another status", principal: principal("1", "admin"), update: entity.User{ID: other, Status: entity.StatusInactive}},
+		{name: "Error - Self changes own status", principal: principal("1"), update: entity.User{ID: own, Status: entity.StatusInactive}, expectedCode: notify.CodeForbidden},
+		{name: "Error - Self changes another user", principal: principal("1"), update: entity.User{ID: other, Name: "Novo nome"}, expectedCode: notify.CodeForbidden},
+		{name: "Error - Support changes another status", principal: principal("1", "support"), update: entity.User{ID: other, Status: entity.StatusInactive}, expectedCode: notify.CodeForbidden},
+		{name: "Error - Support changes name", principal: principal("1", "support"), update: entity.User{ID: other, Name: "Novo nome"}, expectedCode: notify.CodeForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
+				return &entity.User{ID: id, Name: "Nome", Email: "user@example.com", Status: entity.StatusActive}, nil
+			}
+			updated := false
+			mockRepo.UpdateFunc = func(user *entity.User) error {
+				updated = true
+				return nil
+			}
+			userService := service.NewUserService(mockRepo, nil, loadTestPolicy(t))
+			ctx := auth.WithPrincipal(context.Background(), tt.principal)
+
+			// Act
+			err := userService.Update(ctx, &tt.update)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+				helpers.AssertEqual(t, false, updated, "A denied update should not reach the repository")
+				return
+			}
+			helpers.AssertNoError(t, err, "Update should be allowed")
+			helpers.AssertEqual(t, true, updated, "User should be saved")
+		})
+	}
+}
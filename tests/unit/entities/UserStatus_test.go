@@ -0,0 +1,99 @@
+package entities_test
+
+import (
+	entity "PocGo/internal/domain/entities"
+	"PocGo/tests/helpers"
+	setJson "encoding/json"
+	"testing"
+)
+
+func TestUserStatus_MarshalJSON(t *testing.T) {
+	// Arrange
+	user := entity.User{ID: helpers.TestGuid("1"), Status: entity.StatusBlocked}
+
+	// Act
+	body, err := setJson.Marshal(user)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Should not return an error")
+	helpers.AssertEqual(t, `{"id":"00000000-0000-0000-0000-000000000001","name":"","email":"","status":"blocked"}`, string(body), "Status should be written as text")
+}
+
+func TestUserStatus_MarshalJSON_UnknownValue(t *testing.T) {
+	// Arrange
+	users := []entity.User{
+		{ID: helpers.TestGuid("1"), Status: entity.StatusActive},
+		{ID: helpers.TestGuid("2"), Status: entity.UserStatus(42)},
+	}
+
+	// Act
+	body, err := setJson.Marshal(users)
+
+	// Assert
+	helpers.AssertNoError(t, err, "An unknown status should not fail the whole list")
+	helpers.AssertEqual(t, `[{"id":"00000000-0000-0000-0000-000000000001","name":"","email":"","status":"active"},`+
+		`{"id":"00000000-0000-0000-0000-000000000002","name":"","email":"","status":"unknown"}]`, string(body), "Unknown status should be written as unknown")
+}
+
+func TestUserStatus_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus entity.UserStatus
+		expectError    bool
+	}{
+		{name: "Success - Name", body: `{"status":"inactive"}`, expectedStatus: entity.StatusInactive},
+		{name: "Success - Name is case insensitive", body: `{"status":"Pending"}`, expectedStatus: entity.StatusPending},
+		{name: "Success - Legacy number", body: `{"status":2}`, expectedStatus: entity.StatusInactive},
+		{name: "Success - Omitted", body: `{}`, expectedStatus: entity.StatusUndefined},
+		{name: "Error - Unknown name", body: `{"status":"archived"}`, expectError: true},
+		{name: "Error - Unknown number", body: `{"status":42}`, expectError: true},
+		{name: "Error - Wrong type", body: `{"status":true}`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var user entity.User
+
+			// Act
+			err := setJson.Unmarshal([]byte(tt.body), &user)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertError(t, err, "Should return an error")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, tt.expectedStatus, user.Status, "Status should match expected")
+			}
+		})
+	}
+}
+
+func TestUserStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     entity.UserStatus
+		to       entity.UserStatus
+		expected bool
+	}{
+		{name: "New user as pending", from: entity.StatusUndefined, to: entity.StatusPending, expected: true},
+		{name: "New user as blocked", from: entity.StatusUndefined, to: entity.StatusBlocked, expected: false},
+		{name: "Active to inactive", from: entity.StatusActive, to: entity.StatusInactive, expected: true},
+		{name: "Pending to inactive", from: entity.StatusPending, to: entity.StatusInactive, expected: false},
+		{name: "Blocked to active", from: entity.StatusBlocked, to: entity.StatusActive, expected: true},
+		{name: "Deleted to inactive", from: entity.StatusDeleted, to: entity.StatusInactive, expected: true},
+		{name: "Deleted to active", from: entity.StatusDeleted, to: entity.StatusActive, expected: false},
+		{name: "Same state", from: entity.StatusInactive, to: entity.StatusInactive, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			allowed := tt.from.CanTransitionTo(tt.to)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expected, allowed, "Transition result should match expected")
+		})
+	}
+}
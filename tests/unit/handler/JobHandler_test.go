@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"context"
+	setJson "encoding/json"
+	httpclient "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newAdminTestServer(t *testing.T) (httpclient.Handler, *scheduler.Scheduler) {
+	t.Helper()
+
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC, Recorder: repos.JobRun, Locker: repos.Locker})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:     "sweep",
+		Schedule: "@daily",
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			run.Processed = 1
+			return nil
+		},
+	})
+	jobScheduler.Start(context.Background())
+	t.Cleanup(func() { _ = jobScheduler.Stop(context.Background()) })
+
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	server := applicationServer.NewServer(service.NewServices(repos, jobScheduler, nil, nil), configuration, nil, nil, nil, nil, nil)
+	return server.Handler(), jobScheduler
+}
+
+func TestJobHandler_TriggerAndListRuns(t *testing.T) {
+	// Arrange
+	handler, jobScheduler := newAdminTestServer(t)
+
+	// Act
+	triggerRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(triggerRecorder, httptest.NewRequest(httpclient.MethodPost, "/admin/jobs/sweep/trigger?dryRun=true", nil))
+	_ = jobScheduler.Stop(context.Background())
+
+	runsRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(runsRecorder, httptest.NewRequest(httpclient.MethodGet, "/admin/jobs/sweep/runs?limit=5", nil))
+
+	jobsRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(jobsRecorder, httptest.NewRequest(httpclient.MethodGet, "/admin/jobs", nil))
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusAccepted, triggerRecorder.Code, "Trigger should be accepted")
+	helpers.AssertEqual(t, "/admin/jobs/sweep/runs", triggerRecorder.Header().Get("Location"), "Location should point to the runs")
+
+	var runs []entity.JobRun
+	_ = setJson.NewDecoder(runsRecorder.Body).Decode(&runs)
+	helpers.AssertEqual(t, httpclient.StatusOK, runsRecorder.Code, "Runs should be listed")
+	helpers.AssertEqual(t, 1, len(runs), "Triggered run should be listed")
+	helpers.AssertEqual(t, entity.JobRunSucceeded, runs[0].Status, "Run should have finished")
+	helpers.AssertEqual(t, true, runs[0].DryRun, "Run should be a dry run")
+
+	var jobs []service.JobSummary
+	_ = setJson.NewDecoder(jobsRecorder.Body).Decode(&jobs)
+	helpers.AssertEqual(t, 1, len(jobs), "Registered job should be listed")
+	helpers.AssertEqual(t, "sweep", jobs[0].Name, "Job name should match")
+	helpers.AssertEqual(t, true, jobs[0].LastRun != nil && jobs[0].LastRun.ID == runs[0].ID, "Last run should be included")
+}
+
+func TestJobHandler_Errors(t *testing.T) {
+	handler, _ := newAdminTestServer(t)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"Unknown job runs", httpclient.MethodGet, "/admin/jobs/unknown/runs", httpclient.StatusNotFound, notify.CodeNotFound},
+		{"Unknown job trigger", httpclient.MethodPost, "/admin/jobs/unknown/trigger", httpclient.StatusNotFound, notify.CodeNotFound},
+		{"Invalid dryRun", httpclient.MethodPost, "/admin/jobs/sweep/trigger?dryRun=maybe", httpclient.StatusBadRequest, notify.CodeInvalidData},
+		{"Invalid limit", httpclient.MethodGet, "/admin/jobs/sweep/runs?limit=-1", httpclient.StatusBadRequest, notify.CodeInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tt.method, tt.target, nil)
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			problem := decodeProblem(t, recorder)
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status should match")
+			helpers.AssertEqual(t, tt.expectedCode, problem.Code, "Code should match")
+		})
+	}
+}
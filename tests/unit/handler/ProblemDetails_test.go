@@ -0,0 +1,164 @@
+package handler_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	handlerBase "PocGo/internal/handler/base"
+	"PocGo/internal/middleware"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"context"
+	setJson "encoding/json"
+	"errors"
+	httpclient "net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) handlerBase.ProblemDetails {
+	t.Helper()
+
+	helpers.AssertEqual(t, handlerBase.ContentTypeProblem, recorder.Header().Get("Content-Type"), "Content-Type should be problem+json")
+
+	var problem handlerBase.ProblemDetails
+	if err := setJson.NewDecoder(recorder.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	return problem
+}
+
+func TestSendErrorResponse_MapsDomainErrorCodes(t *testing.T) {
+	driverError := errors.New("mssql: Login failed for user 'sa'")
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "Not found",
+			err:            notify.CreateCustomNotification(notify.NotFound, "Usuário", driverError),
+			expectedStatus: httpclient.StatusNotFound,
+			expectedCode:   notify.CodeNotFound,
+		},
+		{
+			name:           "Invalid data",
+			err:            notify.CreateCustomNotification(notify.InvalidData, "Usuário", driverError),
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedCode:   notify.CodeInvalidData,
+		},
+		{
+			name:           "Invalid method",
+			err:            notify.CreateNotification(notify.InvalidMethod),
+			expectedStatus: httpclient.StatusMethodNotAllowed,
+			expectedCode:   notify.CodeInvalidMethod,
+		},
+		{
+			name:           "Scan error",
+			err:            notify.CreateSimpleNotification(notify.ScanErrorRepository, driverError),
+			expectedStatus: httpclient.StatusInternalServerError,
+			expectedCode:   notify.CodeScanError,
+		},
+		{
+			name:           "Timeout",
+			err:            notify.CreateSimpleNotification(notify.FindErrorRepository, context.DeadlineExceeded),
+			expectedStatus: httpclient.StatusGatewayTimeout,
+			expectedCode:   notify.CodeFindError,
+		},
+		{
+			name:           "Forbidden",
+			err:            notify.CreateCustomNotification(notify.Forbidden, "", "permissão users:delete necessária"),
+			expectedStatus: httpclient.StatusForbidden,
+			expectedCode:   notify.CodeForbidden,
+		},
+		{
+			name:           "Rate limited",
+			err:            notify.CreateCustomNotification(notify.TooManyRequests, "", "tente novamente em 30 s"),
+			expectedStatus: httpclient.StatusTooManyRequests,
+			expectedCode:   notify.CodeRateLimited,
+		},
+		{
+			name:           "Plain error",
+			err:            driverError,
+			expectedStatus: httpclient.StatusInternalServerError,
+			expectedCode:   notify.CodeUnknownError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handlerBase.ConfigureProblemResponses(false)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(httpclient.MethodGet, "/user/get_user_by_id?id=1", nil)
+
+			// Act
+			handlerBase.SendErrorResponse(recorder, request, tt.err)
+
+			// Assert
+			problem := decodeProblem(t, recorder)
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "HTTP status should match")
+			helpers.AssertEqual(t, tt.expectedStatus, problem.Status, "Problem status should match")
+			helpers.AssertEqual(t, tt.expectedCode, problem.Code, "Problem code should match")
+			helpers.AssertEqual(t, "/user/get_user_by_id", problem.Instance, "Instance should be the request path")
+			helpers.AssertEqual(t, httpclient.StatusText(tt.expectedStatus), problem.Title, "Title should be the status text")
+		})
+	}
+}
+
+func TestSendErrorResponse_ProductionHidesCauses(t *testing.T) {
+	// Arrange
+	handlerBase.ConfigureProblemResponses(true)
+	defer handlerBase.ConfigureProblemResponses(false)
+
+	err := notify.CreateCustomNotification(notify.InvalidData, "Usuário", errors.New("mssql: Invalid column name 'email'"))
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(httpclient.MethodPut, "/user/update_user", nil)
+
+	// Act
+	handlerBase.SendErrorResponse(recorder, request, err)
+
+	// Assert
+	problem := decodeProblem(t, recorder)
+	helpers.AssertEqual(t, "Notific : Dados do Usuário inválidos: ", problem.Detail, "Detail should not include the driver message")
+	helpers.AssertEqual(t, "", problem.Cause, "Cause should be hidden")
+}
+
+func TestServer_ErrorResponsesCarryRequestId(t *testing.T) {
+	// Arrange
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	server := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"Unknown user", httpclient.MethodGet, "/user/get_user_by_id?id=missing", httpclient.StatusNotFound, notify.CodeNotFound},
+		{"Unknown route", httpclient.MethodGet, "/nothing/here", httpclient.StatusNotFound, notify.CodeNotFound},
+		{"Wrong method", httpclient.MethodDelete, "/user/update_user", httpclient.StatusMethodNotAllowed, notify.CodeInvalidMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tt.method, tt.target, nil)
+			request.Header.Set(middleware.RequestIdHeader, "test-request-id")
+
+			server.Handler().ServeHTTP(recorder, request)
+
+			problem := decodeProblem(t, recorder)
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "HTTP status should match")
+			helpers.AssertEqual(t, tt.expectedCode, problem.Code, "Problem code should match")
+			helpers.AssertEqual(t, "test-request-id", problem.RequestId, "Problem should carry the request ID")
+			helpers.AssertEqual(t, "test-request-id", recorder.Header().Get(middleware.RequestIdHeader), "Response should echo the request ID")
+		})
+	}
+}
@@ -0,0 +1,401 @@
+package handler_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	handlerBase "PocGo/internal/handler/base"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	setJson "encoding/json"
+	httpclient "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// IDs of the first test users served by newUserTestServer.
+var (
+	user1Id = helpers.TestGuid("1").String()
+	user2Id = helpers.TestGuid("2").String()
+)
+
+// newUserTestServer serves count test users, with the IDs of helpers.TestGuid("1") to count, followed by extra.
+func newUserTestServer(t *testing.T, count int, extra ...entity.User) httpclient.Handler {
+	t.Helper()
+
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	memoryRepo := repos.User.(*repository.MemoryUserRepository)
+	for index, user := range *helpers.CreateTestUsers(count) {
+		memoryRepo.Seed(user, time.Now().Add(time.Duration(index)*time.Minute))
+	}
+	for _, user := range extra {
+		memoryRepo.Seed(user, time.Now().Add(time.Duration(count)*time.Minute))
+	}
+
+	configuration := &config.Config{App: &provider.AppConfig{
+		Environment:  "test",
+		LegacySunset: time.Date(2027, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}}
+	return applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, nil, nil, nil).Handler()
+}
+
+func TestUserHandler_List(t *testing.T) {
+	// Arrange
+	handler := newUserTestServer(t, 3)
+
+	// Act
+	firstRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(firstRecorder, httptest.NewRequest(httpclient.MethodGet, "/api/v1/users?limit=2&status=active", nil))
+
+	var first repository.UserPage
+	_ = setJson.NewDecoder(firstRecorder.Body).Decode(&first)
+
+	secondRecorder := httptest.NewRecorder()
+	handler.ServeHTTP(secondRecorder, httptest.NewRequest(httpclient.MethodGet, "/api/v1/users?limit=2&status=active&cursor="+first.NextCursor, nil))
+
+	var second repository.UserPage
+	_ = setJson.NewDecoder(secondRecorder.Body).Decode(&second)
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusOK, firstRecorder.Code, "First page should be returned")
+	helpers.AssertEqual(t, 2, len(first.Items), "First page should be full")
+	helpers.AssertEqual(t, true, first.HasMore, "First page should have more")
+
+	link := strings.Join(firstRecorder.Header().Values("Link"), ", ")
+	helpers.AssertEqual(t, true, strings.Contains(link, `</api/v1/users?limit=2&status=active>; rel="first"`), "Link should point to the first page")
+	helpers.AssertEqual(t, true, strings.Contains(link, "cursor="+first.NextCursor) && strings.Contains(link, `rel="next"`), "Link should point to the next page")
+
+	helpers.AssertEqual(t, []entity.User{(*helpers.CreateTestUsers(3))[2]}, second.Items, "Second page should hold the last user")
+	helpers.AssertEqual(t, false, second.HasMore, "Second page should be the last")
+	helpers.AssertEqual(t, 1, len(secondRecorder.Header().Values("Link")), "Last page should have no next link")
+}
+
+func TestUserHandler_List_InvalidQuery(t *testing.T) {
+	handler := newUserTestServer(t, 1)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "Non-numeric limit", query: "limit=abc"},
+		{name: "Unknown status", query: "status=archived"},
+		{name: "Unknown sort field", query: "sort=-password"},
+		{name: "Malformed date", query: "createdFrom=yesterday"},
+		{name: "Malformed cursor", query: "cursor=%25%25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+
+			// Act
+			handler.ServeHTTP(recorder, httptest.NewRequest(httpclient.MethodGet, "/api/v1/users?"+tt.query, nil))
+
+			// Assert
+			helpers.AssertEqual(t, httpclient.StatusBadRequest, recorder.Code, "Invalid query should be rejected")
+		})
+	}
+}
+
+func TestUserRoutes_VersionedAndLegacy(t *testing.T) {
+	handler := newUserTestServer(t, 2)
+	oldDate := time.Now().AddDate(-1, 0, 0).Format("2006-01-02")
+
+	tests := []struct {
+		name              string
+		method            string
+		target            string
+		body              string
+		contentType       string
+		ifMatch           string
+		expectedStatus    int
+		expectedSuccessor string
+	}{
+		{name: "v1 get by id", method: httpclient.MethodGet, target: "/api/v1/users/" + user1Id, expectedStatus: httpclient.StatusOK},
+		{name: "v1 put", method: httpclient.MethodPut, target: "/api/v1/users/" + user2Id, body: `{"name":"Renamed"}`, ifMatch: "*", expectedStatus: httpclient.StatusOK},
+		{name: "v1 patch", method: httpclient.MethodPatch, target: "/api/v1/users/" + user2Id, body: `{"name":"Renamed"}`, contentType: "application/merge-patch+json", ifMatch: "*", expectedStatus: httpclient.StatusOK},
+		{name: "Unknown version", method: httpclient.MethodGet, target: "/api/v2/users/" + user1Id, expectedStatus: httpclient.StatusNotFound},
+		{name: "Legacy get by id", method: httpclient.MethodGet, target: "/user/get_user_by_id?id=" + user1Id, expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users/" + user1Id},
+		{name: "Legacy get all with data", method: httpclient.MethodGet, target: "/user/get_all_users?data=" + oldDate, expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users"},
+		{name: "Legacy get all with date", method: httpclient.MethodGet, target: "/user/get_all_users?date=" + oldDate, expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users"},
+		{name: "Legacy update", method: httpclient.MethodPut, target: "/user/update_user", body: `{"id":"` + user1Id + `","name":"Renamed"}`, ifMatch: "*", expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users"},
+		{name: "Unversioned resource", method: httpclient.MethodGet, target: "/users/" + user1Id, expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users/" + user1Id},
+		{name: "Unversioned listing", method: httpclient.MethodGet, target: "/users", expectedStatus: httpclient.StatusOK, expectedSuccessor: "/api/v1/users"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				request.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status should match")
+			if tt.expectedSuccessor == "" {
+				helpers.AssertEqual(t, "", recorder.Header().Get("Deprecation"), "Versioned routes should not be deprecated")
+				return
+			}
+			helpers.AssertEqual(t, true, strings.HasPrefix(recorder.Header().Get("Deprecation"), "@"), "Deprecation should be a structured date")
+			helpers.AssertEqual(t, "Wed, 30 Jun 2027 00:00:00 GMT", recorder.Header().Get("Sunset"), "Sunset should be the configured date")
+			helpers.AssertEqual(t, `<`+tt.expectedSuccessor+`>; rel="successor-version"`, recorder.Header().Values("Link")[0], "Link should point to the successor")
+		})
+	}
+}
+
+func TestUserHandler_Patch(t *testing.T) {
+	tests := []struct {
+		name           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expectedUser   *entity.User
+	}{
+		{
+			name:           "Merge patch clears the name",
+			contentType:    "application/merge-patch+json",
+			body:           `{"name":null}`,
+			expectedStatus: httpclient.StatusOK,
+			expectedUser:   &entity.User{ID: helpers.TestGuid("1"), Email: "user1@example.com", Status: entity.StatusActive},
+		},
+		{
+			name:           "JSON patch changes the status",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op":"test","path":"/status","value":"active"},{"op":"replace","path":"/status","value":"inactive"}]`,
+			expectedStatus: httpclient.StatusOK,
+			expectedUser:   &entity.User{ID: helpers.TestGuid("1"), Name: "Test User 1", Email: "user1@example.com", Status: entity.StatusInactive},
+		},
+		{
+			name:           "Failing test operation",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op":"test","path":"/status","value":"blocked"},{"op":"replace","path":"/name","value":"Renamed"}]`,
+			expectedStatus: httpclient.StatusConflict,
+		},
+		{
+			name:           "Invalid status transition",
+			contentType:    "application/merge-patch+json",
+			body:           `{"status":"pending"}`,
+			expectedStatus: httpclient.StatusConflict,
+		},
+		{
+			name:           "Changing the id",
+			contentType:    "application/merge-patch+json",
+			body:           `{"id":"` + user2Id + `"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+		},
+		{
+			name:           "Removing the email",
+			contentType:    "application/json-patch+json",
+			body:           `[{"op":"remove","path":"/email"}]`,
+			expectedStatus: httpclient.StatusBadRequest,
+		},
+		{
+			name:           "Unknown member",
+			contentType:    "application/merge-patch+json",
+			body:           `{"password":"secret"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+		},
+		{
+			name:           "Malformed patch",
+			contentType:    "application/json-patch+json",
+			body:           `{"op":"remove"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := newUserTestServer(t, 1)
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(httpclient.MethodPatch, "/api/v1/users/"+user1Id, strings.NewReader(tt.body))
+			request.Header.Set("Content-Type", tt.contentType)
+			request.Header.Set("If-Match", `"1"`)
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status should match")
+			if tt.expectedUser != nil {
+				var user entity.User
+				_ = setJson.NewDecoder(recorder.Body).Decode(&user)
+				helpers.AssertEqual(t, *tt.expectedUser, user, "Patched user should be returned")
+			}
+		})
+	}
+}
+
+func TestUserHandler_Patch_UnsupportedMediaType(t *testing.T) {
+	// Arrange
+	handler := newUserTestServer(t, 1)
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(httpclient.MethodPatch, "/api/v1/users/"+user1Id, strings.NewReader(`{"name":"Renamed"}`))
+	request.Header.Set("Content-Type", "application/json")
+
+	// Act
+	handler.ServeHTTP(recorder, request)
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusUnsupportedMediaType, recorder.Code, "Plain JSON should be rejected")
+	helpers.AssertEqual(t, "application/merge-patch+json, application/json-patch+json", recorder.Header().Get("Accept-Patch"), "Accepted patch formats should be advertised")
+}
+
+func TestUserHandler_ConditionalRequests(t *testing.T) {
+	// Arrange
+	handler := newUserTestServer(t, 1)
+	send := func(method string, ifMatch string, ifNoneMatch string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(method, "/api/v1/users/"+user1Id, strings.NewReader(`{"name":"Renamed"}`))
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Act
+	read := send(httpclient.MethodGet, "", "")
+	notModified := send(httpclient.MethodGet, "", `W/"1", "7"`)
+	missing := send(httpclient.MethodPut, "", "")
+	weak := send(httpclient.MethodPut, `W/"1"`, "")
+	updated := send(httpclient.MethodPut, `"1"`, "")
+	stale := send(httpclient.MethodPut, `"1"`, "")
+	modified := send(httpclient.MethodGet, "", `"1"`)
+	wildcard := send(httpclient.MethodPut, "*", "")
+
+	// Assert
+	helpers.AssertEqual(t, `"1"`, read.Header().Get("ETag"), "Read should return the strong ETag")
+	helpers.AssertEqual(t, httpclient.StatusNotModified, notModified.Code, "Matching If-None-Match should answer 304")
+	helpers.AssertEqual(t, 0, notModified.Body.Len(), "304 should have no body")
+	helpers.AssertEqual(t, httpclient.StatusPreconditionRequired, missing.Code, "Update without If-Match should answer 428")
+	helpers.AssertEqual(t, httpclient.StatusPreconditionFailed, weak.Code, "Weak ETags should not satisfy If-Match")
+	helpers.AssertEqual(t, httpclient.StatusOK, updated.Code, "Matching If-Match should update")
+	helpers.AssertEqual(t, `"2"`, updated.Header().Get("ETag"), "Update should return the new ETag")
+	helpers.AssertEqual(t, httpclient.StatusPreconditionFailed, stale.Code, "Stale If-Match should answer 412")
+	helpers.AssertEqual(t, `"2"`, stale.Header().Get("ETag"), "412 should carry the current ETag")
+	helpers.AssertEqual(t, httpclient.StatusOK, modified.Code, "Changed user should be returned again")
+	helpers.AssertEqual(t, httpclient.StatusOK, wildcard.Code, "If-Match * should match the existing user")
+	helpers.AssertEqual(t, `"3"`, wildcard.Header().Get("ETag"), "Update should return the new ETag")
+}
+
+func TestUserHandler_RequestValidation(t *testing.T) {
+	handler := newUserTestServer(t, 1)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedFields []notify.FieldError
+	}{
+		{
+			name:           "Create with every field invalid",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":"` + strings.Repeat("a", 257) + `","email":"not-an-email","status":"archived"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{
+				{Field: "name", Message: "deve ter no máximo 256 caracteres"},
+				{Field: "email", Message: "deve ser um email válido"},
+				{Field: "status", Message: "deve ser um de: pending, active, inactive, blocked, deleted"},
+			},
+		},
+		{
+			name:           "Create without required fields",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":"  "}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{
+				{Field: "name", Message: "é obrigatório"},
+				{Field: "email", Message: "é obrigatório"},
+			},
+		},
+		{
+			name:           "Unknown field",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":"New","email":"new@example.com","password":"secret"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{{Field: "password", Message: "campo desconhecido"}},
+		},
+		{
+			name:           "Wrong type",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":42,"email":"new@example.com"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{{Field: "name", Message: "tipo inválido, esperado string"}},
+		},
+		{
+			name:           "Legacy update without id",
+			method:         httpclient.MethodPut,
+			target:         "/user/update_user",
+			body:           `{"name":"Renamed"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{{Field: "id", Message: "é obrigatório"}},
+		},
+		{
+			name:           "Legacy update with malformed id",
+			method:         httpclient.MethodPut,
+			target:         "/user/update_user",
+			body:           `{"id":"1 OR 1=1","email":"a@b.com"}`,
+			expectedStatus: httpclient.StatusBadRequest,
+			expectedFields: []notify.FieldError{{Field: "id", Message: "deve ser um GUID no formato xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"}},
+		},
+		{
+			name:           "Padded email and numeric legacy status",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":"Padded","email":" padded@example.com ","status":1}`,
+			expectedStatus: httpclient.StatusCreated,
+		},
+		{
+			name:           "Valid create",
+			method:         httpclient.MethodPost,
+			target:         "/api/v1/users",
+			body:           `{"name":"New","email":"new@example.com","status":1}`,
+			expectedStatus: httpclient.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			request.Header.Set("If-Match", "*")
+
+			// Act
+			handler.ServeHTTP(recorder, request)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status should match")
+			if tt.expectedFields != nil {
+				var problem handlerBase.ProblemDetails
+				_ = setJson.NewDecoder(recorder.Body).Decode(&problem)
+				helpers.AssertEqual(t, notify.CodeInvalidData, problem.Code, "Code should be INVALID_DATA")
+				helpers.AssertEqual(t, tt.expectedFields, problem.Errors, "Every invalid field should be listed")
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package health_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/health"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"context"
+	setJson "encoding/json"
+	"errors"
+	httpclient "net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingChecker counts its calls and fails with err when set.
+func countingChecker(name string, calls *atomic.Int32, err error) health.Checker {
+	return health.NewChecker(name, func(context.Context) (string, error) {
+		calls.Add(1)
+		return "ok", err
+	})
+}
+
+type heartbeat time.Time
+
+func (beat heartbeat) Heartbeat() time.Time { return time.Time(beat) }
+
+type schema struct {
+	version int64
+	latest  int64
+	err     error
+}
+
+func (source schema) Version(context.Context) (int64, error) { return source.version, source.err }
+func (source schema) Latest() int64                          { return source.latest }
+
+func TestHealth_Ready(t *testing.T) {
+	// Arrange
+	var databaseCalls, cacheCalls atomic.Int32
+	checks := health.New(health.Options{CacheTTL: -1})
+	checks.Register(countingChecker("database", &databaseCalls, nil))
+	checks.Register(countingChecker("cache", &cacheCalls, errors.New("connection refused")))
+
+	// Act
+	report := checks.Ready(context.Background())
+
+	// Assert
+	helpers.AssertEqual(t, health.StatusDown, report.Status, "One component down should fail readiness")
+	helpers.AssertEqual(t, 2, len(report.Components), "Every component should be listed")
+	helpers.AssertEqual(t, "database", report.Components[0].Name, "Components should keep the registration order")
+	helpers.AssertEqual(t, health.StatusUp, report.Components[0].Status, "Database should be up")
+	helpers.AssertEqual(t, "ok", report.Components[0].Detail, "Detail should be reported")
+	helpers.AssertEqual(t, health.StatusDown, report.Components[1].Status, "Cache should be down")
+	helpers.AssertEqual(t, "connection refused", report.Components[1].Error, "The failure should be reported")
+}
+
+func TestHealth_ReadyCache(t *testing.T) {
+	tests := []struct {
+		name          string
+		cacheTTL      time.Duration
+		expectedCalls int32
+	}{
+		{name: "Cached", cacheTTL: time.Minute, expectedCalls: 1},
+		{name: "Caching disabled", cacheTTL: -1, expectedCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var calls atomic.Int32
+			checks := health.New(health.Options{CacheTTL: tt.cacheTTL})
+			checks.Register(countingChecker("database", &calls, nil))
+
+			// Act
+			for range 3 {
+				checks.Ready(context.Background())
+			}
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedCalls, calls.Load(), "Checker calls should match")
+		})
+	}
+}
+
+func TestHealth_ReadyTimeout(t *testing.T) {
+	// Arrange
+	checks := health.New(health.Options{Timeout: 10 * time.Millisecond})
+	checks.Register(health.NewChecker("slow", func(ctx context.Context) (string, error) {
+		<-ctx.Done()
+		return "", ctx.Err()
+	}))
+	requestCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	report := checks.Ready(requestCtx)
+
+	// Assert
+	helpers.AssertEqual(t, health.StatusDown, report.Status, "A hung dependency should fail readiness")
+	helpers.AssertEqual(t, context.DeadlineExceeded.Error(), report.Components[0].Error, "The check should end at the timeout, not with the request")
+}
+
+func TestHealth_BeginShutdown(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	checks := health.New(health.Options{})
+	checks.Register(countingChecker("database", &calls, nil))
+
+	// Act
+	checks.BeginShutdown()
+	ready := checks.Ready(context.Background())
+
+	// Assert
+	helpers.AssertEqual(t, health.StatusShuttingDown, ready.Status, "Readiness should fail once shutdown begins")
+	helpers.AssertEqual(t, int32(0), calls.Load(), "The checks should not run during shutdown")
+	helpers.AssertEqual(t, health.StatusUp, checks.Live().Status, "Liveness should not be affected")
+}
+
+func TestSchedulerChecker(t *testing.T) {
+	tests := []struct {
+		name        string
+		heartbeat   time.Time
+		expectError bool
+	}{
+		{name: "Recent heartbeat", heartbeat: time.Now().Add(-time.Second)},
+		{name: "Stale heartbeat", heartbeat: time.Now().Add(-time.Hour), expectError: true},
+		{name: "Stopped", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := health.SchedulerChecker(heartbeat(tt.heartbeat), time.Minute).Check(context.Background())
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectError, err != nil, "Failure should match")
+		})
+	}
+}
+
+func TestMigrationChecker(t *testing.T) {
+	tests := []struct {
+		name           string
+		source         schema
+		expectedDetail string
+		expectError    bool
+	}{
+		{name: "Up to date", source: schema{version: 6, latest: 6}, expectedDetail: "versão 6"},
+		{name: "Newer schema", source: schema{version: 7, latest: 6}, expectedDetail: "versão 7"},
+		{name: "Pending migrations", source: schema{version: 5, latest: 6}, expectedDetail: "versão 5", expectError: true},
+		{name: "Unreachable database", source: schema{err: errors.New("timeout")}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			detail, err := health.MigrationChecker(tt.source).Check(context.Background())
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectError, err != nil, "Failure should match")
+			helpers.AssertEqual(t, tt.expectedDetail, detail, "Detail should match")
+		})
+	}
+}
+
+func TestHealth_Endpoints(t *testing.T) {
+	// Arrange
+	var calls atomic.Int32
+	checks := health.New(health.Options{CacheTTL: -1})
+	checks.Register(countingChecker("database", &calls, errors.New("connection refused")))
+
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	handler := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, checks, nil, nil).Handler()
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{path: "/health/live", expectedStatus: httpclient.StatusOK, expectedBody: health.StatusUp},
+		{path: "/health/ready", expectedStatus: httpclient.StatusServiceUnavailable, expectedBody: health.StatusDown},
+		{path: "/health", expectedStatus: httpclient.StatusServiceUnavailable, expectedBody: health.StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// Act
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(httpclient.MethodGet, tt.path, nil))
+
+			// Assert
+			var report health.Report
+			err := setJson.NewDecoder(recorder.Body).Decode(&report)
+			helpers.AssertNoError(t, err, "Body should be a health report")
+			helpers.AssertEqual(t, tt.expectedStatus, recorder.Code, "Status code should match")
+			helpers.AssertEqual(t, tt.expectedBody, report.Status, "Report status should match")
+			helpers.AssertEqual(t, "no-store", recorder.Header().Get("Cache-Control"), "Probes should not be cached")
+		})
+	}
+}
+
+func TestServer_ShutdownEndsTheDelay(t *testing.T) {
+	// Arrange
+	checks := health.New(health.Options{})
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	configuration := &config.Config{
+		App:    &provider.AppConfig{Environment: "test"},
+		Health: &provider.HealthConfig{ShutdownDelay: time.Hour},
+	}
+	server := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, checks, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() { done <- server.Start(ctx) }()
+	for checks.Ready(context.Background()).Status != health.StatusShuttingDown {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Act
+	err := server.Shutdown(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, err, "Shutdown should not fail")
+	select {
+	case err := <-done:
+		helpers.AssertNoError(t, err, "Start should stop cleanly")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown should end the shutdown delay")
+	}
+}
@@ -0,0 +1,101 @@
+package lock_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/lock"
+	"PocGo/tests/helpers"
+	"PocGo/tests/integration/testutils"
+	"context"
+	dbProvider "database/sql"
+	_ "modernc.org/sqlite"
+	"testing"
+	"time"
+)
+
+func newSqliteDatabase(t *testing.T) *dbProvider.DB {
+	t.Helper()
+
+	db, err := dbProvider.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := testutils.MigrateTestDatabase(provider.DriverSqlite, db); err != nil {
+		t.Fatalf("Failed to migrate schema: %v", err)
+	}
+	return db
+}
+
+func TestLocker_Backends(t *testing.T) {
+	lockers := []struct {
+		name    string
+		factory func(*testing.T) lock.Locker
+	}{
+		{name: "memory", factory: func(*testing.T) lock.Locker { return lock.NewMemoryLocker() }},
+		{name: "sqlite", factory: func(t *testing.T) lock.Locker { return lock.NewSqliteLocker(newSqliteDatabase(t)) }},
+	}
+
+	for _, backend := range lockers {
+		t.Run(backend.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			locker := backend.factory(t)
+
+			// Act
+			first, acquired, err := locker.TryAcquire(ctx, "job:sweep", time.Minute)
+			helpers.AssertNoError(t, err, "First acquire should not fail")
+			helpers.AssertEqual(t, true, acquired, "First acquire should get the lease")
+
+			_, acquiredWhileHeld, err := locker.TryAcquire(ctx, "job:sweep", time.Minute)
+			helpers.AssertNoError(t, err, "Acquire while held should not fail")
+
+			_, acquiredOther, _ := locker.TryAcquire(ctx, "job:other", time.Minute)
+
+			helpers.AssertNoError(t, first.Release(ctx), "Release should not fail")
+			helpers.AssertNoError(t, first.Release(ctx), "Second release should be harmless")
+			second, acquiredAfterRelease, _ := locker.TryAcquire(ctx, "job:sweep", time.Minute)
+
+			// Assert
+			helpers.AssertEqual(t, false, acquiredWhileHeld, "Held lease should not be acquired again")
+			helpers.AssertEqual(t, true, acquiredOther, "Other names should be independent")
+			helpers.AssertEqual(t, true, acquiredAfterRelease, "Released lease should be acquired again")
+			_ = second.Release(ctx)
+		})
+	}
+}
+
+func TestSqliteLocker_TakesOverExpiredLease(t *testing.T) {
+	// Arrange
+	db := newSqliteDatabase(t)
+	locker := lock.NewSqliteLocker(db)
+	expired := time.Now().Add(-time.Minute).UnixMilli()
+	_, _ = db.Exec(`INSERT INTO scheduler_lock (name, owner, expires_at) VALUES ('job:sweep', 'crashed-replica', ?)`, expired)
+
+	// Act
+	lease, acquired, err := locker.TryAcquire(context.Background(), "job:sweep", time.Minute)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Acquire should not fail")
+	helpers.AssertEqual(t, true, acquired, "Expired lease should be taken over")
+	_ = lease.Release(context.Background())
+}
+
+func TestSqliteLocker_ReportsLostLease(t *testing.T) {
+	// Arrange
+	db := newSqliteDatabase(t)
+	locker := lock.NewSqliteLocker(db)
+	lease, _, _ := locker.TryAcquire(context.Background(), "job:sweep", 60*time.Millisecond)
+	defer lease.Release(context.Background())
+
+	// Act
+	_, _ = db.Exec(`UPDATE scheduler_lock SET owner = 'other-replica'`)
+
+	// Assert
+	select {
+	case <-lease.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("Lease should be reported as lost once renewal fails")
+	}
+}
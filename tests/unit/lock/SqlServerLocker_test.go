@@ -0,0 +1,118 @@
+package lock_test
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/lock"
+	"PocGo/tests/helpers"
+	"context"
+	dbProvider "database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// appLockDriver fakes the SQL Server applock procedures: sp_getapplock grants the lock and
+// sp_releaseapplock fails when failRelease is set. It counts the connections it closes.
+type appLockDriver struct {
+	failRelease bool
+	closed      atomic.Int32
+}
+
+func (fake *appLockDriver) Open(string) (driver.Conn, error) { return &appLockConn{driver: fake}, nil }
+
+type appLockConn struct {
+	driver *appLockDriver
+}
+
+func (conn *appLockConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare não suportado")
+}
+
+func (conn *appLockConn) Close() error {
+	conn.driver.closed.Add(1)
+	return nil
+}
+
+func (conn *appLockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transação não suportada")
+}
+
+func (conn *appLockConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if strings.Contains(query, "APPLOCK_MODE") {
+		return &singleValueRows{value: "Exclusive"}, nil
+	}
+	return &singleValueRows{value: int64(0)}, nil
+}
+
+func (conn *appLockConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	if conn.driver.failRelease {
+		return nil, errors.New("conexão interrompida")
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type singleValueRows struct {
+	value any
+	read  bool
+}
+
+func (rows *singleValueRows) Columns() []string { return []string{"result"} }
+
+func (rows *singleValueRows) Close() error { return nil }
+
+func (rows *singleValueRows) Next(dest []driver.Value) error {
+	if rows.read {
+		return io.EOF
+	}
+	rows.read = true
+	dest[0] = rows.value
+	return nil
+}
+
+func TestSqlServerLocker_Release(t *testing.T) {
+	tests := []struct {
+		name           string
+		failRelease    bool
+		expectedCode   string
+		expectedClosed int32
+		expectedIdle   int
+	}{
+		{name: "Released session goes back to the pool", expectedIdle: 1},
+		{name: "Error - Failed release discards the session", failRelease: true, expectedCode: notify.CodeLockError, expectedClosed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			fake := &appLockDriver{failRelease: tt.failRelease}
+			db := dbProvider.OpenDB(fakeConnector{driver: fake})
+			t.Cleanup(func() { _ = db.Close() })
+
+			lease, acquired, err := lock.NewSqlServerLocker(db).TryAcquire(context.Background(), "job", time.Minute)
+			helpers.AssertNoError(t, err, "TryAcquire should not fail")
+			helpers.AssertEqual(t, true, acquired, "Lock should be acquired")
+
+			// Act
+			err = lease.Release(context.Background())
+
+			// Assert
+			helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+			helpers.AssertEqual(t, tt.expectedClosed, fake.closed.Load(), "Only a session that may hold the lock should be closed")
+			helpers.AssertEqual(t, tt.expectedIdle, db.Stats().Idle, "Pooled sessions should match")
+		})
+	}
+}
+
+type fakeConnector struct {
+	driver *appLockDriver
+}
+
+func (connector fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return connector.driver.Open("")
+}
+
+func (connector fakeConnector) Driver() driver.Driver { return connector.driver }
@@ -0,0 +1,106 @@
+package logging_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/logging"
+	"PocGo/internal/middleware"
+	"PocGo/tests/helpers"
+	"bytes"
+	"context"
+	setJson "encoding/json"
+	muxRouter "github.com/gorilla/mux"
+	"log/slog"
+	httpclient "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		level       string
+		expected    string
+		expectEmpty bool
+		expectError bool
+	}{
+		{name: "JSON", format: provider.LogFormatJson, level: "info", expected: `"msg":"mensagem"`},
+		{name: "Text", format: provider.LogFormatText, level: "info", expected: "msg=mensagem"},
+		{name: "Empty format is text", format: "", level: "", expected: "msg=mensagem"},
+		{name: "Records below the level are dropped", format: provider.LogFormatText, level: "WARN", expectEmpty: true},
+		{name: "Unknown format", format: "xml", level: "info", expectError: true},
+		{name: "Unknown level", format: provider.LogFormatText, level: "verbose", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var output bytes.Buffer
+
+			// Act
+			level, levelErr := logging.ParseLevel(tt.level)
+			handler, handlerErr := logging.NewHandler(tt.format, level, &output)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertEqual(t, true, levelErr != nil || handlerErr != nil, "Should reject the configuration")
+				return
+			}
+			helpers.AssertNoError(t, levelErr, "Should accept the level")
+			helpers.AssertNoError(t, handlerErr, "Should accept the format")
+
+			slog.New(handler).Info("mensagem")
+			if tt.expectEmpty {
+				helpers.AssertEqual(t, "", output.String(), "Nothing should be written")
+				return
+			}
+			helpers.AssertEqual(t, true, strings.Contains(output.String(), tt.expected), "Output should be "+tt.expected+": "+output.String())
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	fallback := slog.New(slog.NewTextHandler(&output, nil))
+	ctx := logging.With(logging.WithLogger(context.Background(), fallback), logging.KeyJob, "sweep")
+
+	// Act
+	logging.FromContext(ctx, nil).Info("com contexto")
+	logging.FromContext(context.Background(), fallback).Info("sem contexto")
+
+	// Assert
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	helpers.AssertEqual(t, 2, len(lines), "Both records should reach the logger")
+	helpers.AssertEqual(t, true, strings.Contains(lines[0], "job=sweep"), "Context logger should carry the job")
+	helpers.AssertEqual(t, false, strings.Contains(lines[1], "job="), "Fallback should not carry context attributes")
+	helpers.AssertEqual(t, slog.Default(), logging.FromContext(context.Background(), nil), "Default should be the last fallback")
+}
+
+func TestRequestLogger(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&output, nil))
+
+	router := muxRouter.NewRouter()
+	router.Use(middleware.RouteLogger)
+	router.HandleFunc("/users/{id}", func(w httpclient.ResponseWriter, r *httpclient.Request) {
+		logging.FromContext(r.Context(), nil).Info("handler")
+	})
+	handler := middleware.RequestId(middleware.RequestLogger(base)(router))
+
+	request := httptest.NewRequest(httpclient.MethodGet, "/users/42", nil)
+	request.Header.Set(middleware.RequestIdHeader, "req-1")
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+
+	// Assert
+	var record map[string]any
+	helpers.AssertNoError(t, setJson.Unmarshal(output.Bytes(), &record), "Should write one JSON record")
+	helpers.AssertEqual(t, "req-1", record[logging.KeyRequestId], "Record should carry the request ID")
+	helpers.AssertEqual(t, "/users/{id}", record[logging.KeyRoute], "Record should carry the route template")
+	helpers.AssertEqual(t, "42", record[logging.KeyUserId], "Record should carry the user ID")
+	helpers.AssertEqual(t, httpclient.MethodGet, record[logging.KeyMethod], "Record should carry the method")
+}
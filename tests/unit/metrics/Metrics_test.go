@@ -0,0 +1,104 @@
+package metrics_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	"PocGo/internal/metrics"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	dbProvider "database/sql"
+	"io"
+	httpclient "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakePool struct{}
+
+func (fakePool) Stats() dbProvider.DBStats {
+	return dbProvider.DBStats{MaxOpenConnections: 25, OpenConnections: 4, InUse: 3, Idle: 1, WaitCount: 7, WaitDuration: 2 * time.Second}
+}
+
+// scrape sends requests through a server exposing telemetry and returns the /metrics body.
+func scrape(t *testing.T, telemetry *metrics.Metrics, paths ...string) string {
+	t.Helper()
+
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	handler := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, telemetry, nil, nil, nil).Handler()
+
+	for _, path := range paths {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(httpclient.MethodGet, path, nil))
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(httpclient.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	return string(body)
+}
+
+func TestMetrics_Http(t *testing.T) {
+	// Arrange
+	telemetry := metrics.New()
+	missing := helpers.TestGuid("9").String()
+
+	// Act
+	body := scrape(t, telemetry, "/api/v1/users/"+missing, "/api/v1/users/"+missing, "/nothing/here")
+
+	// Assert
+	expected := []string{
+		`pocgo_http_requests_total{method="GET",route="/api/v1/users/{id}",status="404"} 2`,
+		`pocgo_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`pocgo_http_request_duration_seconds_count{method="GET",route="/api/v1/users/{id}",status="404"} 2`,
+		`go_goroutines`,
+	}
+	for _, line := range expected {
+		helpers.AssertEqual(t, true, strings.Contains(body, line), "Metrics should contain "+line)
+	}
+}
+
+func TestMetrics_DatabaseAndJobs(t *testing.T) {
+	// Arrange
+	telemetry := metrics.New()
+	finishedAt := time.Unix(1_800_000_000, 0)
+	failedAt := finishedAt.Add(time.Hour)
+	partialAt := finishedAt.Add(2 * time.Hour)
+	err := telemetry.RegisterDatabase(provider.DriverSqlite, fakePool{})
+
+	// Act
+	telemetry.RunFinished(entity.JobRun{JobName: "sweep", Status: entity.JobRunSucceeded, Updated: 5,
+		StartedAt: finishedAt.Add(-2 * time.Second), FinishedAt: &finishedAt})
+	telemetry.RunFinished(entity.JobRun{JobName: "sweep", Status: entity.JobRunFailed, Updated: 0,
+		StartedAt: finishedAt, FinishedAt: &failedAt})
+	telemetry.RunFinished(entity.JobRun{JobName: "sweep", Status: entity.JobRunPartial, Updated: 1,
+		StartedAt: failedAt, FinishedAt: &partialAt})
+	telemetry.RunFinished(entity.JobRun{JobName: "sweep", Status: entity.JobRunSucceeded, Updated: 9, DryRun: true,
+		StartedAt: finishedAt.Add(-time.Hour), FinishedAt: &finishedAt})
+	telemetry.LockSkipped("sweep")
+	body := scrape(t, telemetry)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Database should be registered")
+	expected := []string{
+		`pocgo_db_open_connections{driver="sqlite"} 4`,
+		`pocgo_db_in_use_connections{driver="sqlite"} 3`,
+		`pocgo_db_idle_connections{driver="sqlite"} 1`,
+		`pocgo_db_wait_count_total{driver="sqlite"} 7`,
+		`pocgo_db_wait_duration_seconds_total{driver="sqlite"} 2`,
+		`pocgo_job_runs_total{job="sweep",status="succeeded"} 2`,
+		`pocgo_job_runs_total{job="sweep",status="failed"} 1`,
+		`pocgo_job_runs_total{job="sweep",status="partial"} 1`,
+		`pocgo_job_run_duration_seconds_count{job="sweep"} 4`,
+		`pocgo_job_last_success_timestamp_seconds{job="sweep"} 1.8e+09`,
+		`pocgo_job_items_updated_total{job="sweep"} 6`,
+		`pocgo_job_lock_skips_total{job="sweep"} 1`,
+	}
+	for _, line := range expected {
+		helpers.AssertEqual(t, true, strings.Contains(body, line), "Metrics should contain "+line)
+	}
+}
@@ -0,0 +1,131 @@
+package middleware_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	"PocGo/internal/middleware"
+	"PocGo/tests/helpers"
+	"bytes"
+	setJson "encoding/json"
+	muxRouter "github.com/gorilla/mux"
+	httpclient "net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+// serve sends request through AccessLog around a router with a /users/{id} route answering 201 with "created".
+func serve(t *testing.T, format string, request *httpclient.Request) string {
+	t.Helper()
+
+	router := muxRouter.NewRouter()
+	router.Use(middleware.RouteLogger)
+	router.HandleFunc("/users/{id}", func(w httpclient.ResponseWriter, _ *httpclient.Request) {
+		w.WriteHeader(httpclient.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	})
+
+	var output bytes.Buffer
+	handler := middleware.AccessLog(middleware.AccessLogOptions{Format: format, Output: &output})(router)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	return output.String()
+}
+
+func TestAccessLog_Formats(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{name: "Common", format: provider.AccessLogCommon, expected: `"GET /users/42?x=1 HTTP/1.1" 201 7` + "\n"},
+		{name: "Combined", format: provider.AccessLogCombined, expected: `"GET /users/42?x=1 HTTP/1.1" 201 7 "https://ref.example" "agent/1.0"` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest(httpclient.MethodGet, "/users/42?x=1", nil)
+			request.Header.Set("Referer", "https://ref.example")
+			request.Header.Set("User-Agent", "agent/1.0")
+
+			// Act
+			line := serve(t, tt.format, request)
+
+			// Assert
+			helpers.AssertEqual(t, true, strings.HasPrefix(line, "192.0.2.1 - - ["), "Line should start with the client: "+line)
+			helpers.AssertEqual(t, true, strings.HasSuffix(line, tt.expected), "Line should end with "+tt.expected+": "+line)
+		})
+	}
+}
+
+func TestAccessLog_Json(t *testing.T) {
+	// Arrange
+	request := httptest.NewRequest(httpclient.MethodGet, "/users/42", nil)
+	request.Header.Set("User-Agent", "agent/1.0")
+
+	// Act
+	line := serve(t, provider.AccessLogJson, request)
+
+	// Assert
+	var entry middleware.AccessEntry
+	helpers.AssertNoError(t, setJson.Unmarshal([]byte(line), &entry), "Line should be JSON")
+	helpers.AssertEqual(t, httpclient.StatusCreated, entry.Status, "Status should be the one written by the handler")
+	helpers.AssertEqual(t, int64(7), entry.Bytes, "Bytes should count the body")
+	helpers.AssertEqual(t, "/users/{id}", entry.Route, "Route should be the matched template")
+	helpers.AssertEqual(t, "192.0.2.1", entry.ClientIP, "Client IP should come from the connection")
+	helpers.AssertEqual(t, "agent/1.0", entry.UserAgent, "User agent should be logged")
+}
+
+func TestDtoResponse_OptionalInterfaces(t *testing.T) {
+	// Arrange
+	recorder := httptest.NewRecorder()
+	var writer httpclient.ResponseWriter = middleware.NewDtoResponse(recorder)
+
+	// Act
+	flusher, isFlusher := writer.(httpclient.Flusher)
+	_, isHijacker := writer.(httpclient.Hijacker)
+	flusher.Flush()
+	_, _, hijackErr := httpclient.NewResponseController(writer).Hijack()
+
+	// Assert
+	helpers.AssertEqual(t, true, isFlusher, "Wrapper should be a Flusher")
+	helpers.AssertEqual(t, true, isHijacker, "Wrapper should be a Hijacker")
+	helpers.AssertEqual(t, true, recorder.Flushed, "Flush should reach the underlying writer")
+	helpers.AssertEqual(t, true, hijackErr != nil, "Hijack should report that the recorder cannot be hijacked")
+}
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("192.0.2.1/32")}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expected     string
+	}{
+		{name: "Direct client", remoteAddr: "203.0.113.9:5000", expected: "203.0.113.9"},
+		{name: "Untrusted peer cannot spoof", remoteAddr: "203.0.113.9:5000", forwardedFor: []string{"1.1.1.1"}, expected: "203.0.113.9"},
+		{name: "Trusted proxy", remoteAddr: "10.0.0.5:5000", forwardedFor: []string{"198.51.100.7"}, expected: "198.51.100.7"},
+		{name: "Chain of proxies", remoteAddr: "10.0.0.5:5000", forwardedFor: []string{"6.6.6.6, 198.51.100.7", "10.0.0.6"}, expected: "198.51.100.7"},
+		{name: "Only trusted hops", remoteAddr: "192.0.2.1:5000", forwardedFor: []string{"10.0.0.6"}, expected: "10.0.0.6"},
+		{name: "Malformed hop", remoteAddr: "10.0.0.5:5000", forwardedFor: []string{"garbage"}, expected: "10.0.0.5"},
+		{name: "IPv6", remoteAddr: "[2001:db8::1]:5000", expected: "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			request := httptest.NewRequest(httpclient.MethodGet, "/", nil)
+			request.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwardedFor {
+				request.Header.Add(middleware.ForwardedForHeader, value)
+			}
+
+			// Act
+			client := middleware.ClientIP(request, trusted)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expected, client, "Client IP should match")
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package migrations_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	migration "PocGo/internal/migrations"
+	"PocGo/tests/helpers"
+	"bytes"
+	"context"
+	dbProvider "database/sql"
+	"errors"
+	"log/slog"
+	_ "modernc.org/sqlite"
+	"strings"
+	"testing"
+)
+
+func newSqliteRunner(t *testing.T) (*migration.Runner, *dbProvider.DB) {
+	t.Helper()
+
+	db, err := dbProvider.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	runner, err := migration.NewRunner(provider.DriverSqlite, db, nil)
+	if err != nil {
+		t.Fatalf("Failed to create runner: %v", err)
+	}
+	return runner, db
+}
+
+func tableExists(t *testing.T, db *dbProvider.DB, table string) bool {
+	t.Helper()
+
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to inspect schema: %v", err)
+	}
+	return count == 1
+}
+
+func TestLoad_EveryDriverHasMigrations(t *testing.T) {
+	for _, driver := range []string{provider.DriverSqlServer, provider.DriverSqlite} {
+		t.Run(driver, func(t *testing.T) {
+			migrations, err := migration.Load(driver)
+
+			helpers.AssertNoError(t, err, "Embedded migrations should load")
+			helpers.AssertEqual(t, true, len(migrations) > 0, "Driver should have migrations")
+			for i, current := range migrations {
+				helpers.AssertEqual(t, 64, len(current.Checksum), "Checksum should be a sha256 hex digest")
+				if i > 0 {
+					helpers.AssertEqual(t, true, current.Version > migrations[i-1].Version, "Migrations should be ordered")
+				}
+			}
+		})
+	}
+
+	_, err := migration.NewRunner(provider.DriverMemory, nil, nil)
+	helpers.AssertError(t, err, "Memory driver has no schema to migrate")
+}
+
+func TestRunner_UpDownGoto(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, db := newSqliteRunner(t)
+	migrations, _ := migration.Load(provider.DriverSqlite)
+	latest := migrations[len(migrations)-1].Version
+
+	// Act
+	applied, err := runner.Up(ctx)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Up should not fail")
+	helpers.AssertEqual(t, len(migrations), applied, "Every migration should be applied")
+	helpers.AssertEqual(t, true, tableExists(t, db, "auth_user"), "auth_user should exist")
+
+	version, _ := runner.Version(ctx)
+	helpers.AssertEqual(t, latest, version, "Version should be the latest migration")
+
+	applied, err = runner.Up(ctx)
+	helpers.AssertNoError(t, err, "Second Up should not fail")
+	helpers.AssertEqual(t, 0, applied, "Second Up should be a no-op")
+
+	reverted, err := runner.Down(ctx)
+	helpers.AssertNoError(t, err, "Down should not fail")
+	helpers.AssertEqual(t, 1, reverted, "Down should revert a single migration")
+
+	_, err = runner.Goto(ctx, 0)
+	helpers.AssertNoError(t, err, "Goto 0 should not fail")
+	helpers.AssertEqual(t, false, tableExists(t, db, "auth_user"), "auth_user should be dropped")
+
+	statuses, err := runner.Status(ctx)
+	helpers.AssertNoError(t, err, "Status should not fail")
+	for _, status := range statuses {
+		helpers.AssertEqual(t, migration.StatePending, status.State, "Every migration should be pending")
+	}
+
+	_, err = runner.Goto(ctx, 9999)
+	helpers.AssertError(t, err, "Unknown version should be rejected")
+}
+
+func TestRunner_DetectsModifiedMigration(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, db := newSqliteRunner(t)
+	if _, err := runner.Up(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE schema_migrations SET checksum = 'tampered' WHERE version = 1`); err != nil {
+		t.Fatalf("Failed to tamper checksum: %v", err)
+	}
+
+	// Act
+	statuses, statusErr := runner.Status(ctx)
+	_, downErr := runner.Down(ctx)
+
+	// Assert
+	helpers.AssertNoError(t, statusErr, "Status should still report")
+	helpers.AssertEqual(t, migration.StateModified, statuses[0].State, "First migration should be reported as modified")
+
+	var domainError *notify.DomainError
+	helpers.AssertEqual(t, true, errors.As(downErr, &domainError), "Down should fail with a DomainError")
+	helpers.AssertEqual(t, "MIGRATION_CHECKSUM", domainError.Code, "Error code should be MIGRATION_CHECKSUM")
+}
+
+func TestRunner_UniqueLoginStopsOnCollidingUsers(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, db := newSqliteRunner(t)
+	if _, err := runner.Goto(ctx, 5); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+	// User 1 changed their email to the login of user 2, which syncing the login would duplicate.
+	_, err := db.Exec(`INSERT INTO auth_user (id, normalized_login, login, email) VALUES
+		('user-1', 'OLD@TEST.COM', 'old@test.com', 'ana@test.com'),
+		('user-2', 'ANA@TEST.COM', 'ana@test.com', 'ana@test.com'),
+		('user-3', 'BIA@TEST.COM', 'bia@test.com', 'bia@test.com')`)
+	if err != nil {
+		t.Fatalf("Failed to seed users: %v", err)
+	}
+
+	// Act
+	_, upErr := runner.Up(ctx)
+	version, _ := runner.Version(ctx)
+	var login string
+	_ = db.QueryRow(`SELECT login FROM auth_user WHERE id = 'user-1'`).Scan(&login)
+
+	// Assert
+	helpers.AssertEqual(t, notify.CodeMigrationError, notify.CodeOf(upErr), "Error code should be MIGRATION_ERROR")
+	helpers.AssertEqual(t, true, strings.Contains(upErr.Error(), "ANA@TEST.COM (user-1, user-2)"), "Error should name the clashing users")
+	helpers.AssertEqual(t, int64(5), version, "The failed migration should not be applied")
+	helpers.AssertEqual(t, "old@test.com", login, "Logins should be left as they were")
+
+	_, _ = db.Exec(`UPDATE auth_user SET email = 'ana.souza@test.com' WHERE id = 'user-1'`)
+	_, upErr = runner.Up(ctx)
+	helpers.AssertNoError(t, upErr, "Up should succeed once the collision is fixed")
+}
+
+func TestRunner_ReadsWithoutCreatingTheTrackingTable(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	runner, db := newSqliteRunner(t)
+
+	// Act
+	version, versionErr := runner.Version(ctx)
+	statuses, statusErr := runner.Status(ctx)
+
+	// Assert
+	helpers.AssertNoError(t, versionErr, "Version should not fail without the tracking table")
+	helpers.AssertNoError(t, statusErr, "Status should not fail without the tracking table")
+	helpers.AssertEqual(t, int64(0), version, "Version should be 0 before any migration")
+	helpers.AssertEqual(t, migration.StatePending, statuses[0].State, "Every migration should be pending")
+	helpers.AssertEqual(t, false, tableExists(t, db, "schema_migrations"), "Reads should not create the tracking table")
+}
+
+func TestRunner_LogsThroughItsLogger(t *testing.T) {
+	// Arrange
+	db, err := dbProvider.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	var output bytes.Buffer
+	runner, err := migration.NewRunner(provider.DriverSqlite, db, slog.New(slog.NewJSONHandler(&output, nil)))
+	helpers.AssertNoError(t, err, "Runner should be created")
+
+	// Act
+	_, err = runner.Goto(context.Background(), 1)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Goto should not fail")
+	helpers.AssertEqual(t, true, strings.Contains(output.String(), `"msg":"Migração aplicada"`), "Applied migrations should be logged through the runner logger")
+}
@@ -0,0 +1,139 @@
+package patch_test
+
+import (
+	"PocGo/internal/patch"
+	"PocGo/tests/helpers"
+	setJson "encoding/json"
+	"errors"
+	"testing"
+)
+
+// normalize re-encodes a JSON document so documents can be compared regardless of member order.
+func normalize(t *testing.T, document string) string {
+	t.Helper()
+
+	var value any
+	if err := setJson.Unmarshal([]byte(document), &value); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", document, err)
+	}
+	data, _ := setJson.Marshal(value)
+	return string(data)
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		patch    string
+		expected string
+	}{
+		{name: "Replace member", document: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{name: "Add member", document: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{name: "Null removes member", document: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{name: "Arrays are replaced", document: `{"a":["b"]}`, patch: `{"a":["c","d"]}`, expected: `{"a":["c","d"]}`},
+		{name: "Nested objects are merged", document: `{"a":{"b":"c","d":"e"}}`, patch: `{"a":{"d":null,"f":"g"}}`, expected: `{"a":{"b":"c","f":"g"}}`},
+		{name: "Non-object patch replaces document", document: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mergePatch, err := patch.ParseMergePatch([]byte(tt.patch))
+			helpers.AssertNoError(t, err, "Patch should parse")
+
+			// Act
+			result, err := mergePatch.Apply([]byte(tt.document))
+
+			// Assert
+			helpers.AssertNoError(t, err, "Patch should apply")
+			helpers.AssertEqual(t, normalize(t, tt.expected), normalize(t, string(result)), "Document should match")
+		})
+	}
+}
+
+func TestJsonPatch(t *testing.T) {
+	tests := []struct {
+		name          string
+		document      string
+		patch         string
+		expected      string
+		expectedError error
+		parseError    bool
+	}{
+		{name: "Add member", document: `{"a":1}`, patch: `[{"op":"add","path":"/b","value":2}]`, expected: `{"a":1,"b":2}`},
+		{name: "Add array element", document: `{"a":[1,3]}`, patch: `[{"op":"add","path":"/a/1","value":2}]`, expected: `{"a":[1,2,3]}`},
+		{name: "Append to array", document: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/-","value":2}]`, expected: `{"a":[1,2]}`},
+		{name: "Remove member", document: `{"a":1,"b":2}`, patch: `[{"op":"remove","path":"/a"}]`, expected: `{"b":2}`},
+		{name: "Remove array element", document: `{"a":[1,2,3]}`, patch: `[{"op":"remove","path":"/a/1"}]`, expected: `{"a":[1,3]}`},
+		{name: "Replace member", document: `{"a":1}`, patch: `[{"op":"replace","path":"/a","value":null}]`, expected: `{"a":null}`},
+		{name: "Replace root", document: `{"a":1}`, patch: `[{"op":"replace","path":"","value":{"b":2}}]`, expected: `{"b":2}`},
+		{name: "Add root", document: `{"a":1}`, patch: `[{"op":"add","path":"","value":[1]}]`, expected: `[1]`},
+		{name: "Move member", document: `{"a":{"b":1},"c":{}}`, patch: `[{"op":"move","from":"/a/b","path":"/c/d"}]`, expected: `{"a":{},"c":{"d":1}}`},
+		{name: "Copy member", document: `{"a":{"b":1}}`, patch: `[{"op":"copy","from":"/a","path":"/c"}]`, expected: `{"a":{"b":1},"c":{"b":1}}`},
+		{name: "Escaped pointer", document: `{"a/b":1,"m~n":2}`, patch: `[{"op":"remove","path":"/a~1b"},{"op":"remove","path":"/m~0n"}]`, expected: `{}`},
+		{name: "Passing test", document: `{"a":[1,{"b":"c"}]}`, patch: `[{"op":"test","path":"/a","value":[1,{"b":"c"}]}]`, expected: `{"a":[1,{"b":"c"}]}`},
+		{name: "Failing test", document: `{"a":1}`, patch: `[{"op":"test","path":"/a","value":2}]`, expectedError: patch.ErrTestFailed},
+		{name: "Replace missing member", document: `{"a":1}`, patch: `[{"op":"replace","path":"/b","value":2}]`, expectedError: errors.New("missing")},
+		{name: "Array index out of range", document: `{"a":[1]}`, patch: `[{"op":"add","path":"/a/5","value":2}]`, expectedError: errors.New("range")},
+		{name: "Unknown op", patch: `[{"op":"merge","path":"/a"}]`, parseError: true},
+		{name: "Add without value", patch: `[{"op":"add","path":"/a"}]`, parseError: true},
+		{name: "Relative path", patch: `[{"op":"remove","path":"a"}]`, parseError: true},
+		{name: "Move into itself", patch: `[{"op":"move","from":"/a","path":"/a/b"}]`, parseError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			jsonPatch, err := patch.ParseJsonPatch([]byte(tt.patch))
+			if tt.parseError {
+				helpers.AssertError(t, err, "Patch should be rejected before applying")
+				return
+			}
+			helpers.AssertNoError(t, err, "Patch should parse")
+
+			// Act
+			result, err := jsonPatch.Apply([]byte(tt.document))
+
+			// Assert
+			if tt.expectedError != nil {
+				helpers.AssertError(t, err, "Patch should fail")
+				if errors.Is(tt.expectedError, patch.ErrTestFailed) {
+					helpers.AssertEqual(t, true, errors.Is(err, patch.ErrTestFailed), "Failure should be ErrTestFailed")
+				}
+				return
+			}
+			helpers.AssertNoError(t, err, "Patch should apply")
+			helpers.AssertEqual(t, normalize(t, tt.expected), normalize(t, string(result)), "Document should match")
+		})
+	}
+}
+
+func TestForContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		unsupported bool
+	}{
+		{name: "Merge patch", contentType: "application/merge-patch+json", body: `{"a":1}`},
+		{name: "Merge patch with charset", contentType: "application/merge-patch+json; charset=utf-8", body: `{"a":1}`},
+		{name: "JSON patch", contentType: "application/json-patch+json", body: `[]`},
+		{name: "Plain JSON", contentType: "application/json", body: `{"a":1}`, unsupported: true},
+		{name: "Missing content type", contentType: "", body: `{"a":1}`, unsupported: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			parsed, err := patch.ForContentType(tt.contentType, []byte(tt.body))
+
+			// Assert
+			if tt.unsupported {
+				helpers.AssertEqual(t, true, errors.Is(err, patch.ErrUnsupportedMediaType), "Content type should be unsupported")
+				return
+			}
+			helpers.AssertNoError(t, err, "Patch should parse")
+			helpers.AssertNotNil(t, parsed, "Patch should be returned")
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package ratelimit_test
+
+import (
+	"PocGo/internal/auth"
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/ratelimit"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/tests/helpers"
+	"context"
+	setJson "encoding/json"
+	"errors"
+	muxRouter "github.com/gorilla/mux"
+	httpclient "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// clock is a manual time source for the memory store.
+type clock struct {
+	now time.Time
+}
+
+func (clock *clock) Now() time.Time { return clock.now }
+
+func (clock *clock) Advance(duration time.Duration) { clock.now = clock.now.Add(duration) }
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)}
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store indisponível")
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	// Arrange
+	now := newClock()
+	store := ratelimit.NewMemoryStore(now.Now)
+	limit := ratelimit.Limit{Requests: 60, Period: time.Minute, Burst: 3}
+	ctx := context.Background()
+
+	// Act
+	var decisions []ratelimit.Decision
+	for range 4 {
+		decision, _ := store.Take(ctx, "client", limit)
+		decisions = append(decisions, decision)
+	}
+	now.Advance(time.Second)
+	refilled, _ := store.Take(ctx, "client", limit)
+	other, _ := store.Take(ctx, "other", limit)
+
+	// Assert
+	for index, expected := range []int{2, 1, 0} {
+		helpers.AssertEqual(t, true, decisions[index].Allowed, "Burst should be allowed")
+		helpers.AssertEqual(t, expected, decisions[index].Remaining, "Remaining tokens should decrease")
+	}
+	helpers.AssertEqual(t, false, decisions[3].Allowed, "An empty bucket should reject")
+	helpers.AssertEqual(t, time.Second, decisions[3].RetryAfter, "Retry should wait for the next token")
+	helpers.AssertEqual(t, 3*time.Second, decisions[3].Reset, "Reset should be the time to refill the burst")
+	helpers.AssertEqual(t, true, refilled.Allowed, "A token should be refilled after the interval")
+	helpers.AssertEqual(t, 2, other.Remaining, "Clients should have buckets of their own")
+}
+
+func TestMemoryStore_DropsRefilledBuckets(t *testing.T) {
+	// Arrange
+	now := newClock()
+	store := ratelimit.NewMemoryStore(now.Now)
+	limit := ratelimit.Limit{Requests: 10, Period: time.Second}
+	_, _ = store.Take(context.Background(), "idle", limit)
+
+	// Act
+	now.Advance(2 * time.Minute)
+	_, _ = store.Take(context.Background(), "active", limit)
+
+	// Assert
+	helpers.AssertEqual(t, 1, store.Len(), "Refilled buckets should be dropped")
+}
+
+func TestNewLimiter(t *testing.T) {
+	valid := provider.RateLimit{Requests: 10, Period: time.Minute}
+
+	tests := []struct {
+		name        string
+		settings    *provider.RateLimitConfig
+		wantLimiter bool
+		wantErr     bool
+	}{
+		{name: "Enabled", settings: &provider.RateLimitConfig{Enabled: true, Default: valid}, wantLimiter: true},
+		{name: "Disabled", settings: &provider.RateLimitConfig{Default: valid}},
+		{name: "No settings", settings: nil},
+		{name: "Error - Default without period", settings: &provider.RateLimitConfig{Enabled: true, Default: provider.RateLimit{Requests: 10}}, wantErr: true},
+		{name: "Error - Route without requests", settings: &provider.RateLimitConfig{Enabled: true, Default: valid,
+			Routes: map[string]provider.RateLimit{"GET /users": {Period: time.Minute}}}, wantErr: true},
+		{name: "Error - Address without period", settings: &provider.RateLimitConfig{Enabled: true, Default: valid,
+			Address: provider.RateLimit{Requests: 10}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(nil), tt.settings, nil)
+
+			// Assert
+			if tt.wantErr {
+				helpers.AssertError(t, err, "Should reject the settings")
+				return
+			}
+			helpers.AssertNoError(t, err, "Should accept the settings")
+			helpers.AssertEqual(t, tt.wantLimiter, limiter != nil, "Limiter should only exist when enabled")
+		})
+	}
+}
+
+// limitedRouter serves /users and /legacy through limiter, with an override of 1 request per minute on /legacy.
+func limitedRouter(t *testing.T, store ratelimit.Store) httpclient.Handler {
+	t.Helper()
+
+	limiter, err := ratelimit.NewLimiter(store, &provider.RateLimitConfig{
+		Enabled: true,
+		Default: provider.RateLimit{Requests: 2, Period: time.Minute},
+		Routes:  map[string]provider.RateLimit{"GET /legacy": {Requests: 1, Period: time.Minute}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Failed to build the limiter: %v", err)
+	}
+
+	ok := httpclient.HandlerFunc(func(w httpclient.ResponseWriter, _ *httpclient.Request) { w.WriteHeader(httpclient.StatusOK) })
+	router := muxRouter.NewRouter()
+	router.Handle("/users", limiter.Middleware(ok)).Methods(httpclient.MethodGet)
+	router.Handle("/legacy", limiter.Middleware(ok)).Methods(httpclient.MethodGet)
+	return router
+}
+
+func serve(handler httpclient.Handler, path, remoteAddr string, principal *auth.Principal) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(httpclient.MethodGet, path, nil)
+	request.RemoteAddr = remoteAddr
+	if principal != nil {
+		request = request.WithContext(auth.WithPrincipal(request.Context(), *principal))
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	// Arrange
+	now := newClock()
+	handler := limitedRouter(t, ratelimit.NewMemoryStore(now.Now))
+	const client = "203.0.113.7:5000"
+
+	// Act
+	first := serve(handler, "/users", client, nil)
+	_ = serve(handler, "/users", client, nil)
+	rejected := serve(handler, "/users", client, nil)
+	legacy := serve(handler, "/legacy", client, nil)
+	legacyRejected := serve(handler, "/legacy", client, nil)
+	otherClient := serve(handler, "/users", "203.0.113.8:5000", nil)
+	keyFromSameAddress := serve(handler, "/users", client, &auth.Principal{Subject: "key-1", Kind: auth.PrincipalApiKey})
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusOK, first.Code, "First request should pass")
+	helpers.AssertEqual(t, "2", first.Header().Get(ratelimit.LimitHeader), "Limit header should be the burst")
+	helpers.AssertEqual(t, "1", first.Header().Get(ratelimit.RemainingHeader), "Remaining header should count down")
+	helpers.AssertEqual(t, "30", first.Header().Get(ratelimit.ResetHeader), "Reset header should be the time to refill")
+	helpers.AssertEqual(t, "2;w=60;burst=2", first.Header().Get(ratelimit.PolicyHeader), "Policy header should describe the limit")
+
+	helpers.AssertEqual(t, httpclient.StatusTooManyRequests, rejected.Code, "Third request should be rejected")
+	helpers.AssertEqual(t, "30", rejected.Header().Get("Retry-After"), "Retry-After should wait for the next token")
+	var problem map[string]any
+	_ = setJson.NewDecoder(rejected.Body).Decode(&problem)
+	helpers.AssertEqual(t, notify.CodeRateLimited, problem["code"], "Problem code should match")
+
+	helpers.AssertEqual(t, httpclient.StatusOK, legacy.Code, "An overridden route should have its own bucket")
+	helpers.AssertEqual(t, "1", legacy.Header().Get(ratelimit.LimitHeader), "Override should set the limit")
+	helpers.AssertEqual(t, httpclient.StatusTooManyRequests, legacyRejected.Code, "Override should be enforced")
+	helpers.AssertEqual(t, httpclient.StatusOK, otherClient.Code, "Other addresses should not be limited")
+	helpers.AssertEqual(t, httpclient.StatusOK, keyFromSameAddress.Code, "Principals should be limited apart from their address")
+}
+
+func TestLimiter_AddressLimitsBadCredentials(t *testing.T) {
+	// Arrange
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	limiter, err := ratelimit.NewLimiter(ratelimit.NewMemoryStore(nil), &provider.RateLimitConfig{
+		Enabled: true,
+		Default: provider.RateLimit{Requests: 100, Period: time.Minute},
+		Address: provider.RateLimit{Requests: 2, Period: time.Minute},
+	}, nil)
+	helpers.AssertNoError(t, err, "Limiter should be built")
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	handler := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, nil,
+		auth.NewAuthenticator(nil, repos.ApiKey), limiter).Handler()
+
+	guess := func(remoteAddr string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(httpclient.MethodGet, "/api/v1/users", nil)
+		request.RemoteAddr = remoteAddr
+		request.Header.Set(auth.ApiKeyHeader, auth.ApiKeyPrefix+"guess")
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	const client = "203.0.113.7:5000"
+
+	// Act
+	first := guess(client)
+	second := guess(client)
+	third := guess(client)
+	otherClient := guess("203.0.113.8:5000")
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusUnauthorized, first.Code, "A bad key should be rejected")
+	helpers.AssertEqual(t, httpclient.StatusUnauthorized, second.Code, "A bad key should be rejected")
+	helpers.AssertEqual(t, httpclient.StatusTooManyRequests, third.Code, "Repeated bad keys should be limited")
+	helpers.AssertEqual(t, "30", third.Header().Get("Retry-After"), "Retry-After should wait for the next token")
+	helpers.AssertEqual(t, httpclient.StatusUnauthorized, otherClient.Code, "Other addresses should not be limited")
+}
+
+func TestLimiter_StoreFailureLetsRequestsThrough(t *testing.T) {
+	// Arrange
+	handler := limitedRouter(t, failingStore{})
+
+	// Act
+	recorder := serve(handler, "/users", "203.0.113.7:5000", nil)
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusOK, recorder.Code, "A store failure should not reject requests")
+	helpers.AssertEqual(t, "", recorder.Header().Get(ratelimit.LimitHeader), "No headers without a decision")
+}
+
+func TestLimiter_NilLetsRequestsThrough(t *testing.T) {
+	// Arrange
+	var limiter *ratelimit.Limiter
+	next := httpclient.HandlerFunc(func(w httpclient.ResponseWriter, _ *httpclient.Request) { w.WriteHeader(httpclient.StatusNoContent) })
+
+	// Act
+	recorder := serve(limiter.AddressMiddleware(limiter.Middleware(next)), "/users", "203.0.113.7:5000", nil)
+
+	// Assert
+	helpers.AssertEqual(t, httpclient.StatusNoContent, recorder.Code, "A nil limiter should not limit")
+}
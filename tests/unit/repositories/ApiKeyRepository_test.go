@@ -0,0 +1,74 @@
+package repositories_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/tests/helpers"
+	"PocGo/tests/integration/testutils"
+	"context"
+	dbProvider "database/sql"
+	"testing"
+	"time"
+)
+
+func newApiKeyRepository(t *testing.T, driver string) repository.ApiKeyRepository {
+	t.Helper()
+
+	var db *dbProvider.DB
+	if driver == provider.DriverSqlite {
+		var err error
+		db, err = dbProvider.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open sqlite: %v", err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { _ = db.Close() })
+
+		if err := testutils.MigrateTestDatabase(provider.DriverSqlite, db); err != nil {
+			t.Fatalf("Failed to migrate schema: %v", err)
+		}
+	}
+
+	repos, err := repository.NewRepositories(driver, db, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repositories: %v", err)
+	}
+	return repos.ApiKey
+}
+
+func TestApiKeyRepository_Backends(t *testing.T) {
+	for _, driver := range []string{provider.DriverMemory, provider.DriverSqlite} {
+		t.Run(driver, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newApiKeyRepository(t, driver)
+			createdAt := time.Now().Add(-time.Hour).Truncate(time.Second).UTC()
+			expiresAt := createdAt.Add(24 * time.Hour)
+			key := &entity.ApiKey{Name: "billing", Prefix: "pocgo_abcdefgh", Hash: "hash-1", Roles: []string{"reader", "admin"},
+				CreatedAt: createdAt, ExpiresAt: &expiresAt}
+
+			// Act
+			createErr := repo.Create(ctx, key)
+			found, findErr := repo.FindByHash(ctx, "hash-1")
+			_, missingErr := repo.FindByHash(ctx, "hash-2")
+			revokeErr := repo.Revoke(ctx, key.ID, createdAt.Add(time.Minute))
+			revoked, _ := repo.FindByHash(ctx, "hash-1")
+			secondRevokeErr := repo.Revoke(ctx, key.ID, createdAt.Add(time.Minute))
+
+			// Assert
+			helpers.AssertNoError(t, createErr, "Create should not fail")
+			helpers.AssertEqual(t, false, key.ID.IsZero(), "Create should assign an ID")
+			helpers.AssertNoError(t, findErr, "FindByHash should find the key")
+			helpers.AssertEqual(t, key.ID, found.ID, "ID should round trip")
+			helpers.AssertEqual(t, []string{"reader", "admin"}, found.Roles, "Roles should round trip")
+			helpers.AssertEqual(t, true, found.ExpiresAt != nil && found.ExpiresAt.Equal(expiresAt), "Expiry should round trip")
+			helpers.AssertEqual(t, true, found.IsActive(createdAt.Add(time.Minute)), "Key should be active")
+			helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(missingErr), "Unknown hash should be NOT_FOUND")
+			helpers.AssertNoError(t, revokeErr, "Revoke should not fail")
+			helpers.AssertEqual(t, false, revoked.IsActive(createdAt.Add(2*time.Minute)), "Revoked key should not be active")
+			helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(secondRevokeErr), "Revoking twice should be NOT_FOUND")
+		})
+	}
+}
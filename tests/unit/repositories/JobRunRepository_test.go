@@ -0,0 +1,138 @@
+package repositories_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/tests/helpers"
+	"PocGo/tests/integration/testutils"
+	"context"
+	dbProvider "database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newJobRunRepository(t *testing.T, driver string) repository.JobRunRepository {
+	t.Helper()
+
+	var db *dbProvider.DB
+	if driver == provider.DriverSqlite {
+		var err error
+		db, err = dbProvider.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open sqlite: %v", err)
+		}
+		db.SetMaxOpenConns(1)
+		t.Cleanup(func() { _ = db.Close() })
+
+		if err := testutils.MigrateTestDatabase(provider.DriverSqlite, db); err != nil {
+			t.Fatalf("Failed to migrate schema: %v", err)
+		}
+	}
+
+	repos, err := repository.NewRepositories(driver, db, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repositories: %v", err)
+	}
+	return repos.JobRun
+}
+
+func TestJobRunRepository_Backends(t *testing.T) {
+	for _, driver := range []string{provider.DriverMemory, provider.DriverSqlite} {
+		t.Run(driver, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newJobRunRepository(t, driver)
+			started := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+			older := &entity.JobRun{JobName: "sweep", TriggeredBy: entity.TriggerSchedule, Status: entity.JobRunRunning, StartedAt: started}
+			newer := &entity.JobRun{JobName: "sweep", TriggeredBy: entity.TriggerManual, DryRun: true, Status: entity.JobRunRunning, StartedAt: started.Add(time.Minute)}
+			other := &entity.JobRun{JobName: "other", TriggeredBy: entity.TriggerSchedule, Status: entity.JobRunRunning, StartedAt: started}
+
+			// Act
+			for _, run := range []*entity.JobRun{older, newer, other} {
+				helpers.AssertNoError(t, repo.Create(ctx, run), "Create should not fail")
+			}
+
+			older.Processed = 3
+			older.Updated = 1
+			older.AddError("42", "update failed")
+			older.Finish(started.Add(30*time.Second), nil)
+			helpers.AssertNoError(t, repo.Update(ctx, older), "Update should not fail")
+
+			runs, err := repo.FindByJob(ctx, "sweep", 10)
+
+			// Assert
+			helpers.AssertNoError(t, err, "FindByJob should not fail")
+			helpers.AssertEqual(t, 2, len(*runs), "Only runs of the job should be returned")
+			helpers.AssertEqual(t, newer.ID, (*runs)[0].ID, "Newest run should come first")
+			helpers.AssertEqual(t, true, (*runs)[0].DryRun, "Dry run flag should be kept")
+
+			finished := (*runs)[1]
+			helpers.AssertEqual(t, entity.JobRunPartial, finished.Status, "Status should be updated")
+			helpers.AssertEqual(t, 3, finished.Processed, "Processed should be updated")
+			helpers.AssertEqual(t, 1, finished.Failed, "Failed should be updated")
+			helpers.AssertEqual(t, []entity.ItemError{{ItemID: "42", Message: "update failed"}}, finished.Errors, "Item errors should round trip")
+			helpers.AssertEqual(t, true, finished.FinishedAt != nil && finished.FinishedAt.Equal(started.Add(30*time.Second)), "Finish time should round trip")
+
+			limited, _ := repo.FindByJob(ctx, "sweep", 1)
+			helpers.AssertEqual(t, 1, len(*limited), "Limit should be applied")
+
+			missing := &entity.JobRun{ID: "missing", Status: entity.JobRunFailed}
+			helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(repo.Update(ctx, missing)), "Unknown run should report NOT_FOUND")
+		})
+	}
+}
+
+func TestJobRunRepository_ExistsForSlot(t *testing.T) {
+	for _, driver := range []string{provider.DriverMemory, provider.DriverSqlite} {
+		t.Run(driver, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			repo := newJobRunRepository(t, driver)
+			location, _ := time.LoadLocation("America/Sao_Paulo")
+			slot := time.Date(2025, time.March, 10, 3, 0, 0, 0, location)
+			scheduled := &entity.JobRun{JobName: "sweep", TriggeredBy: entity.TriggerSchedule, Status: entity.JobRunRunning, StartedAt: slot, ScheduledFor: &slot}
+			manual := &entity.JobRun{JobName: "sweep", TriggeredBy: entity.TriggerManual, Status: entity.JobRunRunning, StartedAt: slot}
+
+			// Act
+			helpers.AssertNoError(t, repo.Create(ctx, scheduled), "Create should not fail")
+			helpers.AssertNoError(t, repo.Create(ctx, manual), "Manual runs should not take a slot")
+			duplicate := repo.Create(ctx, &entity.JobRun{JobName: "sweep", TriggeredBy: entity.TriggerSchedule, Status: entity.JobRunRunning, StartedAt: slot, ScheduledFor: &slot})
+			exists, err := repo.ExistsForSlot(ctx, "sweep", slot.UTC())
+			nextExists, _ := repo.ExistsForSlot(ctx, "sweep", slot.Add(24*time.Hour))
+			otherJob, _ := repo.ExistsForSlot(ctx, "other", slot)
+
+			// Assert
+			helpers.AssertNoError(t, err, "ExistsForSlot should not fail")
+			helpers.AssertError(t, duplicate, "A slot should be recorded once")
+			helpers.AssertEqual(t, true, exists, "Recorded slot should exist in any time zone")
+			helpers.AssertEqual(t, false, nextExists, "Other slots should not exist")
+			helpers.AssertEqual(t, false, otherJob, "Slots are per job")
+
+			runs, _ := repo.FindByJob(ctx, "sweep", 10)
+			var recorded *time.Time
+			for _, run := range *runs {
+				if run.ID == scheduled.ID {
+					recorded = run.ScheduledFor
+				}
+			}
+			helpers.AssertEqual(t, true, recorded != nil && recorded.Equal(slot), "Slot should round trip")
+		})
+	}
+}
+
+func TestJobRunRepository_CancelledContext(t *testing.T) {
+	// Arrange
+	repo := newJobRunRepository(t, provider.DriverMemory)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := repo.Create(ctx, &entity.JobRun{JobName: "sweep"})
+
+	// Assert
+	helpers.AssertEqual(t, true, errors.Is(err, context.Canceled), "Cancelled context should be reported")
+}
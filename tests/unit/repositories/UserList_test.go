@@ -0,0 +1,172 @@
+package repositories_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/tests/helpers"
+	"context"
+	configIO "fmt"
+	"testing"
+	"time"
+)
+
+func listTestSeed() []seededUser {
+	base := time.Now().AddDate(0, -1, 0).Truncate(time.Second)
+
+	var seed []seededUser
+	for index := 1; index <= 7; index++ {
+		user := helpers.CreateTestUser(configIO.Sprintf("%d", index))
+		if index%3 == 0 {
+			user.Status = entity.StatusBlocked
+		}
+		// Users 4 and 5 share the creation date, so the ID must break the tie.
+		offset := index
+		if index == 5 {
+			offset = 4
+		}
+		seed = append(seed, seededUser{user: *user, creationDate: base.Add(time.Duration(offset) * time.Hour)})
+	}
+	return seed
+}
+
+func collectPages(t *testing.T, repo repository.UserRepository, query repository.UserListQuery) ([]string, int) {
+	t.Helper()
+
+	var ids []string
+	pages := 0
+	for {
+		page, err := repo.List(context.Background(), query)
+		helpers.AssertNoError(t, err, "List should not fail")
+		pages++
+
+		for _, user := range page.Items {
+			ids = append(ids, user.ID.String())
+		}
+		helpers.AssertEqual(t, page.HasMore, page.NextCursor != "", "HasMore should match the presence of a cursor")
+		if !page.HasMore || pages > 10 {
+			return ids, pages
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+func TestUserRepository_List(t *testing.T) {
+	backends := []struct {
+		name    string
+		factory func(*testing.T, []seededUser) repository.UserRepository
+	}{
+		{name: "memory", factory: newMemoryTestRepository},
+		{name: "sqlite", factory: newSqliteTestRepository},
+	}
+
+	seed := listTestSeed()
+	tests := []struct {
+		name          string
+		query         repository.UserListQuery
+		expectedIds   []string
+		expectedPages int
+	}{
+		{
+			name:          "Creation date ascending across pages",
+			query:         repository.UserListQuery{Sort: repository.SortByCreationDate, Limit: 3},
+			expectedIds:   []string{"1", "2", "3", "4", "5", "6", "7"},
+			expectedPages: 3,
+		},
+		{
+			name:          "Creation date descending with tie on the date",
+			query:         repository.UserListQuery{Sort: repository.SortByCreationDate, Descending: true, Limit: 2},
+			expectedIds:   []string{"7", "6", "5", "4", "3", "2", "1"},
+			expectedPages: 4,
+		},
+		{
+			name:          "Email descending",
+			query:         repository.UserListQuery{Sort: repository.SortByEmail, Descending: true, Limit: 4},
+			expectedIds:   []string{"7", "6", "5", "4", "3", "2", "1"},
+			expectedPages: 2,
+		},
+		{
+			name: "Status filter",
+			query: repository.UserListQuery{Sort: repository.SortByLogin, Limit: 1,
+				Filter: repository.UserFilter{Statuses: []entity.UserStatus{entity.StatusBlocked}}},
+			expectedIds:   []string{"3", "6"},
+			expectedPages: 2,
+		},
+		{
+			name: "Email prefix is case-insensitive",
+			query: repository.UserListQuery{Sort: repository.SortByEmail, Limit: 10,
+				Filter: repository.UserFilter{EmailPrefix: "USER7"}},
+			expectedIds:   []string{"7"},
+			expectedPages: 1,
+		},
+		{
+			name: "Creation date range",
+			query: repository.UserListQuery{Sort: repository.SortByCreationDate, Limit: 10,
+				Filter: repository.UserFilter{CreatedFrom: seed[1].creationDate, CreatedTo: seed[5].creationDate}},
+			expectedIds:   []string{"2", "3", "4", "5"},
+			expectedPages: 1,
+		},
+	}
+
+	for _, backend := range backends {
+		for _, tt := range tests {
+			t.Run(backend.name+"/"+tt.name, func(t *testing.T) {
+				// Arrange
+				repo := backend.factory(t, seed)
+
+				// Act
+				ids, pages := collectPages(t, repo, tt.query)
+
+				// Assert
+				helpers.AssertEqual(t, helpers.TestGuidStrings(tt.expectedIds...), ids, "Users should be listed in order without gaps or repeats")
+				helpers.AssertEqual(t, tt.expectedPages, pages, "Page count should match")
+			})
+		}
+
+		t.Run(backend.name+"/Rejects invalid queries", func(t *testing.T) {
+			repo := backend.factory(t, seed)
+			first, _ := repo.List(context.Background(), repository.UserListQuery{Sort: repository.SortByEmail, Limit: 1})
+
+			invalid := []repository.UserListQuery{
+				{Sort: "password", Limit: 1},
+				{Sort: repository.SortByEmail, Limit: 1, Cursor: "not-a-cursor"},
+				{Sort: repository.SortByLogin, Limit: 1, Cursor: first.NextCursor},
+			}
+			for _, query := range invalid {
+				_, err := repo.List(context.Background(), query)
+				helpers.AssertEqual(t, notify.CodeInvalidData, notify.CodeOf(err), "Invalid query should be INVALID_DATA")
+			}
+		})
+	}
+}
+
+func TestUserRepository_List_EmailSortOnLegacyRows(t *testing.T) {
+	// Arrange
+	db := openSqliteTestDatabase(t)
+	// Legacy rows may hold an email that differs from the login, or no email at all.
+	rows := []struct {
+		id    string
+		email any
+		login string
+	}{
+		{id: "1", email: "carol@test.com", login: "alice@test.com"},
+		{id: "2", email: nil, login: "bob@test.com"},
+		{id: "3", email: "alice@test.com", login: "carol@test.com"},
+	}
+	for _, row := range rows {
+		_, err := db.Exec(
+			`INSERT INTO auth_user (id, name, email, login, normalized_login, status) VALUES (?, 'Legacy', ?, ?, ?, ?)`,
+			helpers.TestGuid(row.id), row.email, row.login, repository.NormalizeLogin(row.login), entity.StatusActive)
+		helpers.AssertNoError(t, err, "Seeding a legacy user should not fail")
+	}
+	repos, err := repository.NewRepositories(provider.DriverSqlite, db, nil, nil)
+	helpers.AssertNoError(t, err, "Creating repositories should not fail")
+
+	// Act
+	ids, pages := collectPages(t, repos.User, repository.UserListQuery{Sort: repository.SortByEmail, Limit: 1})
+
+	// Assert
+	helpers.AssertEqual(t, helpers.TestGuidStrings("3", "2", "1"), ids, "Users should be paged by the listed email without gaps or repeats")
+	helpers.AssertEqual(t, 3, pages, "Page count should match")
+}
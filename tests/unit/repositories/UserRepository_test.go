@@ -0,0 +1,349 @@
+package repositories_test
+
+import (
+	provider "PocGo/internal/configuration/providers"
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	repository "PocGo/internal/repositories"
+	"PocGo/tests/helpers"
+	"PocGo/tests/integration/testutils"
+	"context"
+	dbProvider "database/sql"
+	"errors"
+	_ "modernc.org/sqlite"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type seededUser struct {
+	user         entity.User
+	creationDate time.Time
+}
+
+func newSqliteTestRepository(t *testing.T, users []seededUser) repository.UserRepository {
+	t.Helper()
+
+	db := openSqliteTestDatabase(t)
+	for _, seed := range users {
+		_, err := db.Exec(
+			`INSERT INTO auth_user (id, name, email, login, normalized_login, status, creation_date) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			seed.user.ID, seed.user.Name, seed.user.Email, seed.user.Email, repository.NormalizeLogin(seed.user.Email), seed.user.Status,
+			seed.creationDate.UTC().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			t.Fatalf("Failed to seed user: %v", err)
+		}
+	}
+
+	repos, err := repository.NewRepositories(provider.DriverSqlite, db, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repositories: %v", err)
+	}
+	return repos.User
+}
+
+// openSqliteTestDatabase opens a migrated in-memory database, closed when the test ends.
+func openSqliteTestDatabase(t *testing.T) *dbProvider.DB {
+	t.Helper()
+
+	db, err := dbProvider.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := testutils.MigrateTestDatabase(provider.DriverSqlite, db); err != nil {
+		t.Fatalf("Failed to migrate schema: %v", err)
+	}
+	return db
+}
+
+func newMemoryTestRepository(t *testing.T, users []seededUser) repository.UserRepository {
+	t.Helper()
+
+	repos, err := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create repositories: %v", err)
+	}
+
+	memoryRepo := repos.User.(*repository.MemoryUserRepository)
+	for _, seed := range users {
+		memoryRepo.Seed(seed.user, seed.creationDate)
+	}
+	return repos.User
+}
+
+func TestUserRepository_Backends(t *testing.T) {
+	backends := []struct {
+		name    string
+		factory func(*testing.T, []seededUser) repository.UserRepository
+	}{
+		{name: "memory", factory: newMemoryTestRepository},
+		{name: "sqlite", factory: newSqliteTestRepository},
+	}
+
+	seed := []seededUser{
+		{user: *helpers.CreateTestUser("1"), creationDate: time.Now().AddDate(-1, 0, 0)},
+		{user: *helpers.CreateTestUser("2"), creationDate: time.Now().AddDate(0, -1, 0)},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			t.Run("FindById returns seeded user", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+
+				user, err := repo.FindById(context.Background(), helpers.TestGuid("1"))
+
+				expected := seed[0].user
+				expected.Version = 1
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, expected, *user, "User should match the seeded one with the initial version")
+			})
+
+			t.Run("FindById returns NOT_FOUND for unknown id", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+
+				_, err := repo.FindById(context.Background(), helpers.TestGuid("999"))
+
+				var domainError *notify.DomainError
+				helpers.AssertEqual(t, true, errors.As(err, &domainError), "Should return a DomainError")
+				helpers.AssertEqual(t, "NOT_FOUND", domainError.Code, "Error code should be NOT_FOUND")
+			})
+
+			t.Run("FindById honours a cancelled context", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err := repo.FindById(ctx, helpers.TestGuid("1"))
+
+				helpers.AssertError(t, err, "Cancelled context should abort the query")
+				helpers.AssertEqual(t, true, errors.Is(err, context.Canceled), "Error should wrap context.Canceled")
+			})
+
+			t.Run("FindAll filters by creation date", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+
+				users, err := repo.FindAll(context.Background(), time.Now().AddDate(0, -6, 0).Format("2006-01-02"))
+
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, 1, len(*users), "Only the recent user should be returned")
+				helpers.AssertEqual(t, helpers.TestGuid("2"), (*users)[0].ID, "Recent user should be returned")
+			})
+
+			t.Run("UpdateStatus", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+
+				err := repo.UpdateStatus(context.Background(), helpers.TestGuid("1"), entity.StatusInactive)
+				helpers.AssertNoError(t, err, "Should not return an error")
+
+				user, _ := repo.FindById(context.Background(), helpers.TestGuid("1"))
+				helpers.AssertEqual(t, entity.StatusInactive, user.Status, "Status should be updated")
+				helpers.AssertError(t, repo.UpdateStatus(context.Background(), helpers.TestGuid("999"), entity.StatusInactive), "Unknown user should not be updated")
+			})
+
+			t.Run("Create, soft delete and restore", func(t *testing.T) {
+				ctx := context.Background()
+				repo := backend.factory(t, seed)
+				user := &entity.User{Name: "New User", Email: "new.user@example.com", Status: entity.StatusActive}
+
+				err := repo.Create(ctx, user)
+				helpers.AssertNoError(t, err, "Create should not fail")
+				helpers.AssertEqual(t, false, user.ID.IsZero(), "Create should assign a GUID")
+
+				_, err = repo.FindById(ctx, user.ID)
+				helpers.AssertNoError(t, err, "Created user should be found")
+
+				helpers.AssertNoError(t, repo.Delete(ctx, user.ID), "Delete should not fail")
+				_, err = repo.FindById(ctx, user.ID)
+				helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(err), "Deleted user should not be found")
+				helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(repo.Delete(ctx, user.ID)), "Deleting twice should report NOT_FOUND")
+
+				emailTaken, _ := repo.ExistsByEmail(ctx, "new.user@example.com")
+				loginTaken, _ := repo.ExistsByLogin(ctx, "NEW.USER@EXAMPLE.COM")
+				helpers.AssertEqual(t, true, emailTaken, "Deleted user should keep the email reserved")
+				emailTaken, _ = repo.ExistsByEmail(ctx, "New.User@EXAMPLE.com")
+				helpers.AssertEqual(t, true, emailTaken, "Emails should be compared ignoring case")
+				helpers.AssertEqual(t, true, loginTaken, "Deleted user should keep the login reserved")
+
+				helpers.AssertNoError(t, repo.Restore(ctx, user.ID), "Restore should not fail")
+				restored, err := repo.FindById(ctx, user.ID)
+				helpers.AssertNoError(t, err, "Restored user should be found")
+				helpers.AssertEqual(t, entity.StatusInactive, restored.Status, "Restored user should be inactive")
+				helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(repo.Restore(ctx, user.ID)), "Restoring an active user should report NOT_FOUND")
+			})
+
+			t.Run("Reactivate only touches inactive users", func(t *testing.T) {
+				ctx := context.Background()
+				repo := backend.factory(t, seed)
+
+				helpers.AssertError(t, repo.Reactivate(ctx, helpers.TestGuid("1")), "Active user should not be reactivated")
+
+				_ = repo.UpdateStatus(ctx, helpers.TestGuid("1"), entity.StatusInactive)
+				helpers.AssertNoError(t, repo.Reactivate(ctx, helpers.TestGuid("1")), "Inactive user should be reactivated")
+
+				user, _ := repo.FindById(ctx, helpers.TestGuid("1"))
+				helpers.AssertEqual(t, entity.StatusActive, user.Status, "Status should be active")
+			})
+
+			t.Run("Update persists fields", func(t *testing.T) {
+				repo := backend.factory(t, seed)
+
+				changed := &entity.User{ID: helpers.TestGuid("2"), Name: "Changed", Email: "changed@example.com", Status: 1, Version: 1}
+				err := repo.Update(context.Background(), changed)
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, int64(2), changed.Version, "Update should return the new version")
+
+				user, _ := repo.FindById(context.Background(), helpers.TestGuid("2"))
+				helpers.AssertEqual(t, entity.StatusActive, user.Status, "Status should be kept")
+				helpers.AssertEqual(t, int64(2), user.Version, "Version should be incremented")
+				helpers.AssertError(t, repo.Update(context.Background(), &entity.User{ID: helpers.TestGuid("999")}), "Unknown user should not be updated")
+			})
+
+			t.Run("Update keeps the login in sync with the email", func(t *testing.T) {
+				ctx := context.Background()
+				repo := backend.factory(t, seed)
+
+				err := repo.Update(ctx, &entity.User{ID: helpers.TestGuid("2"), Name: "Changed", Email: "Changed@example.com", Status: 1, Version: 1})
+				helpers.AssertNoError(t, err, "Should not return an error")
+
+				newLogin, _ := repo.ExistsByLogin(ctx, "CHANGED@EXAMPLE.COM")
+				oldLogin, _ := repo.ExistsByLogin(ctx, repository.NormalizeLogin(seed[1].user.Email))
+				helpers.AssertEqual(t, true, newLogin, "Login should follow the new email")
+				helpers.AssertEqual(t, false, oldLogin, "Previous login should be released")
+			})
+
+			t.Run("Duplicate login is a CONFLICT", func(t *testing.T) {
+				ctx := context.Background()
+				repo := backend.factory(t, seed)
+				taken := strings.ToUpper(seed[0].user.Email)
+
+				err := repo.Create(ctx, &entity.User{Name: "New User", Email: taken, Status: entity.StatusActive})
+				helpers.AssertEqual(t, notify.CodeConflict, notify.CodeOf(err), "Create should report the taken login")
+
+				err = repo.Update(ctx, &entity.User{ID: helpers.TestGuid("2"), Name: "Changed", Email: taken, Status: 1, Version: 1})
+				helpers.AssertEqual(t, notify.CodeConflict, notify.CodeOf(err), "Update should report the taken login")
+			})
+
+			t.Run("Update rejects a stale version", func(t *testing.T) {
+				ctx := context.Background()
+				repo := backend.factory(t, seed)
+				_ = repo.UpdateStatus(ctx, helpers.TestGuid("2"), entity.StatusInactive)
+
+				err := repo.Update(ctx, &entity.User{ID: helpers.TestGuid("2"), Name: "Changed", Email: "changed@example.com", Status: 1, Version: 1})
+
+				helpers.AssertEqual(t, notify.CodePreconditionFailed, notify.CodeOf(err), "Stale version should fail the precondition")
+				user, _ := repo.FindById(ctx, helpers.TestGuid("2"))
+				helpers.AssertEqual(t, "Test User 2", user.Name, "Stale update should not be written")
+				helpers.AssertEqual(t, int64(2), user.Version, "Status change should have bumped the version")
+			})
+		})
+	}
+}
+
+func TestUserRepository_UpdateStatusBatch(t *testing.T) {
+	backends := []struct {
+		name    string
+		factory func(*testing.T, []seededUser) repository.UserRepository
+	}{
+		{name: "memory", factory: newMemoryTestRepository},
+		{name: "sqlite", factory: newSqliteTestRepository},
+	}
+
+	old := time.Now().AddDate(-1, 0, 0)
+	var seed []seededUser
+	for index, status := range []entity.UserStatus{
+		entity.StatusActive, entity.StatusActive, entity.StatusBlocked, entity.StatusActive, entity.StatusInactive,
+	} {
+		user := helpers.CreateTestUser(strconv.Itoa(index + 1))
+		user.Status = status
+		seed = append(seed, seededUser{user: *user, creationDate: old})
+	}
+	seed = append(seed, seededUser{user: *helpers.CreateTestUser("6"), creationDate: time.Now()})
+
+	tests := []struct {
+		name            string
+		dryRun          bool
+		cancelAfter     int
+		expectedUpdated []string
+		expectedSkipped []string
+		expectedChunks  int
+		expectedStatus  entity.UserStatus
+	}{
+		{
+			name:            "Updates eligible users in chunks",
+			expectedUpdated: helpers.TestGuidStrings("1", "2", "4"),
+			expectedSkipped: helpers.TestGuidStrings("3", "5"),
+			expectedChunks:  3,
+			expectedStatus:  entity.StatusInactive,
+		},
+		{
+			name:            "Dry run writes nothing",
+			dryRun:          true,
+			expectedUpdated: helpers.TestGuidStrings("1", "2", "4"),
+			expectedSkipped: helpers.TestGuidStrings("3", "5"),
+			expectedChunks:  3,
+			expectedStatus:  entity.StatusActive,
+		},
+		{
+			name:            "Cancellation stops after the current chunk",
+			cancelAfter:     1,
+			expectedUpdated: helpers.TestGuidStrings("1", "2"),
+			expectedChunks:  1,
+			expectedStatus:  entity.StatusActive,
+		},
+	}
+
+	for _, backend := range backends {
+		for _, tt := range tests {
+			t.Run(backend.name+"/"+tt.name, func(t *testing.T) {
+				// Arrange
+				repo := backend.factory(t, seed)
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				chunks := 0
+
+				// Act
+				result, err := repo.UpdateStatusBatch(ctx, repository.StatusBatch{
+					CreatedBefore: time.Now().AddDate(0, -5, 0),
+					From:          []entity.UserStatus{entity.StatusActive},
+					To:            entity.StatusInactive,
+					Size:          2,
+					DryRun:        tt.dryRun,
+					Progress: func(repository.BatchResult) {
+						chunks++
+						if chunks == tt.cancelAfter {
+							cancel()
+						}
+					},
+				})
+
+				// Assert
+				if tt.cancelAfter > 0 {
+					helpers.AssertEqual(t, true, errors.Is(err, context.Canceled), "Should stop with context.Canceled")
+				} else {
+					helpers.AssertNoError(t, err, "Should not return an error")
+					helpers.AssertEqual(t, tt.expectedSkipped, result.Skipped, "Skipped users should match")
+				}
+				helpers.AssertEqual(t, tt.expectedUpdated, result.Updated, "Updated users should match")
+				helpers.AssertEqual(t, 0, len(result.Failed), "No chunk should fail")
+				helpers.AssertEqual(t, tt.expectedChunks, chunks, "Progress should be reported per chunk")
+
+				user, _ := repo.FindById(context.Background(), helpers.TestGuid("4"))
+				helpers.AssertEqual(t, tt.expectedStatus, user.Status, "Status of an eligible user should match")
+				recent, _ := repo.FindById(context.Background(), helpers.TestGuid("6"))
+				helpers.AssertEqual(t, entity.StatusActive, recent.Status, "Recent user should not be touched")
+			})
+		}
+	}
+}
+
+func TestRepositories_UnknownBackend(t *testing.T) {
+	_, err := repository.NewRepositories("oracle", nil, nil, nil)
+	helpers.AssertError(t, err, "Unknown driver should be rejected")
+
+	_, err = repository.NewRepositories(provider.DriverSqlite, nil, nil, nil)
+	helpers.AssertError(t, err, "SQLite backend should require a connection")
+}
@@ -0,0 +1,148 @@
+package scheduler_test
+
+import (
+	"PocGo/internal/scheduler"
+	"PocGo/tests/helpers"
+	"testing"
+	"time"
+)
+
+func TestParseCron_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{name: "Too few fields", expression: "0 0 *"},
+		{name: "Too many fields", expression: "0 0 0 * * * *"},
+		{name: "Out of range", expression: "0 24 * * *"},
+		{name: "Inverted range", expression: "0 10-5 * * *"},
+		{name: "Invalid step", expression: "*/0 * * * *"},
+		{name: "Unknown name", expression: "0 0 * FOO *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := scheduler.ParseCron(tt.expression, time.UTC)
+
+			// Assert
+			helpers.AssertError(t, err, "Should return an error")
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	// Wednesday, 2025-01-15 10:30:00 UTC
+	from := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		location   *time.Location
+		expected   time.Time
+	}{
+		{
+			name:       "Five fields every minute",
+			expression: "* * * * *",
+			expected:   time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name:       "Six fields with seconds step",
+			expression: "*/15 * * * * *",
+			expected:   time.Date(2025, time.January, 15, 10, 30, 15, 0, time.UTC),
+		},
+		{
+			name:       "Daily macro",
+			expression: "@daily",
+			expected:   time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Weekday names and ranges",
+			expression: "0 9 * * MON-FRI",
+			expected:   time.Date(2025, time.January, 16, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Sunday as seven",
+			expression: "0 0 * * 7",
+			expected:   time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Day of month or weekday",
+			expression: "0 0 20 * MON",
+			expected:   time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Next year",
+			expression: "0 0 1 JAN *",
+			expected:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "Time zone",
+			expression: "0 8 * * *",
+			location:   saoPaulo,
+			expected:   time.Date(2025, time.January, 15, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			schedule, err := scheduler.ParseCron(tt.expression, tt.location)
+			helpers.AssertNoError(t, err, "Should parse the expression")
+
+			// Act
+			next := schedule.Next(from)
+
+			// Assert
+			helpers.AssertEqual(t, true, next.Equal(tt.expected), "Next run should be "+tt.expected.String()+", got "+next.String())
+		})
+	}
+}
+
+func TestCronSchedule_Next_Impossible(t *testing.T) {
+	// Arrange
+	schedule, err := scheduler.ParseCron("0 0 30 2 *", time.UTC)
+	helpers.AssertNoError(t, err, "Should parse the expression")
+
+	// Act
+	next := schedule.Next(time.Now())
+
+	// Assert
+	helpers.AssertEqual(t, true, next.IsZero(), "February 30th should never run")
+}
+
+func TestCronSchedule_Prev(t *testing.T) {
+	from := time.Date(2025, time.March, 10, 14, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		expected   time.Time
+	}{
+		{name: "Every second", expression: "* * * * * *", expected: from},
+		{name: "Every minute", expression: "* * * * *", expected: time.Date(2025, time.March, 10, 14, 30, 0, 0, time.UTC)},
+		{name: "Daily", expression: "0 3 * * *", expected: time.Date(2025, time.March, 10, 3, 0, 0, 0, time.UTC)},
+		{name: "Later today is yesterday", expression: "0 18 * * *", expected: time.Date(2025, time.March, 9, 18, 0, 0, 0, time.UTC)},
+		{name: "Yearly", expression: "@yearly", expected: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Impossible", expression: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			schedule, err := scheduler.ParseCron(tt.expression, time.UTC)
+			helpers.AssertNoError(t, err, "Should parse the expression")
+
+			// Act
+			prev := schedule.Prev(from)
+
+			// Assert
+			helpers.AssertEqual(t, true, prev.Equal(tt.expected), "Previous run should be "+tt.expected.String()+", got "+prev.String())
+		})
+	}
+}
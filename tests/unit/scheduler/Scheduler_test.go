@@ -0,0 +1,351 @@
+package scheduler_test
+
+import (
+	entity "PocGo/internal/domain/entities"
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/lock"
+	"PocGo/internal/logging"
+	repository "PocGo/internal/repositories"
+	"PocGo/internal/scheduler"
+	"PocGo/tests/helpers"
+	"bytes"
+	"context"
+	setJson "encoding/json"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_Register(t *testing.T) {
+	noop := func(ctx context.Context, run *entity.JobRun) error { return nil }
+
+	tests := []struct {
+		name         string
+		job          scheduler.Job
+		expectedCode string
+	}{
+		{name: "Success", job: scheduler.Job{Name: "other", Schedule: "@hourly", Run: noop}},
+		{name: "Error - Duplicate name", job: scheduler.Job{Name: "existing", Schedule: "@hourly", Run: noop}, expectedCode: notify.CodeJobDuplicate},
+		{name: "Error - Invalid schedule", job: scheduler.Job{Name: "bad", Schedule: "every day", Run: noop}, expectedCode: notify.CodeJobInvalid},
+		{name: "Error - Unknown time zone", job: scheduler.Job{Name: "tz", Schedule: "@daily", TimeZone: "Mars/Olympus", Run: noop}, expectedCode: notify.CodeJobInvalid},
+		{name: "Error - Missing function", job: scheduler.Job{Name: "nil", Schedule: "@daily"}, expectedCode: notify.CodeJobInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC})
+			_ = jobScheduler.Register(scheduler.Job{Name: "existing", Schedule: "@daily", Run: noop})
+
+			// Act
+			err := jobScheduler.Register(tt.job)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, 2, len(jobScheduler.Jobs()), "Both jobs should be registered")
+			}
+		})
+	}
+}
+
+func TestScheduler_PreventsOverlap(t *testing.T) {
+	// Arrange
+	var runs atomic.Int32
+	release := make(chan struct{})
+
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC})
+	err := jobScheduler.Register(scheduler.Job{
+		Name:       "slow",
+		Schedule:   "* * * * * *",
+		RunOnStart: true,
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			runs.Add(1)
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	helpers.AssertNoError(t, err, "Should register the job")
+
+	// Act
+	jobScheduler.Start(context.Background())
+	time.Sleep(2500 * time.Millisecond)
+	running := jobScheduler.Jobs()[0].Running
+	close(release)
+	stopErr := jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, stopErr, "Stop should not time out")
+	helpers.AssertEqual(t, true, running, "Job should be reported as running")
+	helpers.AssertEqual(t, int32(1), runs.Load(), "Ticks during a run should be skipped")
+}
+
+func TestScheduler_StopWaitsForRunningJobs(t *testing.T) {
+	// Arrange
+	var finished atomic.Bool
+	started := make(chan struct{})
+
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:       "in-flight",
+		Schedule:   "@yearly",
+		RunOnStart: true,
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			close(started)
+			time.Sleep(200 * time.Millisecond)
+			finished.Store(true)
+			return nil
+		},
+	})
+
+	// Act
+	jobScheduler.Start(context.Background())
+	<-started
+	err := jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, err, "Stop should not time out")
+	helpers.AssertEqual(t, true, finished.Load(), "Stop should wait for the run in progress")
+}
+
+func TestScheduler_StopCancelsAfterDeadline(t *testing.T) {
+	// Arrange
+	var cancelled atomic.Bool
+	started := make(chan struct{})
+
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:       "stuck",
+		Schedule:   "@yearly",
+		RunOnStart: true,
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			close(started)
+			<-ctx.Done()
+			cancelled.Store(true)
+			return ctx.Err()
+		},
+	})
+
+	jobScheduler.Start(context.Background())
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := jobScheduler.Stop(ctx)
+
+	// Assert
+	helpers.AssertEqual(t, context.DeadlineExceeded, err, "Stop should report the deadline")
+	helpers.AssertEqual(t, true, cancelled.Load(), "Run should be cancelled once the deadline passes")
+	helpers.AssertEqual(t, "context canceled", jobScheduler.Jobs()[0].LastError, "Last error should be recorded")
+}
+
+func TestScheduler_TriggerRecordsRun(t *testing.T) {
+	// Arrange
+	recorder := repository.NewMemoryJobRunRepository()
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC, Recorder: recorder})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:     "sweep",
+		Schedule: "@yearly",
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			run.Processed = 2
+			if !run.DryRun {
+				run.Updated = 1
+			}
+			run.AddError("7", "update failed")
+			return nil
+		},
+	})
+	jobScheduler.Start(context.Background())
+
+	// Act
+	started, err := jobScheduler.Trigger("sweep", true)
+	_ = jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, err, "Trigger should not fail")
+	helpers.AssertEqual(t, entity.JobRunRunning, started.Status, "Trigger should return the run as started")
+	helpers.AssertEqual(t, entity.TriggerManual, started.TriggeredBy, "Run should be marked as manual")
+
+	runs, _ := recorder.FindByJob(context.Background(), "sweep", 10)
+	helpers.AssertEqual(t, 1, len(*runs), "Run should be recorded")
+	recorded := (*runs)[0]
+	helpers.AssertEqual(t, started.ID, recorded.ID, "Recorded run should be the triggered one")
+	helpers.AssertEqual(t, entity.JobRunPartial, recorded.Status, "Item errors should make the run partial")
+	helpers.AssertEqual(t, true, recorded.DryRun, "Dry run should be recorded")
+	helpers.AssertEqual(t, 0, recorded.Updated, "Dry run should not update")
+	helpers.AssertEqual(t, 1, recorded.Failed, "Failures should be counted")
+}
+
+func TestScheduler_RunLogger(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	jobScheduler := scheduler.New(scheduler.Options{
+		Location: time.UTC,
+		Logger:   slog.New(slog.NewJSONHandler(&output, nil)),
+	})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:     "sweep",
+		Schedule: "@yearly",
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			logging.FromContext(ctx, nil).Info("dentro do job")
+			return nil
+		},
+	})
+	jobScheduler.Start(context.Background())
+
+	// Act
+	started, err := jobScheduler.Trigger("sweep", false)
+	_ = jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, err, "Trigger should not fail")
+
+	var record map[string]any
+	for _, line := range strings.Split(output.String(), "\n") {
+		if strings.Contains(line, "dentro do job") {
+			helpers.AssertNoError(t, setJson.Unmarshal([]byte(line), &record), "Record should be JSON")
+		}
+	}
+	helpers.AssertEqual(t, true, record != nil, "Job should log through the context logger")
+	helpers.AssertEqual(t, "sweep", record[logging.KeyJob], "Record should carry the job name")
+	helpers.AssertEqual(t, started.ID, record[logging.KeyRunId], "Record should carry the run ID")
+}
+
+func TestScheduler_TriggerErrors(t *testing.T) {
+	// Arrange
+	release := make(chan struct{})
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC})
+	_ = jobScheduler.Register(scheduler.Job{
+		Name:     "busy",
+		Schedule: "@yearly",
+		Run: func(ctx context.Context, run *entity.JobRun) error {
+			<-release
+			return nil
+		},
+	})
+
+	// Act
+	_, stoppedErr := jobScheduler.Trigger("busy", false)
+	jobScheduler.Start(context.Background())
+	_, unknownErr := jobScheduler.Trigger("unknown", false)
+	_, firstErr := jobScheduler.Trigger("busy", false)
+	_, overlapErr := jobScheduler.Trigger("busy", false)
+	close(release)
+	_ = jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertEqual(t, notify.CodeSchedulerStopped, notify.CodeOf(stoppedErr), "Trigger before Start should be rejected")
+	helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(unknownErr), "Unknown job should report NOT_FOUND")
+	helpers.AssertNoError(t, firstErr, "First trigger should start the job")
+	helpers.AssertEqual(t, notify.CodeJobRunning, notify.CodeOf(overlapErr), "Trigger during a run should be rejected")
+}
+
+// runObserver records what a scheduler reports through scheduler.RunObserver.
+type runObserver struct {
+	finished  atomic.Int32
+	lockSkips atomic.Int32
+}
+
+func (observer *runObserver) RunFinished(entity.JobRun) { observer.finished.Add(1) }
+func (observer *runObserver) LockSkipped(string)        { observer.lockSkips.Add(1) }
+
+func TestScheduler_SkipsWhenAnotherReplicaHoldsTheLock(t *testing.T) {
+	// Arrange
+	locker := lock.NewMemoryLocker()
+	release := make(chan struct{})
+	observer := &runObserver{}
+	newReplica := func(observer scheduler.RunObserver) *scheduler.Scheduler {
+		replica := scheduler.New(scheduler.Options{Location: time.UTC, Locker: locker, Observer: observer})
+		_ = replica.Register(scheduler.Job{
+			Name:     "sweep",
+			Schedule: "@yearly",
+			Run: func(ctx context.Context, run *entity.JobRun) error {
+				<-release
+				return nil
+			},
+		})
+		replica.Start(context.Background())
+		return replica
+	}
+	first, second := newReplica(nil), newReplica(observer)
+
+	// Act
+	_, firstErr := first.Trigger("sweep", false)
+	_, secondErr := second.Trigger("sweep", false)
+	close(release)
+	_ = first.Stop(context.Background())
+	_, afterReleaseErr := second.Trigger("sweep", false)
+	_ = second.Stop(context.Background())
+
+	// Assert
+	helpers.AssertNoError(t, firstErr, "First replica should run the job")
+	helpers.AssertEqual(t, notify.CodeJobLocked, notify.CodeOf(secondErr), "Second replica should be skipped")
+	helpers.AssertEqual(t, int64(1), second.Jobs()[0].LockSkips, "Skip should be counted")
+	helpers.AssertNoError(t, afterReleaseErr, "Lease should be free once the first run ends")
+	helpers.AssertEqual(t, int32(1), observer.lockSkips.Load(), "Observer should be told about the skip")
+	helpers.AssertEqual(t, int32(1), observer.finished.Load(), "Observer should be told about the finished run")
+}
+
+func TestScheduler_RunsEachSlotOnceAcrossReplicas(t *testing.T) {
+	// Arrange
+	locker := lock.NewMemoryLocker()
+	recorder := repository.NewMemoryJobRunRepository()
+	var runs atomic.Int32
+	newReplica := func() *scheduler.Scheduler {
+		replica := scheduler.New(scheduler.Options{Location: time.UTC, Locker: locker, Recorder: recorder})
+		_ = replica.Register(scheduler.Job{
+			Name:       "sweep",
+			Schedule:   "@yearly",
+			RunOnStart: true,
+			Run: func(ctx context.Context, run *entity.JobRun) error {
+				runs.Add(1)
+				return nil
+			},
+		})
+		return replica
+	}
+	first, second := newReplica(), newReplica()
+
+	// Act
+	first.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	_ = first.Stop(context.Background())
+	second.Start(context.Background())
+	time.Sleep(50 * time.Millisecond)
+	_ = second.Stop(context.Background())
+
+	// Assert
+	helpers.AssertEqual(t, int32(1), runs.Load(), "The slot should run on a single replica")
+	recorded, _ := recorder.FindByJob(context.Background(), "sweep", 10)
+	helpers.AssertEqual(t, 1, len(*recorded), "A single run should be recorded")
+	slot := time.Date(time.Now().UTC().Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	helpers.AssertEqual(t, true, (*recorded)[0].ScheduledFor != nil && (*recorded)[0].ScheduledFor.Equal(slot), "Run should record its slot")
+	helpers.AssertEqual(t, int64(0), second.Jobs()[0].LockSkips, "The lease was free, so the history should skip the slot")
+}
+
+func TestScheduler_Heartbeat(t *testing.T) {
+	// Arrange
+	jobScheduler := scheduler.New(scheduler.Options{Location: time.UTC, HeartbeatInterval: 10 * time.Millisecond})
+	beforeStart := jobScheduler.Heartbeat()
+
+	// Act
+	jobScheduler.Start(context.Background())
+	first := jobScheduler.Heartbeat()
+	time.Sleep(50 * time.Millisecond)
+	later := jobScheduler.Heartbeat()
+	_ = jobScheduler.Stop(context.Background())
+
+	// Assert
+	helpers.AssertEqual(t, true, beforeStart.IsZero(), "A scheduler not started should have no heartbeat")
+	helpers.AssertEqual(t, false, first.IsZero(), "Start should beat at once")
+	helpers.AssertEqual(t, true, later.After(first), "The heartbeat should be refreshed while running")
+	helpers.AssertEqual(t, true, jobScheduler.Heartbeat().IsZero(), "Stop should clear the heartbeat")
+}
@@ -3,11 +3,16 @@ package services_test
 import (
 	entity "PocGo/internal/domain/entities"
 	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/domain/values"
+	repository "PocGo/internal/repositories"
 	service "PocGo/internal/services"
 	"PocGo/tests/helpers"
 	"PocGo/tests/mocks"
+	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestUserService_GetAll(t *testing.T) {
@@ -56,10 +61,10 @@ func TestUserService_GetAll(t *testing.T) {
 			//Arrage
 			mockRepo := mocks.NewUserRepositoryMock()
 			tt.mockSetup(mockRepo)
-			userService := service.NewUserService(mockRepo)
+			userService := service.NewUserService(mockRepo, nil, nil)
 
 			//Act
-			users, err := userService.GetAll(tt.date)
+			users, err := userService.GetAll(context.Background(), tt.date)
 
 			//Assert
 			if tt.expectedError != nil {
@@ -87,7 +92,7 @@ func TestUserService_GetById(t *testing.T) {
 			name:   "Success - User found",
 			userID: "1",
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				mock.FindByIdFunc = func(id string) (*entity.User, error) {
+				mock.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
 					return helpers.CreateTestUser("1"), nil
 				}
 			},
@@ -98,7 +103,7 @@ func TestUserService_GetById(t *testing.T) {
 			name:   "Error - User not found",
 			userID: "999",
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				mock.FindByIdFunc = func(id string) (*entity.User, error) {
+				mock.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
 					return nil, notify.CreateSimpleNotification(
 						notify.NotFound,
 						errors.New("user not found"))
@@ -111,7 +116,7 @@ func TestUserService_GetById(t *testing.T) {
 			name:   "Error - Database error",
 			userID: "1",
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				mock.FindByIdFunc = func(id string) (*entity.User, error) {
+				mock.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
 					return nil, notify.CreateSimpleNotification(
 						notify.FindErrorRepository,
 						errors.New("database connection error"))
@@ -127,10 +132,10 @@ func TestUserService_GetById(t *testing.T) {
 			// Arrange
 			mockRepo := mocks.NewUserRepositoryMock()
 			tt.mockSetup(mockRepo)
-			userService := service.NewUserService(mockRepo)
+			userService := service.NewUserService(mockRepo, nil, nil)
 
 			// Act
-			user, err := userService.GetById(tt.userID)
+			user, err := userService.GetById(context.Background(), helpers.TestGuid(tt.userID).String())
 
 			// Assert
 			if tt.expectedError != nil {
@@ -145,71 +150,308 @@ func TestUserService_GetById(t *testing.T) {
 			}
 
 			helpers.AssertEqual(t, 1, len(mockRepo.FindByIdCalls), "Repository FindById should be called once")
-			helpers.AssertEqual(t, tt.userID, mockRepo.FindByIdCalls[0], "Repository FindById should be called with the correct ID")
+			helpers.AssertEqual(t, helpers.TestGuid(tt.userID), mockRepo.FindByIdCalls[0], "Repository FindById should be called with the correct ID")
+		})
+	}
+}
+
+func TestUserService_MalformedId(t *testing.T) {
+	tests := []struct {
+		name string
+		call func(userService service.UserService, id string) error
+	}{
+		{name: "GetById", call: func(userService service.UserService, id string) error {
+			_, err := userService.GetById(context.Background(), id)
+			return err
+		}},
+		{name: "Delete", call: func(userService service.UserService, id string) error {
+			return userService.Delete(context.Background(), id)
+		}},
+		{name: "Restore", call: func(userService service.UserService, id string) error {
+			_, err := userService.Restore(context.Background(), id)
+			return err
+		}},
+		{name: "Reactivate", call: func(userService service.UserService, id string) error {
+			_, err := userService.Reactivate(context.Background(), id)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			userService := service.NewUserService(mockRepo, nil, nil)
+
+			// Act
+			err := tt.call(userService, "1 OR 1=1")
+
+			// Assert
+			helpers.AssertEqual(t, notify.CodeNotFound, notify.CodeOf(err), "Malformed ID should be NOT_FOUND")
+			helpers.AssertEqual(t, 0, len(mockRepo.FindByIdCalls)+len(mockRepo.DeleteCalls)+len(mockRepo.RestoreCalls)+len(mockRepo.ReactivateCalls),
+				"Repository should not be called")
 		})
 	}
 }
 
 func TestUserService_UpdateOldUsersStatus(t *testing.T) {
 	tests := []struct {
-		name             string
-		mockSetup        func(*mocks.UserRepositoryMock)
-		expectedCount    int
-		expectedError    error
-		expectedStatuses map[string]int
+		name           string
+		options        service.SweepOptions
+		repoResult     repository.BatchResult
+		repoError      error
+		expectedMonths int
+		expectedCode   string
 	}{
 		{
-			name: "Success - Update multiple users",
-			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				users := helpers.CreateTestUsers(3)
-				mock.FindOldUsersFunc = func() (*[]entity.User, error) {
-					return users, nil
-				}
-				mock.UpdateStatusFunc = func(id string, status int) error {
-					return nil
-				}
+			name:           "Success - Defaults when options are empty",
+			options:        service.SweepOptions{},
+			repoResult:     repository.BatchResult{Processed: 3, Updated: []string{"1", "3"}, Skipped: []string{"2"}},
+			expectedMonths: service.DefaultInactiveAfterMonths,
+		},
+		{
+			name:           "Success - Configured threshold, batch size and dry run",
+			options:        service.SweepOptions{InactiveAfterMonths: 12, BatchSize: 50, DryRun: true},
+			repoResult:     repository.BatchResult{Processed: 1, Updated: []string{"1"}},
+			expectedMonths: 12,
+		},
+		{
+			name:    "Partial Success - Failed chunk is reported",
+			options: service.SweepOptions{},
+			repoResult: repository.BatchResult{
+				Processed: 2,
+				Updated:   []string{"1"},
+				Failed:    []entity.ItemError{{ItemID: "2", Message: "deadlock"}},
 			},
-			expectedCount:    3,
-			expectedError:    nil,
-			expectedStatuses: map[string]int{"1": 2, "2": 2, "3": 2},
+			expectedMonths: service.DefaultInactiveAfterMonths,
 		},
 		{
-			name: "Success - No users to update",
+			name:           "Error - Failed to read users",
+			options:        service.SweepOptions{},
+			repoResult:     repository.BatchResult{Processed: 2, Updated: []string{"1", "2"}},
+			repoError:      notify.CreateSimpleNotification(notify.FindErrorRepository, errors.New("database error")),
+			expectedMonths: service.DefaultInactiveAfterMonths,
+			expectedCode:   notify.CodeFindError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.UpdateStatusBatchFunc = func(batch repository.StatusBatch) (repository.BatchResult, error) {
+				return tt.repoResult, tt.repoError
+			}
+			userService := service.NewUserService(mockRepo, nil, nil)
+			before := time.Now().AddDate(0, -tt.expectedMonths, 0)
+
+			// Act
+			result, err := userService.UpdateOldUsersStatus(context.Background(), tt.options)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+			}
+			helpers.AssertEqual(t, tt.repoResult, result, "Result should be returned even on error")
+
+			helpers.AssertEqual(t, 1, len(mockRepo.UpdateStatusBatchCalls), "UpdateStatusBatch should be called once")
+			batch := mockRepo.UpdateStatusBatchCalls[0]
+			helpers.AssertEqual(t, []entity.UserStatus{entity.StatusActive}, batch.From, "Only states that may become inactive should be selected")
+			helpers.AssertEqual(t, entity.StatusInactive, batch.To, "Users should become inactive")
+			helpers.AssertEqual(t, tt.options.BatchSize, batch.Size, "Batch size should be passed through")
+			helpers.AssertEqual(t, tt.options.DryRun, batch.DryRun, "Dry run should be passed through")
+			helpers.AssertEqual(t, true, batch.CreatedBefore.Sub(before) < time.Minute && !batch.CreatedBefore.Before(before), "Threshold should be the configured number of months ago")
+		})
+	}
+}
+
+func TestUserService_Update_StatusTransitions(t *testing.T) {
+	tests := []struct {
+		name           string
+		current        entity.UserStatus
+		requested      entity.UserStatus
+		expectedStatus entity.UserStatus
+		expectedCode   string
+	}{
+		{name: "Success - Status omitted keeps current", current: entity.StatusBlocked, requested: entity.StatusUndefined, expectedStatus: entity.StatusBlocked},
+		{name: "Success - Active to blocked", current: entity.StatusActive, requested: entity.StatusBlocked, expectedStatus: entity.StatusBlocked},
+		{name: "Success - Pending to active", current: entity.StatusPending, requested: entity.StatusActive, expectedStatus: entity.StatusActive},
+		{name: "Error - Blocked to inactive", current: entity.StatusBlocked, requested: entity.StatusInactive, expectedCode: notify.CodeInvalidTransition},
+		{name: "Error - Active to pending", current: entity.StatusActive, requested: entity.StatusPending, expectedCode: notify.CodeInvalidTransition},
+		{name: "Error - Deleted through update", current: entity.StatusActive, requested: entity.StatusDeleted, expectedCode: notify.CodeInvalidTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
+				user := helpers.CreateTestUser("1")
+				user.Status = tt.current
+				return user, nil
+			}
+			mockRepo.UpdateFunc = func(user *entity.User) error {
+				return nil
+			}
+			userService := service.NewUserService(mockRepo, nil, nil)
+			toUpdate := &entity.User{ID: helpers.TestGuid("1"), Status: tt.requested}
+
+			// Act
+			err := userService.Update(context.Background(), toUpdate)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+				helpers.AssertEqual(t, 0, len(mockRepo.UpdateCalls), "Repository should not be called")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, tt.expectedStatus, toUpdate.Status, "Status should match expected")
+			}
+		})
+	}
+}
+
+func TestUserService_Update_Version(t *testing.T) {
+	tests := []struct {
+		name         string
+		stored       int64
+		requested    int64
+		repoError    error
+		expectedCode string
+	}{
+		{name: "Success - Matching version", stored: 3, requested: 3},
+		{name: "Error - Stale version", stored: 4, requested: 3, expectedCode: notify.CodePreconditionFailed},
+		{name: "Error - Changed before the write", stored: 3, requested: 3,
+			repoError: notify.CreateCustomNotification(notify.PreconditionFailed, "", "versão 3"), expectedCode: notify.CodePreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
+				user := helpers.CreateTestUser("1")
+				user.Version = tt.stored
+				return user, nil
+			}
+			mockRepo.UpdateFunc = func(user *entity.User) error {
+				return tt.repoError
+			}
+			userService := service.NewUserService(mockRepo, nil, nil)
+
+			// Act
+			err := userService.Update(context.Background(), &entity.User{ID: helpers.TestGuid("1"), Name: "Renamed", Version: tt.requested})
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+			}
+			if tt.stored != tt.requested {
+				helpers.AssertEqual(t, 0, len(mockRepo.UpdateCalls), "Repository should not be called")
+			}
+		})
+	}
+}
+
+func TestUserService_Update_Email(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         string
+		taken         bool
+		expectedEmail string
+		expectedCode  string
+	}{
+		{name: "Success - Email is canonicalized", email: " Ana@Exämple.COM ", expectedEmail: "Ana@xn--exmple-cua.com"},
+		{name: "Success - Case change keeps the own login", email: "USER1@example.com", taken: true, expectedEmail: "USER1@example.com"},
+		{name: "Error - Invalid email", email: "ana@localhost", expectedCode: notify.CodeInvalidData},
+		{name: "Error - Email of another user", email: "other@example.com", taken: true, expectedCode: notify.CodeConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
+				return helpers.CreateTestUser("1"), nil
+			}
+			mockRepo.ExistsByEmailFunc = func(email string) (bool, error) {
+				return tt.taken, nil
+			}
+			mockRepo.UpdateFunc = func(user *entity.User) error {
+				return nil
+			}
+			userService := service.NewUserService(mockRepo, nil, nil)
+			toUpdate := &entity.User{ID: helpers.TestGuid("1"), Email: tt.email}
+
+			// Act
+			err := userService.Update(context.Background(), toUpdate)
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+				helpers.AssertEqual(t, 0, len(mockRepo.UpdateCalls), "Repository should not be called")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, tt.expectedEmail, toUpdate.Email, "Email should be stored in canonical form")
+			}
+		})
+	}
+}
+
+func TestUserService_Create(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         *entity.User
+		mockSetup    func(*mocks.UserRepositoryMock)
+		expectedCode string
+		expectCreate bool
+	}{
+		{
+			name: "Success - User created as active",
+			user: &entity.User{Name: " New User ", Email: "new@example.com"},
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				mock.FindOldUsersFunc = func() (*[]entity.User, error) {
-					return &[]entity.User{}, nil
+				mock.CreateFunc = func(user *entity.User) error {
+					user.ID = helpers.TestGuid("100")
+					return nil
 				}
 			},
-			expectedCount: 0,
-			expectedError: nil,
+			expectCreate: true,
 		},
 		{
-			name: "Partial Success - Some updates fail",
+			name:         "Error - Invalid email",
+			user:         &entity.User{Name: "New User", Email: "new@localhost"},
+			mockSetup:    func(mock *mocks.UserRepositoryMock) {},
+			expectedCode: notify.CodeInvalidData,
+		},
+		{
+			name:         "Error - Missing email",
+			user:         &entity.User{Name: "New User"},
+			mockSetup:    func(mock *mocks.UserRepositoryMock) {},
+			expectedCode: notify.CodeInvalidData,
+		},
+		{
+			name: "Error - Duplicate email",
+			user: &entity.User{Name: "New User", Email: "taken@example.com"},
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				users := helpers.CreateTestUsers(3)
-				mock.FindOldUsersFunc = func() (*[]entity.User, error) {
-					return users, nil
-				}
-				mock.UpdateStatusFunc = func(id string, status int) error {
-					if id == "2" {
-						return errors.New("update failed")
-					}
-					return nil
+				mock.ExistsByEmailFunc = func(email string) (bool, error) {
+					return email == "taken@example.com", nil
 				}
 			},
-			expectedCount:    2,
-			expectedError:    nil,
-			expectedStatuses: map[string]int{"1": 2, "2": 2, "3": 2},
+			expectedCode: notify.CodeConflict,
 		},
 		{
-			name: "Error - Failed to find old users",
+			name: "Error - Duplicate login",
+			user: &entity.User{Name: "New User", Email: "Taken@Example.com"},
 			mockSetup: func(mock *mocks.UserRepositoryMock) {
-				mock.FindOldUsersFunc = func() (*[]entity.User, error) {
-					return nil, errors.New("database error")
+				mock.ExistsByLoginFunc = func(normalizedLogin string) (bool, error) {
+					return normalizedLogin == "TAKEN@EXAMPLE.COM", nil
 				}
 			},
-			expectedCount: 0,
-			expectedError: errors.New("database error"),
+			expectedCode: notify.CodeConflict,
 		},
 	}
 
@@ -218,27 +460,75 @@ func TestUserService_UpdateOldUsersStatus(t *testing.T) {
 			// Arrange
 			mockRepo := mocks.NewUserRepositoryMock()
 			tt.mockSetup(mockRepo)
-			userService := service.NewUserService(mockRepo)
+			userService := service.NewUserService(mockRepo, nil, nil)
 
 			// Act
-			count, err := userService.UpdateOldUsersStatus()
+			err := userService.Create(context.Background(), tt.user)
 
 			// Assert
-			if tt.expectedError != nil {
-				helpers.AssertError(t, err, "Should return an error")
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
 			} else {
 				helpers.AssertNoError(t, err, "Should not return an error")
-				helpers.AssertEqual(t, tt.expectedCount, count, "Updated count should match expected")
+				helpers.AssertEqual(t, helpers.TestGuid("100"), tt.user.ID, "ID should come from the repository")
+				helpers.AssertEqual(t, "New User", tt.user.Name, "Name should be trimmed")
+				helpers.AssertEqual(t, entity.StatusActive, tt.user.Status, "New users should be active")
 			}
+			helpers.AssertEqual(t, tt.expectCreate, len(mockRepo.CreateCalls) == 1, "Repository Create call should match")
+		})
+	}
+}
+
+func TestUserService_Create_KeepsRepositoryConflict(t *testing.T) {
+	// Arrange
+	mockRepo := mocks.NewUserRepositoryMock()
+	mockRepo.CreateFunc = func(user *entity.User) error {
+		return notify.CreateCustomNotification(notify.Conflict, "", "login duplicado")
+	}
+	userService := service.NewUserService(mockRepo, nil, nil)
 
-			helpers.AssertEqual(t, 1, mockRepo.FindOldUsersCalls, "FindOldUsers should be called once")
+	// Act
+	err := userService.Create(context.Background(), &entity.User{Name: "New User", Email: "new@example.com"})
 
-			if tt.expectedStatuses != nil {
-				for id, status := range tt.expectedStatuses {
-					if actualStatus, ok := mockRepo.UpdateStatusCalls[id]; ok {
-						helpers.AssertEqual(t, status, actualStatus, "Status for user "+id+" should match")
-					}
-				}
+	// Assert
+	helpers.AssertEqual(t, notify.CodeConflict, notify.CodeOf(err), "Error code should match")
+	helpers.AssertEqual(t, true, strings.Contains(err.Error(), "login duplicado"), "The repository detail should be kept")
+}
+
+func TestUserService_Reactivate(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       entity.UserStatus
+		expectedCode string
+	}{
+		{name: "Success - Inactive user", status: entity.StatusInactive},
+		{name: "Error - Active user", status: entity.StatusActive, expectedCode: notify.CodeInvalidState},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := mocks.NewUserRepositoryMock()
+			mockRepo.FindByIdFunc = func(id values.GUID) (*entity.User, error) {
+				user := helpers.CreateTestUser("1")
+				user.Status = tt.status
+				return user, nil
+			}
+			mockRepo.ReactivateFunc = func(id values.GUID) error {
+				return nil
+			}
+			userService := service.NewUserService(mockRepo, nil, nil)
+
+			// Act
+			user, err := userService.Reactivate(context.Background(), helpers.TestGuid("1").String())
+
+			// Assert
+			if tt.expectedCode != "" {
+				helpers.AssertEqual(t, tt.expectedCode, notify.CodeOf(err), "Error code should match")
+				helpers.AssertEqual(t, 0, len(mockRepo.ReactivateCalls), "Repository should not be called")
+			} else {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				helpers.AssertEqual(t, entity.StatusActive, user.Status, "User should be active")
 			}
 		})
 	}
@@ -0,0 +1,130 @@
+package tracing_test
+
+import (
+	config "PocGo/internal/configuration"
+	provider "PocGo/internal/configuration/providers"
+	repository "PocGo/internal/repositories"
+	applicationServer "PocGo/internal/server"
+	service "PocGo/internal/services"
+	"PocGo/internal/tracing"
+	"PocGo/tests/helpers"
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	httpclient "net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	incomingTraceId = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceParent     = "00-" + incomingTraceId + "-00f067aa0ba902b7-01"
+)
+
+// record installs a tracer provider keeping the finished spans in memory for the rest of the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracerProvider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+		_ = tracerProvider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func spansByName(recorder *tracetest.SpanRecorder) map[string]sdkTrace.ReadOnlySpan {
+	spans := map[string]sdkTrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func TestTracing_HttpRequest(t *testing.T) {
+	// Arrange
+	recorder := record(t)
+	repos, _ := repository.NewRepositories(provider.DriverMemory, nil, nil, nil)
+	configuration := &config.Config{App: &provider.AppConfig{Environment: "test"}}
+	handler := applicationServer.NewServer(service.NewServices(repos, nil, nil, nil), configuration, nil, nil, nil, nil, nil).Handler()
+	list := httptest.NewRequest(httpclient.MethodGet, "/api/v1/users", nil)
+	list.Header.Set("traceparent", traceParent)
+	missing := httptest.NewRequest(httpclient.MethodGet, "/api/v1/users/"+helpers.TestGuid("9").String(), nil)
+	missing.Header.Set("traceparent", traceParent)
+
+	// Act
+	handler.ServeHTTP(httptest.NewRecorder(), list)
+	handler.ServeHTTP(httptest.NewRecorder(), missing)
+
+	// Assert
+	spans := spansByName(recorder)
+	for _, name := range []string{"GET /api/v1/users", "userService.List", "json.Encode", "GET /api/v1/users/{id}", "userService.GetById"} {
+		span, exists := spans[name]
+		helpers.AssertEqual(t, true, exists, "Should record the span "+name)
+		if exists {
+			helpers.AssertEqual(t, incomingTraceId, span.SpanContext().TraceID().String(), name+" should continue the incoming trace")
+		}
+	}
+
+	server := spans["GET /api/v1/users"]
+	helpers.AssertEqual(t, server.SpanContext().SpanID(), spans["userService.List"].Parent().SpanID(), "Service span should be a child of the request")
+	helpers.AssertEqual(t, server.SpanContext().SpanID(), spans["json.Encode"].Parent().SpanID(), "Encoding span should be a child of the request")
+	helpers.AssertEqual(t, codes.Unset, spans["GET /api/v1/users/{id}"].Status().Code, "A 404 is not a server error")
+}
+
+func TestTracing_Statement(t *testing.T) {
+	// Arrange
+	recorder := record(t)
+
+	// Act
+	_, span := tracing.StartStatement(context.Background(), "sqlite", "select id from users where login = 'admin'")
+	tracing.End(span, context.DeadlineExceeded)
+
+	// Assert
+	spans := recorder.Ended()
+	helpers.AssertEqual(t, 1, len(spans), "Should record one span")
+	helpers.AssertEqual(t, "SELECT", spans[0].Name(), "Span should be named by the operation")
+	helpers.AssertEqual(t, codes.Error, spans[0].Status().Code, "Failed statements should be marked as errors")
+
+	attributes := map[string]string{}
+	for _, attribute := range spans[0].Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.Emit()
+	}
+	helpers.AssertEqual(t, "sqlite", attributes["db.system"], "Should carry the database system")
+	helpers.AssertEqual(t, "select id from users where login = ?", attributes["db.query.text"], "Literals should not reach the trace")
+}
+
+func TestSanitizeQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "Placeholders are kept", query: "SELECT * FROM Users WHERE Id = @p1", expected: "SELECT * FROM Users WHERE Id = @p1"},
+		{name: "String literal", query: "UPDATE Users SET Name = 'João' WHERE Id = ?", expected: "UPDATE Users SET Name = ? WHERE Id = ?"},
+		{name: "Escaped quote", query: "SELECT 'it''s' AS Value", expected: "SELECT ? AS Value"},
+		{name: "Numbers", query: "SELECT TOP 10 * FROM Users WHERE Status = 2.5", expected: "SELECT TOP ? * FROM Users WHERE Status = ?"},
+		{name: "Digits in identifiers", query: "SELECT Col1 FROM Table2", expected: "SELECT Col1 FROM Table2"},
+		{name: "Whitespace", query: "\n\tSELECT  Id\n  FROM Users \n", expected: "SELECT Id FROM Users"},
+		{name: "Unterminated string", query: "SELECT 'open", expected: "SELECT ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sanitized := tracing.SanitizeQuery(tt.query)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expected, sanitized, "Query should be sanitized")
+		})
+	}
+}
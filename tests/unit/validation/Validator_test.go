@@ -0,0 +1,114 @@
+package validation_test
+
+import (
+	notify "PocGo/internal/domain/notification"
+	"PocGo/internal/validation"
+	"PocGo/tests/helpers"
+	setJson "encoding/json"
+	"strings"
+	"testing"
+)
+
+type embeddedPayload struct {
+	Email string `json:"email" validate:"omitempty,max=20,email"`
+}
+
+type payload struct {
+	ID     string             `json:"id" validate:"required,guid"`
+	Name   string             `json:"name,omitempty" validate:"max=5"`
+	Kind   string             `json:"kind" validate:"omitempty,oneof=admin user"`
+	Status setJson.RawMessage `json:"status" validate:"omitempty,status"`
+	embeddedPayload
+}
+
+func TestValidate(t *testing.T) {
+	validId := "6F9619FF-8B86-D011-B42D-00C04FC964FF"
+
+	tests := []struct {
+		name     string
+		value    any
+		expected []notify.FieldError
+	}{
+		{name: "Valid payload", value: payload{ID: validId, Name: "Ana", Kind: "user", Status: setJson.RawMessage(`"active"`)}},
+		{name: "Empty optional fields are skipped", value: &payload{ID: validId, Status: setJson.RawMessage(`null`)}},
+		{name: "Numeric status", value: payload{ID: validId, Status: setJson.RawMessage(`2`)}},
+		{
+			name:     "Missing required field",
+			value:    payload{},
+			expected: []notify.FieldError{{Field: "id", Message: "é obrigatório"}},
+		},
+		{
+			name: "Every failure is reported in field order",
+			value: payload{
+				ID:              "123",
+				Name:            "Joãozinho",
+				Kind:            "root",
+				Status:          setJson.RawMessage(`"archived"`),
+				embeddedPayload: embeddedPayload{Email: "Ana <a@b.com>"},
+			},
+			expected: []notify.FieldError{
+				{Field: "id", Message: "deve ser um GUID no formato xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"},
+				{Field: "name", Message: "deve ter no máximo 5 caracteres"},
+				{Field: "kind", Message: "deve ser um de: admin, user"},
+				{Field: "status", Message: "deve ser um de: pending, active, inactive, blocked, deleted"},
+				{Field: "email", Message: "deve ser um email válido"},
+			},
+		},
+		{
+			name:     "Length counts characters, not bytes",
+			value:    payload{ID: validId, Name: "Ação!"},
+			expected: nil,
+		},
+		{
+			name:     "First failing rule of a field wins",
+			value:    payload{ID: validId, embeddedPayload: embeddedPayload{Email: strings.Repeat("a", 21)}},
+			expected: []notify.FieldError{{Field: "email", Message: "deve ter no máximo 20 caracteres"}},
+		},
+		{name: "Non-struct values are ignored", value: "text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			failures := validation.Validate(tt.value)
+
+			// Assert
+			helpers.AssertEqual(t, tt.expected, failures, "Failures should match")
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []notify.FieldError
+		noFields bool
+	}{
+		{name: "Valid body", body: `{"id":"6F9619FF-8B86-D011-B42D-00C04FC964FF","email":"ana@example.com"}`},
+		{name: "Unknown member", body: `{"id":"6F9619FF-8B86-D011-B42D-00C04FC964FF","role":"admin"}`, expected: []notify.FieldError{{Field: "role", Message: "campo desconhecido"}}},
+		{name: "Wrong type", body: `{"id":7}`, expected: []notify.FieldError{{Field: "id", Message: "tipo inválido, esperado string"}}},
+		{name: "Rule failure", body: `{"id":"x"}`, expected: []notify.FieldError{{Field: "id", Message: "deve ser um GUID no formato xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"}}},
+		{name: "Malformed JSON", body: `{"id":`, noFields: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var target payload
+
+			// Act
+			err := validation.DecodeJSON(strings.NewReader(tt.body), &target, "Usuário")
+
+			// Assert
+			if tt.expected == nil && !tt.noFields {
+				helpers.AssertNoError(t, err, "Should not return an error")
+				return
+			}
+
+			helpers.AssertEqual(t, notify.CodeInvalidData, notify.CodeOf(err), "Error should be INVALID_DATA")
+			domainError := err.(*notify.DomainError)
+			helpers.AssertEqual(t, tt.expected, domainError.Fields, "Field errors should match")
+		})
+	}
+}
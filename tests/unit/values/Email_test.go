@@ -0,0 +1,44 @@
+package values_test
+
+import (
+	"PocGo/internal/domain/values"
+	"PocGo/tests/helpers"
+	"testing"
+)
+
+func TestParseEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expected      values.Email
+		expectedLogin string
+		expectError   bool
+	}{
+		{name: "Bare address", raw: "ana@example.com", expected: "ana@example.com", expectedLogin: "ANA@EXAMPLE.COM"},
+		{name: "Surrounding spaces are trimmed", raw: "  ana@example.com ", expected: "ana@example.com", expectedLogin: "ANA@EXAMPLE.COM"},
+		{name: "Domain is lower-cased, local part keeps its case", raw: "Ana@Example.COM", expected: "Ana@example.com", expectedLogin: "ANA@EXAMPLE.COM"},
+		{name: "Internationalized domain becomes ASCII", raw: "Ana@Exämple.COM", expected: "Ana@xn--exmple-cua.com", expectedLogin: "ANA@XN--EXMPLE-CUA.COM"},
+		{name: "Display name is rejected", raw: "Ana <ana@example.com>", expectError: true},
+		{name: "Missing domain", raw: "ana@", expectError: true},
+		{name: "Domain without a dot", raw: "ana@localhost", expectError: true},
+		{name: "Invalid domain label", raw: "ana@exa_mple.com", expectError: true},
+		{name: "Empty", raw: "   ", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			email, err := values.ParseEmail(tt.raw)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertError(t, err, "Should reject the address")
+				return
+			}
+			helpers.AssertNoError(t, err, "Should accept the address")
+			helpers.AssertEqual(t, tt.expected, email, "Email should be canonical")
+			helpers.AssertEqual(t, tt.expectedLogin, values.LoginFromEmail(email).Normalized(), "Normalized login should match")
+			helpers.AssertEqual(t, tt.expectedLogin, email.Normalized(), "Email and login should normalize alike")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package values_test
+
+import (
+	"PocGo/internal/domain/values"
+	"PocGo/tests/helpers"
+	setJson "encoding/json"
+	"testing"
+)
+
+const canonicalGuid = "6F9619FF-8B86-D011-B42D-00C04FC964FF"
+
+// sqlServerGuid is canonicalGuid as SQL Server stores it, with the first three groups little-endian.
+var sqlServerGuid = []byte{0xFF, 0x19, 0x96, 0x6F, 0x86, 0x8B, 0x11, 0xD0, 0xB4, 0x2D, 0x00, 0xC0, 0x4F, 0xC9, 0x64, 0xFF}
+
+func TestParseGUID(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		expectError bool
+	}{
+		{name: "Hyphenated", raw: canonicalGuid},
+		{name: "Lower case", raw: "6f9619ff-8b86-d011-b42d-00c04fc964ff"},
+		{name: "Braces", raw: "{6F9619FF-8B86-D011-B42D-00C04FC964FF}"},
+		{name: "Parentheses", raw: "(6F9619FF-8B86-D011-B42D-00C04FC964FF)"},
+		{name: "Digits only", raw: "6F9619FF8B86D011B42D00C04FC964FF"},
+		{name: "URN", raw: "urn:uuid:6f9619ff-8b86-d011-b42d-00c04fc964ff"},
+		{name: "Mismatched brackets", raw: "{6F9619FF-8B86-D011-B42D-00C04FC964FF)", expectError: true},
+		{name: "Misplaced hyphen", raw: "6F9619FF8-B86-D011-B42D-00C04FC964FF", expectError: true},
+		{name: "Not hexadecimal", raw: "6F9619FF-8B86-D011-B42D-00C04FC964FG", expectError: true},
+		{name: "Too short", raw: "1", expectError: true},
+		{name: "Empty", raw: "", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			guid, err := values.ParseGUID(tt.raw)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertError(t, err, "Should reject the GUID")
+				return
+			}
+			helpers.AssertNoError(t, err, "Should accept the GUID")
+			helpers.AssertEqual(t, canonicalGuid, guid.String(), "String should be the upper-case hyphenated form")
+		})
+	}
+}
+
+func TestGUID_SqlServerByteOrder(t *testing.T) {
+	// Arrange
+	guid := values.MustParseGUID(canonicalGuid)
+
+	// Act
+	var scanned values.GUID
+	err := scanned.Scan(sqlServerGuid)
+
+	// Assert
+	helpers.AssertNoError(t, err, "Should read a uniqueidentifier")
+	helpers.AssertEqual(t, guid, scanned, "Mixed-endian bytes should give the GUID SQL Server displays")
+	helpers.AssertEqual(t, sqlServerGuid, guid.SqlServerBytes(), "Conversion back should restore the stored order")
+
+	_, err = values.GUIDFromSqlServer(sqlServerGuid[:8])
+	helpers.AssertError(t, err, "Short slices should be rejected instead of panicking")
+}
+
+func TestGUID_Scan(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         any
+		expected    values.GUID
+		expectError bool
+	}{
+		{name: "Text, as in SQLite", src: "6f9619ff-8b86-d011-b42d-00c04fc964ff", expected: values.MustParseGUID(canonicalGuid)},
+		{name: "Text as bytes", src: []byte(canonicalGuid), expected: values.MustParseGUID(canonicalGuid)},
+		{name: "NULL", src: nil},
+		{name: "Short bytes", src: []byte{0x01, 0x02}, expectError: true},
+		{name: "Unsupported type", src: int64(1), expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			var guid values.GUID
+			err := guid.Scan(tt.src)
+
+			// Assert
+			if tt.expectError {
+				helpers.AssertError(t, err, "Should fail to scan")
+				return
+			}
+			helpers.AssertNoError(t, err, "Should scan")
+			helpers.AssertEqual(t, tt.expected, guid, "GUID should match")
+		})
+	}
+}
+
+func TestGUID_JSON(t *testing.T) {
+	// Arrange
+	type document struct {
+		ID values.GUID `json:"id"`
+	}
+
+	// Act
+	body, marshalErr := setJson.Marshal(document{ID: values.MustParseGUID(canonicalGuid)})
+	var decoded document
+	unmarshalErr := setJson.Unmarshal([]byte(`{"id":"{6f9619ff-8b86-d011-b42d-00c04fc964ff}"}`), &decoded)
+	invalidErr := setJson.Unmarshal([]byte(`{"id":"1"}`), &decoded)
+	value, valueErr := decoded.ID.Value()
+
+	// Assert
+	helpers.AssertNoError(t, marshalErr, "Should marshal")
+	helpers.AssertEqual(t, `{"id":"`+canonicalGuid+`"}`, string(body), "JSON should hold the canonical form")
+	helpers.AssertNoError(t, unmarshalErr, "Should unmarshal any canonical form")
+	helpers.AssertEqual(t, values.MustParseGUID(canonicalGuid), decoded.ID, "Decoded GUID should match")
+	helpers.AssertError(t, invalidErr, "Invalid GUID should be rejected")
+	helpers.AssertNoError(t, valueErr, "Should produce a driver value")
+	helpers.AssertEqual(t, any(canonicalGuid), value, "Driver value should be the canonical form")
+}
+
+func TestNewGUID(t *testing.T) {
+	// Act
+	first, second := values.NewGUID(), values.NewGUID()
+
+	// Assert
+	helpers.AssertEqual(t, false, first == second, "GUIDs should be random")
+	helpers.AssertEqual(t, byte('4'), first.String()[14], "GUID should be version 4")
+}